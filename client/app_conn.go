@@ -0,0 +1,67 @@
+/*
+Copyright 2026 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package client
+
+import (
+	"context"
+	"errors"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+// appClientConn proxies calls to the gRPC services of an app through the
+// sidecar.
+type appClientConn struct {
+	conn      grpc.ClientConnInterface
+	appID     string
+	authToken *authToken
+}
+
+// AppClientConn returns a connection to the gRPC services of appID, which the
+// sidecar proxies calls to. Every call carries the app ID and the API token of
+// the client, so generated clients work against it as is:
+//
+//	conn, err := client.AppClientConn("greeter")
+//	greeter := pb.NewGreeterClient(conn)
+func (c *GRPCClient) AppClientConn(appID string) (grpc.ClientConnInterface, error) {
+	if appID == "" {
+		return nil, errors.New("appID required")
+	}
+	if c.connection == nil {
+		return nil, errors.New("client connection closed")
+	}
+	at := c.authToken
+	if at == nil {
+		at = &authToken{}
+	}
+	return &appClientConn{conn: c.connection, appID: appID, authToken: at}, nil
+}
+
+// outgoingContext sets the app ID and API token in the outgoing metadata of
+// ctx, replacing any app ID set by the caller.
+func (c *appClientConn) outgoingContext(ctx context.Context) context.Context {
+	md, _ := metadata.FromOutgoingContext(ctx)
+	md = md.Copy()
+	md.Set(daprAppIDHeader, c.appID)
+	return withAPIToken(metadata.NewOutgoingContext(ctx, md), c.authToken.get())
+}
+
+func (c *appClientConn) Invoke(ctx context.Context, method string, args, reply any, opts ...grpc.CallOption) error {
+	return c.conn.Invoke(c.outgoingContext(ctx), method, args, reply, opts...)
+}
+
+func (c *appClientConn) NewStream(ctx context.Context, desc *grpc.StreamDesc, method string, opts ...grpc.CallOption) (grpc.ClientStream, error) {
+	return c.conn.NewStream(c.outgoingContext(ctx), desc, method, opts...)
+}
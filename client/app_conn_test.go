@@ -0,0 +1,123 @@
+/*
+Copyright 2026 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package client
+
+import (
+	"context"
+	"net"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+// fakeAppServer stands in for the sidecar proxying calls to the health
+// service of an app, and records the metadata of the calls.
+type fakeAppServer struct {
+	grpc_health_v1.UnimplementedHealthServer
+
+	lock sync.Mutex
+	md   []metadata.MD
+}
+
+func (s *fakeAppServer) record(ctx context.Context) {
+	md, _ := metadata.FromIncomingContext(ctx)
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.md = append(s.md, md)
+}
+
+func (s *fakeAppServer) last() metadata.MD {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	return s.md[len(s.md)-1]
+}
+
+func (s *fakeAppServer) Check(ctx context.Context, in *grpc_health_v1.HealthCheckRequest) (*grpc_health_v1.HealthCheckResponse, error) {
+	s.record(ctx)
+	return &grpc_health_v1.HealthCheckResponse{Status: grpc_health_v1.HealthCheckResponse_SERVING}, nil
+}
+
+func (s *fakeAppServer) Watch(in *grpc_health_v1.HealthCheckRequest, stream grpc_health_v1.Health_WatchServer) error {
+	s.record(stream.Context())
+	return stream.Send(&grpc_health_v1.HealthCheckResponse{Status: grpc_health_v1.HealthCheckResponse_SERVING})
+}
+
+func TestAppClientConn(t *testing.T) {
+	ctx := t.Context()
+	app := &fakeAppServer{}
+	s := grpc.NewServer()
+	grpc_health_v1.RegisterHealthServer(s, app)
+	l := bufconn.Listen(testBufSize)
+	go s.Serve(l)
+	defer s.Stop()
+
+	t.Setenv(apiTokenEnvVarName, "")
+	c, err := NewClientWithAddressContext(ctx, "localhost:50001",
+		grpc.WithContextDialer(func(context.Context, string) (net.Conn, error) { return l.Dial() }),
+		grpc.WithTransportCredentials(insecure.NewCredentials()))
+	require.NoError(t, err)
+	defer c.Close()
+
+	conn, err := c.AppClientConn("checkout")
+	require.NoError(t, err)
+	health := grpc_health_v1.NewHealthClient(conn)
+
+	t.Run("unary", func(t *testing.T) {
+		c.WithAuthToken("secret")
+		defer c.WithAuthToken("")
+		callCtx := metadata.AppendToOutgoingContext(ctx, "x-tenant", "acme", daprAppIDHeader, "other")
+		resp, err := health.Check(callCtx, &grpc_health_v1.HealthCheckRequest{})
+		require.NoError(t, err)
+		assert.Equal(t, grpc_health_v1.HealthCheckResponse_SERVING, resp.GetStatus())
+
+		md := app.last()
+		assert.Equal(t, []string{"checkout"}, md.Get(daprAppIDHeader))
+		assert.Equal(t, []string{"secret"}, md.Get(apiTokenKey))
+		assert.Equal(t, []string{"acme"}, md.Get("x-tenant"))
+	})
+
+	t.Run("streaming", func(t *testing.T) {
+		stream, err := health.Watch(ctx, &grpc_health_v1.HealthCheckRequest{})
+		require.NoError(t, err)
+		_, err = stream.Recv()
+		require.NoError(t, err)
+
+		md := app.last()
+		assert.Equal(t, []string{"checkout"}, md.Get(daprAppIDHeader))
+		assert.Empty(t, md.Get(apiTokenKey))
+	})
+
+	_, err = c.AppClientConn("")
+	require.Error(t, err)
+}
+
+func TestWithAPIToken(t *testing.T) {
+	ctx := metadata.AppendToOutgoingContext(t.Context(), traceparentKey, "00-1-2-01")
+	md, _ := metadata.FromOutgoingContext(withAPIToken(ctx, "secret"))
+	assert.Equal(t, []string{"secret"}, md.Get(apiTokenKey))
+	assert.Equal(t, []string{"00-1-2-01"}, md.Get(traceparentKey), "other metadata is kept")
+
+	ctx = metadata.AppendToOutgoingContext(ctx, apiTokenKey, "caller")
+	md, _ = metadata.FromOutgoingContext(withAPIToken(ctx, "secret"))
+	assert.Equal(t, []string{"caller"}, md.Get(apiTokenKey))
+
+	assert.Equal(t, ctx, withAPIToken(ctx, ""))
+}
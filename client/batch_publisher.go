@@ -0,0 +1,386 @@
+/*
+Copyright 2026 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/cenkalti/backoff/v4"
+
+	pb "github.com/dapr/dapr/pkg/proto/runtime/v1"
+)
+
+const (
+	defaultBatchMaxEvents        = 100
+	defaultBatchMaxBytes         = 1 << 20
+	defaultBatchLinger           = 10 * time.Millisecond
+	defaultBatchMaxRetries       = 3
+	defaultBatchRetryInterval    = 100 * time.Millisecond
+	defaultBatchMaxRetryInterval = 5 * time.Second
+)
+
+// ErrBatchPublisherClosed is the error of events published after Close.
+var ErrBatchPublisherClosed = errors.New("batch publisher closed")
+
+// BatchPublisherOptions are the options of a BatchPublisher.
+type BatchPublisherOptions struct {
+	// MaxEvents is the number of buffered events of a topic which triggers a
+	// flush. Defaults to 100.
+	MaxEvents int
+	// MaxBytes is the size of the buffered event data of a topic which
+	// triggers a flush. Larger events are sent in a batch of their own.
+	// Defaults to 1MiB.
+	MaxBytes int
+	// Linger is how long the first buffered event of a topic waits for more
+	// events before the batch is flushed. Defaults to 10ms.
+	Linger time.Duration
+	// MaxRetries is the number of times events failing to publish are sent
+	// again. Negative values disable retries. Defaults to 3.
+	MaxRetries int
+	// RetryInterval is the delay before the first retry. It doubles with every
+	// retry, with jitter, up to MaxRetryInterval. Defaults to 100ms and 5s.
+	RetryInterval    time.Duration
+	MaxRetryInterval time.Duration
+	// Metadata is the metadata of every bulk publish request.
+	Metadata map[string]string
+	// OnResult, if set, is called with the outcome of every event, from the
+	// goroutine which flushed it.
+	OnResult func(result BatchPublishResult)
+}
+
+// BatchPublishResult is the outcome of an event published with a BatchPublisher.
+type BatchPublishResult struct {
+	Topic   string
+	EntryID string
+	// Event is the value passed to Publish.
+	Event interface{}
+	// Err is nil if the event was published.
+	Err error
+}
+
+// PublishFuture is the pending outcome of an event published with a BatchPublisher.
+type PublishFuture struct {
+	entryID string
+	done    chan struct{}
+	err     error
+}
+
+// EntryID returns the bulk publish entry ID of the event.
+func (f *PublishFuture) EntryID() string {
+	return f.entryID
+}
+
+// Done returns a channel which is closed once the event is published or failed.
+func (f *PublishFuture) Done() <-chan struct{} {
+	return f.done
+}
+
+// Err returns the error of the event once Done is closed, and nil before.
+func (f *PublishFuture) Err() error {
+	select {
+	case <-f.done:
+		return f.err
+	default:
+		return nil
+	}
+}
+
+// Wait waits for the event to be published, and returns its error.
+func (f *PublishFuture) Wait(ctx context.Context) error {
+	select {
+	case <-f.done:
+		return f.err
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// batchEntry is a buffered event.
+type batchEntry struct {
+	entry  *pb.BulkPublishRequestEntry
+	event  interface{}
+	future *PublishFuture
+}
+
+// topicBatch is the buffer of a topic.
+type topicBatch struct {
+	entries []*batchEntry
+	bytes   int
+	timer   *time.Timer
+}
+
+// BatchPublisher buffers events per topic and publishes them with
+// BulkPublishEvent once MaxEvents or MaxBytes are reached, or Linger has
+// elapsed. The batches of a topic are sent in order, one at a time. Entries
+// which fail are retried with exponential backoff, and the outcome of every
+// event is reported through its PublishFuture and OnResult.
+type BatchPublisher struct {
+	client     *GRPCClient
+	pubsubName string
+	opts       BatchPublisherOptions
+
+	// ctx bounds the sends of batches, and is canceled when Close gives up.
+	ctx    context.Context
+	cancel context.CancelFunc
+
+	lock    sync.Mutex
+	batches map[string]*topicBatch
+	// sent are closed once the last batch of their topic has been sent, so
+	// the batches of a topic are sent one at a time, in order.
+	sent   map[string]chan struct{}
+	closed bool
+	// sending is the number of batches being sent, and idle is closed
+	// whenever it is zero.
+	sending int
+	idle    chan struct{}
+}
+
+// NewBatchPublisher returns a BatchPublisher for pubsubName. It must be closed
+// to publish the events it buffers.
+func (c *GRPCClient) NewBatchPublisher(pubsubName string, opts BatchPublisherOptions) (*BatchPublisher, error) {
+	if pubsubName == "" {
+		return nil, errors.New("pubsubName name required")
+	}
+	if opts.MaxEvents <= 0 {
+		opts.MaxEvents = defaultBatchMaxEvents
+	}
+	if opts.MaxBytes <= 0 {
+		opts.MaxBytes = defaultBatchMaxBytes
+	}
+	if opts.Linger <= 0 {
+		opts.Linger = defaultBatchLinger
+	}
+	if opts.MaxRetries == 0 {
+		opts.MaxRetries = defaultBatchMaxRetries
+	}
+	if opts.RetryInterval <= 0 {
+		opts.RetryInterval = defaultBatchRetryInterval
+	}
+	if opts.MaxRetryInterval <= 0 {
+		opts.MaxRetryInterval = defaultBatchMaxRetryInterval
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	idle := make(chan struct{})
+	close(idle)
+	return &BatchPublisher{
+		client:     c,
+		pubsubName: pubsubName,
+		opts:       opts,
+		ctx:        ctx,
+		cancel:     cancel,
+		batches:    make(map[string]*topicBatch),
+		sent:       make(map[string]chan struct{}),
+		idle:       idle,
+	}, nil
+}
+
+// Publish buffers data for topicName, and returns the future of its outcome.
+// Data is encoded as with PublishEvents, so a PublishEventsEvent sets the entry
+// ID, content type and metadata of the event. Publish does not block, and ctx
+// is only used to validate data against the schema of the client, if any.
+func (p *BatchPublisher) Publish(ctx context.Context, topicName string, data interface{}) *PublishFuture {
+	future := &PublishFuture{done: make(chan struct{})}
+	if topicName == "" {
+		p.complete(topicName, &batchEntry{event: data, future: future}, errors.New("topic name required"))
+		return future
+	}
+	entry, err := createBulkPublishRequestEntry(data)
+	if err == nil {
+		future.entryID = entry.GetEntryId()
+		err = p.client.validateEvent(ctx, p.pubsubName, topicName, entry.GetContentType(), entry.GetEvent())
+	}
+	e := &batchEntry{entry: entry, event: data, future: future}
+	if err != nil {
+		p.complete(topicName, e, err)
+		return future
+	}
+
+	p.lock.Lock()
+	if p.closed {
+		p.lock.Unlock()
+		p.complete(topicName, e, ErrBatchPublisherClosed)
+		return future
+	}
+	p.enqueueLocked(topicName, e)
+	p.lock.Unlock()
+	return future
+}
+
+// enqueueLocked adds e to the batch of topicName, which is flushed when full.
+func (p *BatchPublisher) enqueueLocked(topicName string, e *batchEntry) {
+	size := len(e.entry.GetEvent())
+	b := p.batches[topicName]
+	if b != nil && b.bytes+size > p.opts.MaxBytes {
+		p.flushLocked(topicName)
+		b = nil
+	}
+	if b == nil {
+		b = &topicBatch{}
+		b.timer = time.AfterFunc(p.opts.Linger, func() {
+			p.lock.Lock()
+			defer p.lock.Unlock()
+			// The batch may have been flushed, and a new one started.
+			if p.batches[topicName] == b {
+				p.flushLocked(topicName)
+			}
+		})
+		p.batches[topicName] = b
+	}
+	b.entries = append(b.entries, e)
+	b.bytes += size
+	if len(b.entries) >= p.opts.MaxEvents || b.bytes >= p.opts.MaxBytes {
+		p.flushLocked(topicName)
+	}
+}
+
+// Flush sends the buffered events of every topic, and waits until the outcome
+// of all events sent so far is known or ctx is done.
+func (p *BatchPublisher) Flush(ctx context.Context) error {
+	p.lock.Lock()
+	for topicName := range p.batches {
+		p.flushLocked(topicName)
+	}
+	idle := p.idle
+	p.lock.Unlock()
+
+	select {
+	case <-idle:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// Close flushes the buffered events and waits for their outcome. Events
+// published afterwards fail with ErrBatchPublisherClosed. If ctx is done
+// first, the events which are still being sent or retried are failed.
+func (p *BatchPublisher) Close(ctx context.Context) error {
+	p.lock.Lock()
+	p.closed = true
+	p.lock.Unlock()
+
+	err := p.Flush(ctx)
+	p.cancel()
+	if err != nil {
+		p.lock.Lock()
+		idle := p.idle
+		p.lock.Unlock()
+		<-idle
+	}
+	return err
+}
+
+// flushLocked sends the buffered events of topicName in the background.
+func (p *BatchPublisher) flushLocked(topicName string) {
+	b := p.batches[topicName]
+	if b == nil {
+		return
+	}
+	delete(p.batches, topicName)
+	b.timer.Stop()
+	if len(b.entries) == 0 {
+		return
+	}
+	if p.sending == 0 {
+		p.idle = make(chan struct{})
+	}
+	p.sending++
+	prev, sent := p.sent[topicName], make(chan struct{})
+	p.sent[topicName] = sent
+	go func() {
+		if prev != nil {
+			<-prev
+		}
+		p.send(topicName, b.entries)
+
+		p.lock.Lock()
+		defer p.lock.Unlock()
+		close(sent)
+		if p.sent[topicName] == sent {
+			delete(p.sent, topicName)
+		}
+		if p.sending--; p.sending == 0 {
+			close(p.idle)
+		}
+	}()
+}
+
+// send publishes entries, retrying those that fail, and completes them.
+func (p *BatchPublisher) send(topicName string, entries []*batchEntry) {
+	bo := backoff.NewExponentialBackOff()
+	bo.InitialInterval = p.opts.RetryInterval
+	bo.MaxInterval = p.opts.MaxRetryInterval
+	bo.MaxElapsedTime = 0
+	var policy backoff.BackOff = &backoff.StopBackOff{}
+	if p.opts.MaxRetries > 0 {
+		policy = backoff.WithMaxRetries(bo, uint64(p.opts.MaxRetries))
+	}
+
+	pending := entries
+	failed := make(map[string]error)
+	err := backoff.Retry(func() error {
+		request := &pb.BulkPublishRequest{
+			PubsubName: p.pubsubName,
+			Topic:      topicName,
+			Metadata:   p.opts.Metadata,
+			Entries:    make([]*pb.BulkPublishRequestEntry, 0, len(pending)),
+		}
+		for _, e := range pending {
+			request.Entries = append(request.Entries, e.entry)
+		}
+
+		var err error
+		if failed, err = p.client.bulkPublish(p.ctx, request); err != nil {
+			return err
+		}
+		retry := pending[:0]
+		for _, e := range pending {
+			if _, ok := failed[e.entry.GetEntryId()]; ok {
+				retry = append(retry, e)
+			} else {
+				p.complete(topicName, e, nil)
+			}
+		}
+		if pending = retry; len(pending) > 0 {
+			return fmt.Errorf("%d of %d events failed to publish", len(pending), len(request.GetEntries()))
+		}
+		return nil
+	}, backoff.WithContext(policy, p.ctx))
+
+	for _, e := range pending {
+		entryErr := err
+		if reason, ok := failed[e.entry.GetEntryId()]; ok {
+			entryErr = reason
+		}
+		p.complete(topicName, e, fmt.Errorf("error publishing event onto %s topic: %w", topicName, entryErr))
+	}
+}
+
+func (p *BatchPublisher) complete(topicName string, e *batchEntry, err error) {
+	e.future.err = err
+	close(e.future.done)
+	if p.opts.OnResult != nil {
+		p.opts.OnResult(BatchPublishResult{
+			Topic:   topicName,
+			EntryID: e.future.entryID,
+			Event:   e.event,
+			Err:     err,
+		})
+	}
+}
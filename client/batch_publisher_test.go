@@ -0,0 +1,198 @@
+/*
+Copyright 2026 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package client
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+
+	pb "github.com/dapr/dapr/pkg/proto/runtime/v1"
+)
+
+// fakeBulkPublishClient records bulk publish requests, failing the entries in
+// failures as many times as their count, and whole requests while err is set.
+type fakeBulkPublishClient struct {
+	pb.DaprClient
+
+	lock     sync.Mutex
+	requests []*pb.BulkPublishRequest
+	failures map[string]int
+	err      error
+}
+
+func (f *fakeBulkPublishClient) BulkPublishEvent(ctx context.Context, in *pb.BulkPublishRequest, opts ...grpc.CallOption) (*pb.BulkPublishResponse, error) {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	f.requests = append(f.requests, in)
+	if f.err != nil {
+		return nil, f.err
+	}
+	res := &pb.BulkPublishResponse{}
+	for _, entry := range in.GetEntries() {
+		if f.failures[entry.GetEntryId()] > 0 {
+			f.failures[entry.GetEntryId()]--
+			res.FailedEntries = append(res.FailedEntries, &pb.BulkPublishResponseFailedEntry{
+				EntryId: entry.GetEntryId(),
+				Error:   "broker rejected " + entry.GetEntryId(),
+			})
+		}
+	}
+	return res, nil
+}
+
+// entryIDs returns the entry IDs of every request.
+func (f *fakeBulkPublishClient) entryIDs() [][]string {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	ids := make([][]string, 0, len(f.requests))
+	for _, r := range f.requests {
+		var req []string
+		for _, e := range r.GetEntries() {
+			req = append(req, e.GetEntryId())
+		}
+		ids = append(ids, req)
+	}
+	return ids
+}
+
+func entry(id string) PublishEventsEvent {
+	return PublishEventsEvent{EntryID: id, Data: []byte(id), ContentType: "text/plain"}
+}
+
+func TestBatchPublisher(t *testing.T) {
+	ctx := t.Context()
+
+	t.Run("flushes full batches", func(t *testing.T) {
+		fake := &fakeBulkPublishClient{}
+		p, err := (&GRPCClient{protoClient: fake}).NewBatchPublisher("pubsub", BatchPublisherOptions{MaxEvents: 2, Linger: time.Hour})
+		require.NoError(t, err)
+
+		f1 := p.Publish(ctx, "orders", entry("e1"))
+		f2 := p.Publish(ctx, "payments", entry("e2"))
+		f3 := p.Publish(ctx, "orders", entry("e3"))
+		require.NoError(t, f1.Wait(ctx))
+		require.NoError(t, f3.Wait(ctx))
+		assert.Equal(t, "e1", f1.EntryID())
+		assert.Equal(t, [][]string{{"e1", "e3"}}, fake.entryIDs())
+		assert.NoError(t, f2.Err(), "pending")
+
+		require.NoError(t, p.Close(ctx))
+		require.NoError(t, f2.Wait(ctx))
+		assert.Equal(t, [][]string{{"e1", "e3"}, {"e2"}}, fake.entryIDs())
+		assert.Equal(t, "payments", fake.requests[1].GetTopic())
+	})
+
+	t.Run("flushes batches reaching max bytes", func(t *testing.T) {
+		fake := &fakeBulkPublishClient{}
+		p, err := (&GRPCClient{protoClient: fake}).NewBatchPublisher("pubsub", BatchPublisherOptions{MaxBytes: 5, Linger: time.Hour})
+		require.NoError(t, err)
+
+		p.Publish(ctx, "orders", entry("e1"))
+		p.Publish(ctx, "orders", entry("e2"))
+		p.Publish(ctx, "orders", entry("large"))
+		require.NoError(t, p.Flush(ctx))
+		assert.Equal(t, [][]string{{"e1", "e2"}, {"large"}}, fake.entryIDs())
+	})
+
+	t.Run("flushes after linger", func(t *testing.T) {
+		fake := &fakeBulkPublishClient{}
+		p, err := (&GRPCClient{protoClient: fake}).NewBatchPublisher("pubsub", BatchPublisherOptions{Linger: time.Millisecond})
+		require.NoError(t, err)
+
+		require.NoError(t, p.Publish(ctx, "orders", order{ID: "o1"}).Wait(ctx))
+		require.Len(t, fake.requests, 1)
+		assert.Equal(t, "application/json", fake.requests[0].GetEntries()[0].GetContentType())
+	})
+
+	t.Run("retries failed entries", func(t *testing.T) {
+		fake := &fakeBulkPublishClient{failures: map[string]int{"e2": 1, "e3": 10}}
+		var lock sync.Mutex
+		results := map[string]error{}
+		p, err := (&GRPCClient{protoClient: fake}).NewBatchPublisher("pubsub", BatchPublisherOptions{
+			MaxRetries:    2,
+			RetryInterval: time.Millisecond,
+			OnResult: func(r BatchPublishResult) {
+				lock.Lock()
+				defer lock.Unlock()
+				results[r.EntryID] = r.Err
+			},
+		})
+		require.NoError(t, err)
+
+		futures := []*PublishFuture{
+			p.Publish(ctx, "orders", entry("e1")),
+			p.Publish(ctx, "orders", entry("e2")),
+			p.Publish(ctx, "orders", entry("e3")),
+		}
+		require.NoError(t, p.Close(ctx))
+		assert.Equal(t, [][]string{{"e1", "e2", "e3"}, {"e2", "e3"}, {"e3"}}, fake.entryIDs())
+		require.NoError(t, futures[0].Err())
+		require.NoError(t, futures[1].Err())
+		require.ErrorContains(t, futures[2].Err(), "broker rejected e3")
+
+		lock.Lock()
+		defer lock.Unlock()
+		assert.Len(t, results, 3)
+		assert.NoError(t, results["e1"])
+		assert.ErrorContains(t, results["e3"], "broker rejected e3")
+	})
+
+	t.Run("retries failed requests", func(t *testing.T) {
+		fake := &fakeBulkPublishClient{err: errors.New("unavailable")}
+		p, err := (&GRPCClient{protoClient: fake}).NewBatchPublisher("pubsub", BatchPublisherOptions{MaxRetries: -1})
+		require.NoError(t, err)
+		require.ErrorContains(t, p.Publish(ctx, "orders", entry("e1")).Wait(ctx), "unavailable")
+		assert.Len(t, fake.requests, 1, "retries are disabled")
+
+		p, err = (&GRPCClient{protoClient: fake}).NewBatchPublisher("pubsub", BatchPublisherOptions{RetryInterval: time.Hour})
+		require.NoError(t, err)
+		f := p.Publish(ctx, "orders", entry("e1"))
+		closeCtx, cancel := context.WithTimeout(ctx, 50*time.Millisecond)
+		defer cancel()
+		require.ErrorIs(t, p.Close(closeCtx), context.DeadlineExceeded)
+		require.Error(t, f.Err(), "events in flight fail when Close gives up")
+	})
+
+	t.Run("fails invalid events", func(t *testing.T) {
+		fake := &fakeBulkPublishClient{}
+		p, err := (&GRPCClient{protoClient: fake}).NewBatchPublisher("pubsub", BatchPublisherOptions{})
+		require.NoError(t, err)
+
+		require.Error(t, p.Publish(ctx, "", "data").Err())
+		require.Error(t, p.Publish(ctx, "orders", make(chan struct{})).Err())
+
+		require.NoError(t, p.Close(ctx))
+		require.ErrorIs(t, p.Publish(ctx, "orders", "data").Err(), ErrBatchPublisherClosed)
+		assert.Empty(t, fake.requests)
+	})
+
+	_, err := (&GRPCClient{}).NewBatchPublisher("", BatchPublisherOptions{})
+	require.Error(t, err)
+}
+
+func TestPublishEventsEntryErrors(t *testing.T) {
+	fake := &fakeBulkPublishClient{failures: map[string]int{"e2": 1}}
+	c := &GRPCClient{protoClient: fake}
+
+	res := c.PublishEvents(t.Context(), "pubsub", "orders", []interface{}{entry("e1"), entry("e2")})
+	require.ErrorContains(t, res.Error, "broker rejected e2")
+	assert.Equal(t, []interface{}{entry("e2")}, res.FailedEvents)
+}
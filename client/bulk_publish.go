@@ -0,0 +1,182 @@
+/*
+Copyright 2026 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package client
+
+import (
+	"cmp"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+
+	"github.com/google/uuid"
+
+	pb "github.com/dapr/dapr/pkg/proto/runtime/v1"
+)
+
+// BulkPublishEntry is an event published with BulkPublish. It can also be
+// passed to PublishEvents and BatchPublisher.Publish.
+type BulkPublishEntry struct {
+	// EntryID identifies the entry in the result, and must be unique within a
+	// request. Defaults to a random UUID.
+	EntryID string
+	// Data is the payload of the event. Byte slices are published as is,
+	// strings as text, and other values are encoded as JSON.
+	Data interface{}
+	// ContentType is the content type of Data. Defaults to
+	// application/octet-stream for byte slices, text/plain for strings and
+	// application/json for other values.
+	ContentType string
+	// CloudEvent holds the CloudEvent attributes of the event. The id, source
+	// and type attributes are passed to Dapr as metadata. When any other
+	// attribute is set, the entry is published as a structured CloudEvent,
+	// which then requires a source.
+	CloudEvent CloudEventAttributes
+	// Metadata is the metadata of the entry, in addition to the metadata of
+	// the request.
+	Metadata map[string]string
+}
+
+// payload returns the encoded data of e and its content type.
+func (e *BulkPublishEntry) payload() ([]byte, string, error) {
+	var (
+		data        []byte
+		contentType string
+	)
+	switch d := e.Data.(type) {
+	case []byte:
+		data, contentType = d, "application/octet-stream"
+	case string:
+		data, contentType = []byte(d), "text/plain"
+	default:
+		var err error
+		if data, err = json.Marshal(d); err != nil {
+			return nil, "", fmt.Errorf("error serializing input struct: %w", err)
+		}
+		contentType = "application/json"
+	}
+	return data, cmp.Or(e.ContentType, contentType), nil
+}
+
+// requestEntry returns the bulk publish request entry of e with entryID.
+func (e *BulkPublishEntry) requestEntry(entryID string) (*pb.BulkPublishRequestEntry, error) {
+	entry := &pb.BulkPublishRequestEntry{EntryId: entryID}
+
+	var (
+		contentType string
+		err         error
+	)
+	if entry.Event, contentType, err = e.payload(); err != nil {
+		return nil, err
+	}
+
+	if !e.CloudEvent.structured() {
+		entry.ContentType = contentType
+		entry.Metadata = e.CloudEvent.metadata(e.Metadata)
+		return entry, nil
+	}
+	envelope, err := e.CloudEvent.envelope(contentType, entry.GetEvent())
+	if err != nil {
+		return nil, err
+	}
+	entry.Event = envelope
+	entry.ContentType = CloudEventContentType
+	entry.Metadata = e.Metadata
+	return entry, nil
+}
+
+// BulkPublishResult is the outcome of the entries of a BulkPublish request.
+type BulkPublishResult struct {
+	// Entries maps the ID of every entry to nil if it was published, or to the
+	// reason it was not.
+	Entries map[string]error
+}
+
+// Failed returns the IDs of the entries which were not published, sorted.
+func (r *BulkPublishResult) Failed() []string {
+	var ids []string
+	for id, err := range r.Entries {
+		if err != nil {
+			ids = append(ids, id)
+		}
+	}
+	slices.Sort(ids)
+	return ids
+}
+
+// Err returns the errors of the entries which were not published, or nil.
+func (r *BulkPublishResult) Err() error {
+	var errs []error
+	for _, id := range r.Failed() {
+		errs = append(errs, fmt.Errorf("entry %s: %w", id, r.Entries[id]))
+	}
+	return errors.Join(errs...)
+}
+
+// BulkPublish publishes entries onto a topic in a single request. The returned
+// error is set if the request as a whole fails. Otherwise, the result holds the
+// outcome of every entry: entries which cannot be encoded or do not match
+// their schema fail without being sent, and the others succeed or fail as
+// reported by the pubsub component.
+func (c *GRPCClient) BulkPublish(ctx context.Context, pubsubName, topicName string, entries []BulkPublishEntry, opts ...PublishEventsOption) (*BulkPublishResult, error) {
+	if pubsubName == "" {
+		return nil, errors.New("pubsubName name required")
+	}
+	if topicName == "" {
+		return nil, errors.New("topic name required")
+	}
+
+	result := &BulkPublishResult{Entries: make(map[string]error, len(entries))}
+	request := &pb.BulkPublishRequest{
+		PubsubName: pubsubName,
+		Topic:      topicName,
+		Entries:    make([]*pb.BulkPublishRequestEntry, 0, len(entries)),
+	}
+	for i := range entries {
+		id := cmp.Or(entries[i].EntryID, uuid.NewString())
+		if _, ok := result.Entries[id]; ok {
+			return nil, fmt.Errorf("duplicate entry ID %s", id)
+		}
+		entry, err := entries[i].requestEntry(id)
+		result.Entries[id] = err
+		if err == nil {
+			request.Entries = append(request.Entries, entry)
+		}
+	}
+	for _, o := range opts {
+		o(request)
+	}
+
+	valid := request.Entries[:0]
+	for _, entry := range request.GetEntries() {
+		if err := c.validateEvent(ctx, pubsubName, topicName, entry.GetContentType(), entry.GetEvent()); err != nil {
+			result.Entries[entry.GetEntryId()] = err
+			continue
+		}
+		valid = append(valid, entry)
+	}
+	if request.Entries = valid; len(valid) == 0 {
+		return result, nil
+	}
+
+	failed, err := c.bulkPublish(ctx, request)
+	if err != nil {
+		return nil, fmt.Errorf("error publishing events onto %s topic: %w", topicName, err)
+	}
+	for id, reason := range failed {
+		result.Entries[id] = reason
+	}
+	return result, nil
+}
@@ -0,0 +1,142 @@
+/*
+Copyright 2026 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/dapr/go-sdk/schema"
+)
+
+func TestBulkPublish(t *testing.T) {
+	ctx := t.Context()
+
+	t.Run("invalid arguments", func(t *testing.T) {
+		c := &GRPCClient{protoClient: &fakeBulkPublishClient{}}
+		_, err := c.BulkPublish(ctx, "", "orders", nil)
+		require.Error(t, err)
+		_, err = c.BulkPublish(ctx, "pubsub", "", nil)
+		require.Error(t, err)
+		_, err = c.BulkPublish(ctx, "pubsub", "orders", []BulkPublishEntry{{EntryID: "e1"}, {EntryID: "e1"}})
+		require.ErrorContains(t, err, "duplicate entry ID e1")
+	})
+
+	t.Run("per-entry content types and metadata", func(t *testing.T) {
+		fake := &fakeBulkPublishClient{failures: map[string]int{"e3": 1}}
+		c := &GRPCClient{protoClient: fake}
+
+		res, err := c.BulkPublish(ctx, "pubsub", "orders", []BulkPublishEntry{
+			{EntryID: "e1", Data: order{ID: "o1"}, Metadata: map[string]string{"ttlInSeconds": "60"}},
+			{EntryID: "e2", Data: "<order/>", ContentType: "application/xml", CloudEvent: CloudEventAttributes{ID: "ce2", Type: "order.created"}},
+			{EntryID: "e3", Data: []byte{1}},
+			{EntryID: "e4", Data: make(chan struct{})},
+			{Data: "generated ID"},
+		}, PublishEventsWithMetadata(map[string]string{"key": "value"}))
+		require.NoError(t, err)
+
+		require.Len(t, fake.requests, 1)
+		req := fake.requests[0]
+		assert.Equal(t, map[string]string{"key": "value"}, req.GetMetadata())
+		entries := req.GetEntries()
+		require.Len(t, entries, 4)
+		assert.Equal(t, "application/json", entries[0].GetContentType())
+		assert.Equal(t, map[string]string{"ttlInSeconds": "60"}, entries[0].GetMetadata())
+		assert.Equal(t, "application/xml", entries[1].GetContentType())
+		assert.Equal(t, map[string]string{"cloudevent.id": "ce2", "cloudevent.type": "order.created"}, entries[1].GetMetadata())
+		assert.Equal(t, "application/octet-stream", entries[2].GetContentType())
+		assert.Equal(t, "text/plain", entries[3].GetContentType())
+		assert.NotEmpty(t, entries[3].GetEntryId())
+
+		assert.Len(t, res.Entries, 5)
+		assert.NoError(t, res.Entries["e1"])
+		assert.NoError(t, res.Entries[entries[3].GetEntryId()])
+		assert.EqualError(t, res.Entries["e3"], "broker rejected e3")
+		assert.Error(t, res.Entries["e4"], "entries which cannot be encoded are not sent")
+		assert.Equal(t, []string{"e3", "e4"}, res.Failed())
+		require.ErrorContains(t, res.Err(), "entry e3: broker rejected e3")
+	})
+
+	t.Run("structured CloudEvents", func(t *testing.T) {
+		fake := &fakeBulkPublishClient{}
+		c := &GRPCClient{protoClient: fake}
+
+		at := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+		res, err := c.BulkPublish(ctx, "pubsub", "orders", []BulkPublishEntry{
+			{EntryID: "e1", Data: order{ID: "o1"}, CloudEvent: CloudEventAttributes{
+				Source:     "checkout",
+				Subject:    "o1",
+				Time:       at,
+				Extensions: map[string]any{"tenant": "acme"},
+			}},
+			{EntryID: "e2", Data: order{ID: "o2"}, CloudEvent: CloudEventAttributes{Subject: "o2"}},
+		})
+		require.NoError(t, err)
+		require.ErrorContains(t, res.Entries["e2"], "source required")
+		require.NoError(t, res.Entries["e1"])
+
+		entry := fake.requests[0].GetEntries()[0]
+		assert.Equal(t, CloudEventContentType, entry.GetContentType())
+		var ce map[string]any
+		require.NoError(t, json.Unmarshal(entry.GetEvent(), &ce))
+		assert.Equal(t, "checkout", ce["source"])
+		assert.Equal(t, "o1", ce["subject"])
+		assert.Equal(t, "acme", ce["tenant"])
+		assert.Equal(t, "2026-01-02T03:04:05Z", ce["time"])
+		assert.Equal(t, map[string]any{"id": "o1", "total": float64(0)}, ce["data"])
+	})
+
+	t.Run("schema validation", func(t *testing.T) {
+		fake := &fakeBulkPublishClient{}
+		c := &GRPCClient{protoClient: fake}
+		registry := &schema.Registry{}
+		registry.RegisterTopic("pubsub", "orders", schema.ValidatorFunc(func(_ context.Context, data []byte) error {
+			return errors.New("rejected")
+		}))
+		c.WithSchemaValidation(registry)
+
+		res, err := c.BulkPublish(ctx, "pubsub", "orders", []BulkPublishEntry{{EntryID: "e1", Data: order{}}})
+		require.NoError(t, err)
+		var verr *schema.ValidationError
+		require.ErrorAs(t, res.Entries["e1"], &verr)
+		assert.Empty(t, fake.requests)
+	})
+
+	t.Run("request failure", func(t *testing.T) {
+		c := &GRPCClient{protoClient: &fakeBulkPublishClient{err: errors.New("unavailable")}}
+		_, err := c.BulkPublish(ctx, "pubsub", "orders", []BulkPublishEntry{{Data: "x"}})
+		require.ErrorContains(t, err, "unavailable")
+	})
+
+	t.Run("entries in PublishEvents", func(t *testing.T) {
+		fake := &fakeBulkPublishClient{}
+		c := &GRPCClient{protoClient: fake}
+		res := c.PublishEvents(ctx, "pubsub", "orders", []interface{}{
+			BulkPublishEntry{EntryID: "e1", Data: "x", CloudEvent: CloudEventAttributes{Type: "t"}},
+			&BulkPublishEntry{Data: []byte("y"), ContentType: "image/png"},
+		})
+		require.NoError(t, res.Error)
+		entries := fake.requests[0].GetEntries()
+		assert.Equal(t, "e1", entries[0].GetEntryId())
+		assert.Equal(t, map[string]string{"cloudevent.type": "t"}, entries[0].GetMetadata())
+		assert.Equal(t, "image/png", entries[1].GetContentType())
+		assert.NotEmpty(t, entries[1].GetEntryId())
+	})
+}
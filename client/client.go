@@ -19,18 +19,21 @@ import (
 	"errors"
 	"fmt"
 	"io"
+	"iter"
 	"log"
 	"net"
 	"os"
 	"strconv"
 	"strings"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/dapr/durabletask-go/workflow"
 	"github.com/dapr/go-sdk/actor"
 	"github.com/dapr/go-sdk/actor/config"
 	"github.com/dapr/go-sdk/client/internal"
+	"github.com/dapr/go-sdk/schema"
 	"github.com/dapr/go-sdk/version"
 
 	"google.golang.org/grpc"
@@ -49,6 +52,7 @@ const (
 	daprPortEnvVarName             = "DAPR_GRPC_PORT" /* #nosec */
 	daprGRPCEndpointEnvVarName     = "DAPR_GRPC_ENDPOINT"
 	traceparentKey                 = "traceparent"
+	tracestateKey                  = "tracestate"
 	apiTokenKey                    = "dapr-api-token" /* #nosec */
 	apiTokenEnvVarName             = "DAPR_API_TOKEN" /* #nosec */
 	clientDefaultTimeoutSeconds    = 5
@@ -74,6 +78,11 @@ func SetLogger(l *log.Logger) {
 	logger = l
 }
 
+// Logger returns the global logger of the Dapr client, as set with SetLogger.
+func Logger() *log.Logger {
+	return logger
+}
+
 // Client is the interface for Dapr client implementation.
 //
 //nolint:interfacebloat
@@ -95,6 +104,14 @@ type Client interface {
 	// InvokeMethodWithCustomContent invokes app with custom content (struct + content type).
 	InvokeMethodWithCustomContent(ctx context.Context, appID, methodName, verb string, contentType string, content interface{}) (out []byte, err error)
 
+	// Invoke invokes an app with the headers and query of req, and returns the
+	// status, headers and body of the response.
+	Invoke(ctx context.Context, req *InvokeRequest) (*InvokeResponse, error)
+
+	// NewInvokeTransport returns an http.RoundTripper sending requests to
+	// other apps through Dapr service invocation.
+	NewInvokeTransport(opts InvokeTransportOptions) (*InvokeTransport, error)
+
 	// GetMetadata returns metadata from the sidecar.
 	GetMetadata(ctx context.Context) (metadata *GetMetadataResponse, err error)
 
@@ -114,6 +131,13 @@ type Client interface {
 	// The FailedEvents field will contain all events that failed to publish.
 	PublishEvents(ctx context.Context, pubsubName, topicName string, events []interface{}, opts ...PublishEventsOption) PublishEventsResponse
 
+	// BulkPublish publishes entries with per-entry IDs, content types, CloudEvent attributes and metadata
+	// onto a topic, and returns the outcome of every entry.
+	BulkPublish(ctx context.Context, pubsubName, topicName string, entries []BulkPublishEntry, opts ...PublishEventsOption) (*BulkPublishResult, error)
+
+	// NewBatchPublisher returns a publisher which buffers events per topic and publishes them in bulk.
+	NewBatchPublisher(pubsubName string, opts BatchPublisherOptions) (*BatchPublisher, error)
+
 	// GetSecret retrieves preconfigured secret from specified store using key.
 	GetSecret(ctx context.Context, storeName, key string, meta map[string]string) (data map[string]string, err error)
 
@@ -159,6 +183,12 @@ type Client interface {
 	// SubscribeConfigurationItems can subscribe the change of configuration items by storeName and keys, and return subscription id
 	SubscribeConfigurationItems(ctx context.Context, storeName string, keys []string, handler ConfigurationHandleFunction, opts ...ConfigurationOpt) (string, error)
 
+	// WatchConfigurationItems watches configuration items by storeName and keys, re-subscribing when the subscription stream breaks.
+	WatchConfigurationItems(ctx context.Context, storeName string, keys []string, handler ConfigurationEventHandler, opts ConfigurationWatchOptions) (*ConfigurationWatcher, error)
+
+	// WatchConfigurationEvents returns an iterator over the events of a configuration watcher.
+	WatchConfigurationEvents(ctx context.Context, storeName string, keys []string, opts ConfigurationWatchOptions) iter.Seq2[ConfigurationEvent, error]
+
 	// UnsubscribeConfigurationItems stops the subscription with target store's and ID.
 	//
 	// Deprecated: Closing the `SubscribeConfigurationItems` stream (closing the given context) will unsubscribe the client and should be used in favor of `UnsubscribeConfigurationItems`.
@@ -173,6 +203,14 @@ type Client interface {
 	// The returned cancel function must be called after  finishing with subscribing.
 	SubscribeWithHandler(ctx context.Context, opts SubscriptionOptions, handler SubscriptionHandleFunction) (func() error, error)
 
+	// SubscribeWithContextHandler subscribes to a pubsub topic and calls the given handler on topic events
+	// with a context carrying the trace context of the event.
+	// The returned cancel function must be called after finishing with subscribing.
+	SubscribeWithContextHandler(ctx context.Context, opts SubscriptionOptions, handler SubscriptionContextHandleFunction) (func() error, error)
+
+	// SubscribeGroup subscribes to several pubsub topics with per-topic handlers under a single lifecycle.
+	SubscribeGroup(ctx context.Context, subs ...GroupSubscription) (*SubscriptionGroup, error)
+
 	// DeleteBulkState deletes content for multiple keys from store.
 	DeleteBulkState(ctx context.Context, storeName string, keys []string, meta map[string]string) error
 
@@ -185,6 +223,10 @@ type Client interface {
 	// UnlockAlpha1 deletes unlocks a lock from a lock store.
 	UnlockAlpha1(ctx context.Context, storeName string, request *UnlockRequest) (*UnlockResponse, error)
 
+	// Lock acquires a lock from a lock store, retrying with backoff until it is acquired or ctx is done.
+	// The lease of the returned lock is renewed in the background until it is unlocked.
+	Lock(ctx context.Context, storeName, resourceID string, opts *LockOptions) (*LockHandle, error)
+
 	// Encrypt data read from a stream, returning a readable stream that receives the encrypted data.
 	// This method returns an error if the initial call fails. Errors performed during the encryption are received by the out stream.
 	Encrypt(ctx context.Context, in io.Reader, opts EncryptOptions) (io.Reader, error)
@@ -205,6 +247,9 @@ type Client interface {
 	// WithAuthToken sets Dapr API token on the instantiated client.
 	WithAuthToken(token string)
 
+	// WithSchemaValidation validates the data of published events against the schemas of r.
+	WithSchemaValidation(r *schema.Registry)
+
 	// Close cleans up all resources created by the client.
 	Close()
 
@@ -252,6 +297,10 @@ type Client interface {
 	GrpcClient() pb.DaprClient
 
 	GrpcClientConn() *grpc.ClientConn
+
+	// AppClientConn returns a connection to the gRPC services of another app,
+	// proxied by the sidecar, for use with generated gRPC clients.
+	AppClientConn(appID string) (grpc.ClientConnInterface, error)
 }
 
 // NewClient instantiates Dapr client using DAPR_GRPC_PORT environment variable as port.
@@ -452,22 +501,28 @@ func (a *authToken) set(token string) {
 	a.authToken = token
 }
 
+// withAPIToken adds token to the outgoing metadata of ctx, keeping the other
+// metadata, unless it is empty or a token is already set.
+func withAPIToken(ctx context.Context, token string) context.Context {
+	if token == "" {
+		return ctx
+	}
+	if md, ok := metadata.FromOutgoingContext(ctx); ok && len(md.Get(apiTokenKey)) > 0 {
+		return ctx
+	}
+	return metadata.AppendToOutgoingContext(ctx, apiTokenKey, token)
+}
+
 func authTokenUnaryInterceptor(authToken *authToken) grpc.DialOption {
 	return grpc.WithUnaryInterceptor(func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
-		token := authToken.get()
-		if token != "" {
-			ctx = metadata.NewOutgoingContext(ctx, metadata.Pairs(apiTokenKey, token))
-		}
+		ctx = withAPIToken(ctx, authToken.get())
 		return invoker(ctx, method, req, reply, cc, opts...)
 	})
 }
 
 func authTokenStreamInterceptor(authToken *authToken) grpc.DialOption {
 	return grpc.WithStreamInterceptor(func(ctx context.Context, desc *grpc.StreamDesc, cc *grpc.ClientConn, method string, streamer grpc.Streamer, opts ...grpc.CallOption) (grpc.ClientStream, error) {
-		token := authToken.get()
-		if token != "" {
-			ctx = metadata.NewOutgoingContext(ctx, metadata.Pairs(apiTokenKey, token))
-		}
+		ctx = withAPIToken(ctx, authToken.get())
 		return streamer(ctx, desc, cc, method, opts...)
 	})
 }
@@ -477,6 +532,7 @@ type GRPCClient struct {
 	connection  *grpc.ClientConn
 	protoClient pb.DaprClient
 	authToken   *authToken
+	schemas     atomic.Pointer[schema.Registry]
 }
 
 // Close cleans up all resources created by the client.
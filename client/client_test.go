@@ -259,6 +259,7 @@ func Test_getClientTimeoutSeconds(t *testing.T) {
 
 type testDaprServer struct {
 	pb.UnimplementedDaprServer
+	stateLock                         sync.Mutex
 	state                             map[string][]byte
 	configurationSubscriptionIDMapLoc sync.Mutex
 	configurationSubscriptionID       map[string]chan struct{}
@@ -292,6 +293,8 @@ func (s *testDaprServer) InvokeService(ctx context.Context, req *pb.InvokeServic
 }
 
 func (s *testDaprServer) GetState(ctx context.Context, req *pb.GetStateRequest) (*pb.GetStateResponse, error) {
+	s.stateLock.Lock()
+	defer s.stateLock.Unlock()
 	return &pb.GetStateResponse{
 		Data: s.state[req.GetKey()],
 		Etag: "1",
@@ -299,6 +302,8 @@ func (s *testDaprServer) GetState(ctx context.Context, req *pb.GetStateRequest)
 }
 
 func (s *testDaprServer) GetBulkState(ctx context.Context, in *pb.GetBulkStateRequest) (*pb.GetBulkStateResponse, error) {
+	s.stateLock.Lock()
+	defer s.stateLock.Unlock()
 	items := make([]*pb.BulkStateItem, 0)
 	for _, k := range in.GetKeys() {
 		if v, found := s.state[k]; found {
@@ -316,6 +321,8 @@ func (s *testDaprServer) GetBulkState(ctx context.Context, in *pb.GetBulkStateRe
 }
 
 func (s *testDaprServer) SaveState(ctx context.Context, req *pb.SaveStateRequest) (*emptypb.Empty, error) {
+	s.stateLock.Lock()
+	defer s.stateLock.Unlock()
 	for _, item := range req.GetStates() {
 		s.state[item.GetKey()] = item.GetValue()
 	}
@@ -328,6 +335,8 @@ func (s *testDaprServer) QueryStateAlpha1(ctx context.Context, req *pb.QueryStat
 		return nil, err
 	}
 
+	s.stateLock.Lock()
+	defer s.stateLock.Unlock()
 	ret := &pb.QueryStateResponse{
 		Results: make([]*pb.QueryStateItem, 0, len(s.state)),
 	}
@@ -338,11 +347,15 @@ func (s *testDaprServer) QueryStateAlpha1(ctx context.Context, req *pb.QueryStat
 }
 
 func (s *testDaprServer) DeleteState(ctx context.Context, req *pb.DeleteStateRequest) (*emptypb.Empty, error) {
+	s.stateLock.Lock()
+	defer s.stateLock.Unlock()
 	delete(s.state, req.GetKey())
 	return &emptypb.Empty{}, nil
 }
 
 func (s *testDaprServer) DeleteBulkState(ctx context.Context, req *pb.DeleteBulkStateRequest) (*emptypb.Empty, error) {
+	s.stateLock.Lock()
+	defer s.stateLock.Unlock()
 	for _, item := range req.GetStates() {
 		delete(s.state, item.GetKey())
 	}
@@ -350,6 +363,8 @@ func (s *testDaprServer) DeleteBulkState(ctx context.Context, req *pb.DeleteBulk
 }
 
 func (s *testDaprServer) ExecuteStateTransaction(ctx context.Context, in *pb.ExecuteStateTransactionRequest) (*emptypb.Empty, error) {
+	s.stateLock.Lock()
+	defer s.stateLock.Unlock()
 	for _, op := range in.GetOperations() {
 		item := op.GetRequest()
 		switch opType := op.GetOperationType(); opType {
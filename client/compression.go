@@ -0,0 +1,328 @@
+/*
+Copyright 2026 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	pb "github.com/dapr/dapr/pkg/proto/runtime/v1"
+	"github.com/dapr/go-sdk/service/common"
+)
+
+// DefaultCompressionMinSize is the default size, in bytes, below which payloads are sent uncompressed.
+const DefaultCompressionMinSize = 1024
+
+// CompressionOptions contains options passed to NewCompressionClient.
+type CompressionOptions struct {
+	// Encoding is the content encoding to compress with, e.g. common.CompressionGzip.
+	// Additional encodings can be added with common.RegisterCompressor. Required.
+	Encoding string
+	// MinSize is the size, in bytes, below which payloads are sent unchanged.
+	// Defaults to DefaultCompressionMinSize.
+	MinSize int
+}
+
+// compressionClient is a Client which transparently compresses state values,
+// published events and invocation content. All other methods are passed through.
+type compressionClient struct {
+	Client
+
+	encoding string
+	minSize  int
+}
+
+// NewCompressionClient returns a Client which compresses payloads sent with
+// SaveState, SaveStateWithETag, SaveBulkState, ExecuteStateTransaction,
+// PublishEvent, BulkPublish, Invoke, InvokeMethodWithContent and
+// InvokeMethodWithCustomContent. PublishEvents and batch publishers created
+// with NewBatchPublisher are not compressed.
+// Payloads smaller than MinSize, or which do not shrink, are sent unchanged.
+// Compressed payloads are sent with the CompressedContentType content type and
+// start with a header recording their encoding and original content type,
+// which is how they are recognized when read back. Payloads which already
+// start with such a header are always compressed, so they are read back
+// unchanged.
+// State reads through this client are decompressed transparently. Services
+// decompress topic events and invocations once EnableDecompression is called,
+// and streaming subscriptions with SubscriptionOptions.MaxDecompressedSize.
+// When combined with NewStateEncryptionClient, this client should wrap the
+// encrypting client so values are compressed before they are encrypted.
+func NewCompressionClient(c Client, opts CompressionOptions) (Client, error) {
+	if c == nil {
+		return nil, errors.New("client is required")
+	}
+	if opts.Encoding == "" {
+		return nil, errors.New("option 'Encoding' is required")
+	}
+	if _, ok := common.GetCompressor(opts.Encoding); !ok {
+		return nil, fmt.Errorf("unknown content encoding: %s", opts.Encoding)
+	}
+	if opts.MinSize <= 0 {
+		opts.MinSize = DefaultCompressionMinSize
+	}
+
+	return &compressionClient{
+		Client:   c,
+		encoding: opts.Encoding,
+		minSize:  opts.MinSize,
+	}, nil
+}
+
+// SaveState compresses data and saves it into store, default options: strong, last-write.
+func (c *compressionClient) SaveState(ctx context.Context, storeName, key string, data []byte, meta map[string]string, so ...StateOption) error {
+	return c.SaveStateWithETag(ctx, storeName, key, data, "", meta, so...)
+}
+
+// SaveStateWithETag compresses data and saves it into store using provided state options and etag.
+func (c *compressionClient) SaveStateWithETag(ctx context.Context, storeName, key string, data []byte, etag string, meta map[string]string, so ...StateOption) error {
+	out, _, err := c.compress("", data)
+	if err != nil {
+		return fmt.Errorf("error compressing state for key %s: %w", key, err)
+	}
+	return c.Client.SaveStateWithETag(ctx, storeName, key, out, etag, meta, so...)
+}
+
+// SaveBulkState compresses the values of items and saves them to store.
+// The given items are not modified.
+func (c *compressionClient) SaveBulkState(ctx context.Context, storeName string, items ...*SetStateItem) error {
+	out := make([]*SetStateItem, len(items))
+	for i, item := range items {
+		compressed, err := c.compressItem(item)
+		if err != nil {
+			return err
+		}
+		out[i] = compressed
+	}
+	return c.Client.SaveBulkState(ctx, storeName, out...)
+}
+
+// ExecuteStateTransaction compresses the values of upsert operations and executes
+// the operations on store. The given operations are not modified.
+func (c *compressionClient) ExecuteStateTransaction(ctx context.Context, storeName string, meta map[string]string, ops []*StateOperation) error {
+	out := make([]*StateOperation, len(ops))
+	for i, op := range ops {
+		if op == nil || op.Type != StateOperationTypeUpsert {
+			out[i] = op
+			continue
+		}
+		item, err := c.compressItem(op.Item)
+		if err != nil {
+			return err
+		}
+		out[i] = &StateOperation{Type: op.Type, Item: item}
+	}
+	return c.Client.ExecuteStateTransaction(ctx, storeName, meta, out)
+}
+
+// GetState retrieves and decompresses state from specific store using default consistency option.
+func (c *compressionClient) GetState(ctx context.Context, storeName, key string, meta map[string]string) (*StateItem, error) {
+	return c.GetStateWithConsistency(ctx, storeName, key, meta, StateConsistencyStrong)
+}
+
+// GetStateWithConsistency retrieves and decompresses state from specific store using provided state consistency.
+func (c *compressionClient) GetStateWithConsistency(ctx context.Context, storeName, key string, meta map[string]string, sc StateConsistency) (*StateItem, error) {
+	item, err := c.Client.GetStateWithConsistency(ctx, storeName, key, meta, sc)
+	if err != nil || item == nil {
+		return item, err
+	}
+	if item.Value, _, err = common.DecompressPayload(item.Value, ""); err != nil {
+		return nil, fmt.Errorf("error decompressing state for key %s: %w", key, err)
+	}
+	return item, nil
+}
+
+// GetBulkState retrieves and decompresses state for multiple keys from specific store.
+// Items which fail to decompress have their Value cleared and Error set.
+func (c *compressionClient) GetBulkState(ctx context.Context, storeName string, keys []string, meta map[string]string, parallelism int32) ([]*BulkStateItem, error) {
+	items, err := c.Client.GetBulkState(ctx, storeName, keys, meta, parallelism)
+	if err != nil {
+		return items, err
+	}
+	for _, item := range items {
+		if item == nil || item.Error != "" {
+			continue
+		}
+		if item.Value, _, err = common.DecompressPayload(item.Value, ""); err != nil {
+			item.Error = fmt.Sprintf("error decompressing state: %v", err)
+		}
+	}
+	return items, nil
+}
+
+// QueryStateAlpha1 runs a query against state store and decompresses the results.
+// Results which fail to decompress have their Value cleared and Error set.
+func (c *compressionClient) QueryStateAlpha1(ctx context.Context, storeName, query string, meta map[string]string) (*QueryResponse, error) {
+	resp, err := c.Client.QueryStateAlpha1(ctx, storeName, query, meta)
+	if err != nil {
+		return resp, err
+	}
+	for i := range resp.Results {
+		res := &resp.Results[i]
+		if res.Error != "" {
+			continue
+		}
+		if res.Value, _, err = common.DecompressPayload(res.Value, ""); err != nil {
+			res.Error = fmt.Sprintf("error decompressing state: %v", err)
+		}
+	}
+	return resp, nil
+}
+
+// PublishEvent compresses data and publishes it onto specific pubsub topic.
+func (c *compressionClient) PublishEvent(ctx context.Context, pubsubName, topicName string, data interface{}, opts ...PublishEventOption) error {
+	// Resolve the payload and content type the same way PublishEvent does.
+	req := &pb.PublishEventRequest{}
+	for _, o := range opts {
+		o(req)
+	}
+	var payload []byte
+	switch d := data.(type) {
+	case nil:
+	case []byte:
+		payload = d
+	case string:
+		payload = []byte(d)
+	default:
+		var err error
+		req.DataContentType = "application/json"
+		if payload, err = json.Marshal(d); err != nil {
+			return fmt.Errorf("error serializing input struct: %w", err)
+		}
+	}
+
+	out, compressed, err := c.compress(req.GetDataContentType(), payload)
+	if err != nil {
+		return fmt.Errorf("error compressing event: %w", err)
+	}
+	if !compressed {
+		return c.Client.PublishEvent(ctx, pubsubName, topicName, data, opts...)
+	}
+
+	opts = append(opts[:len(opts):len(opts)], func(r *pb.PublishEventRequest) {
+		r.DataContentType = common.CompressedContentType
+	})
+	return c.Client.PublishEvent(ctx, pubsubName, topicName, out, opts...)
+}
+
+// BulkPublish compresses the data of entries and publishes them onto a topic
+// in a single request. The given entries are not modified.
+func (c *compressionClient) BulkPublish(ctx context.Context, pubsubName, topicName string, entries []BulkPublishEntry, opts ...PublishEventsOption) (*BulkPublishResult, error) {
+	out := make([]BulkPublishEntry, len(entries))
+	for i, e := range entries {
+		out[i] = e
+		data, contentType, err := e.payload()
+		if err != nil {
+			// Left for BulkPublish to report as a failed entry.
+			continue
+		}
+		data, compressed, err := c.compress(contentType, data)
+		if err != nil {
+			return nil, fmt.Errorf("error compressing event: %w", err)
+		}
+		if compressed {
+			out[i].Data = data
+			out[i].ContentType = common.CompressedContentType
+		}
+	}
+	return c.Client.BulkPublish(ctx, pubsubName, topicName, out, opts...)
+}
+
+// Invoke compresses the data of req and invokes the method. req is not modified.
+func (c *compressionClient) Invoke(ctx context.Context, req *InvokeRequest) (*InvokeResponse, error) {
+	if req == nil || len(req.Data) == 0 {
+		return c.Client.Invoke(ctx, req)
+	}
+	contentType := req.ContentType
+	if contentType == "" {
+		contentType = req.Header.Get("Content-Type")
+	}
+	out, compressed, err := c.compress(contentType, req.Data)
+	if err != nil {
+		return nil, fmt.Errorf("error compressing content: %w", err)
+	}
+	if compressed {
+		r := *req
+		r.Data, r.ContentType = out, common.CompressedContentType
+		req = &r
+	}
+	return c.Client.Invoke(ctx, req)
+}
+
+// InvokeMethodWithContent compresses the content and invokes the service.
+func (c *compressionClient) InvokeMethodWithContent(ctx context.Context, appID, methodName, verb string, content *DataContent) ([]byte, error) {
+	if content == nil {
+		return c.Client.InvokeMethodWithContent(ctx, appID, methodName, verb, content)
+	}
+	out, compressed, err := c.compress(content.ContentType, content.Data)
+	if err != nil {
+		return nil, fmt.Errorf("error compressing content: %w", err)
+	}
+	if compressed {
+		content = &DataContent{Data: out, ContentType: common.CompressedContentType}
+	}
+	return c.Client.InvokeMethodWithContent(ctx, appID, methodName, verb, content)
+}
+
+// InvokeMethodWithCustomContent serializes and compresses the content and invokes the service.
+func (c *compressionClient) InvokeMethodWithCustomContent(ctx context.Context, appID, methodName, verb string, contentType string, content interface{}) ([]byte, error) {
+	if contentType == "" || content == nil {
+		return c.Client.InvokeMethodWithCustomContent(ctx, appID, methodName, verb, contentType, content)
+	}
+	data, err := json.Marshal(content)
+	if err != nil {
+		return nil, fmt.Errorf("error serializing input struct: %w", err)
+	}
+	return c.InvokeMethodWithContent(ctx, appID, methodName, verb, &DataContent{Data: data, ContentType: contentType})
+}
+
+// compress returns data compressed with the client encoding, and whether it
+// was compressed. Data which is too small or does not shrink is returned
+// unchanged, unless it would be mistaken for a compressed payload when read.
+func (c *compressionClient) compress(contentType string, data []byte) ([]byte, bool, error) {
+	ambiguous := common.IsCompressedPayload(data)
+	if len(data) < c.minSize && !ambiguous {
+		return data, false, nil
+	}
+	out, err := common.CompressPayload(c.encoding, contentType, data)
+	if err != nil {
+		return nil, false, err
+	}
+	if len(out) >= len(data) && !ambiguous {
+		return data, false, nil
+	}
+	return out, true, nil
+}
+
+func (c *compressionClient) compressItem(item *SetStateItem) (*SetStateItem, error) {
+	if item == nil {
+		return nil, nil
+	}
+	out, compressed, err := c.compress("", item.Value)
+	if err != nil {
+		return nil, fmt.Errorf("error compressing state for key %s: %w", item.Key, err)
+	}
+	if !compressed {
+		return item, nil
+	}
+	return &SetStateItem{
+		Key:      item.Key,
+		Value:    out,
+		Etag:     item.Etag,
+		Metadata: item.Metadata,
+		Options:  item.Options,
+	}, nil
+}
@@ -0,0 +1,272 @@
+/*
+Copyright 2026 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package client
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	pb "github.com/dapr/dapr/pkg/proto/runtime/v1"
+	"github.com/dapr/go-sdk/service/common"
+)
+
+// publishRecorder records the last event published through it.
+type publishRecorder struct {
+	Client
+	req *pb.PublishEventRequest
+}
+
+func (c *publishRecorder) PublishEvent(ctx context.Context, pubsubName, topicName string, data interface{}, opts ...PublishEventOption) error {
+	c.req = &pb.PublishEventRequest{PubsubName: pubsubName, Topic: topicName}
+	for _, o := range opts {
+		o(c.req)
+	}
+	switch d := data.(type) {
+	case []byte:
+		c.req.Data = d
+	case string:
+		c.req.Data = []byte(d)
+	}
+	return nil
+}
+
+func TestNewCompressionClient(t *testing.T) {
+	_, err := NewCompressionClient(nil, CompressionOptions{Encoding: common.CompressionGzip})
+	require.Error(t, err)
+
+	_, err = NewCompressionClient(testClient, CompressionOptions{})
+	require.Error(t, err)
+
+	_, err = NewCompressionClient(testClient, CompressionOptions{Encoding: "unknown"})
+	require.Error(t, err)
+}
+
+func TestCompressionClientState(t *testing.T) {
+	ctx := t.Context()
+	c, err := NewCompressionClient(testClient, CompressionOptions{Encoding: common.CompressionGzip, MinSize: 64})
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		keys := []string{"gzip-key1", "gzip-key2", "gzip-key3"}
+		require.NoError(t, testClient.DeleteBulkState(context.Background(), testStore, keys, nil))
+	})
+
+	large := []byte(strings.Repeat(testData, 100))
+
+	t.Run("large value is compressed", func(t *testing.T) {
+		require.NoError(t, c.SaveState(ctx, testStore, "gzip-key1", large, nil))
+
+		raw, err := testClient.GetState(ctx, testStore, "gzip-key1", nil)
+		require.NoError(t, err)
+		assert.True(t, common.IsCompressedPayload(raw.Value))
+		assert.Less(t, len(raw.Value), len(large))
+
+		item, err := c.GetState(ctx, testStore, "gzip-key1", nil)
+		require.NoError(t, err)
+		assert.Equal(t, large, item.Value)
+	})
+
+	t.Run("small value is not compressed", func(t *testing.T) {
+		require.NoError(t, c.SaveState(ctx, testStore, "gzip-key2", []byte(testData), nil))
+
+		raw, err := testClient.GetState(ctx, testStore, "gzip-key2", nil)
+		require.NoError(t, err)
+		assert.Equal(t, testData, string(raw.Value))
+
+		item, err := c.GetState(ctx, testStore, "gzip-key2", nil)
+		require.NoError(t, err)
+		assert.Equal(t, testData, string(item.Value))
+	})
+
+	t.Run("bulk and transactions", func(t *testing.T) {
+		item := &SetStateItem{Key: "gzip-key3", Value: large}
+		require.NoError(t, c.SaveBulkState(ctx, testStore, item))
+		assert.Equal(t, large, item.Value)
+		assert.Nil(t, item.Metadata)
+
+		require.NoError(t, c.ExecuteStateTransaction(ctx, testStore, nil, []*StateOperation{
+			{Type: StateOperationTypeUpsert, Item: &SetStateItem{Key: "gzip-key1", Value: large}},
+		}))
+
+		items, err := c.GetBulkState(ctx, testStore, []string{"gzip-key1", "gzip-key3"}, nil, 1)
+		require.NoError(t, err)
+		require.Len(t, items, 2)
+		for _, item := range items {
+			assert.Empty(t, item.Error)
+			assert.Equal(t, large, item.Value)
+		}
+	})
+
+	t.Run("metadata is unchanged", func(t *testing.T) {
+		cc := c.(*compressionClient)
+		item, err := cc.compressItem(&SetStateItem{Key: "key", Value: large, Metadata: map[string]string{"ttlInSeconds": "10"}})
+		require.NoError(t, err)
+		assert.True(t, common.IsCompressedPayload(item.Value))
+		assert.Equal(t, map[string]string{"ttlInSeconds": "10"}, item.Metadata)
+	})
+
+	t.Run("small value with compressed header", func(t *testing.T) {
+		compressed, err := common.CompressPayload(common.CompressionGzip, "", []byte(testData))
+		require.NoError(t, err)
+		require.NoError(t, c.SaveState(ctx, testStore, "gzip-key4", compressed, nil))
+
+		item, err := c.GetState(ctx, testStore, "gzip-key4", nil)
+		require.NoError(t, err)
+		assert.Equal(t, compressed, item.Value)
+		require.NoError(t, c.DeleteState(ctx, testStore, "gzip-key4", nil))
+	})
+}
+
+func TestCompressionClientPublishEvent(t *testing.T) {
+	ctx := t.Context()
+	rec := &publishRecorder{Client: testClient}
+	c, err := NewCompressionClient(rec, CompressionOptions{Encoding: common.CompressionDeflate, MinSize: 64})
+	require.NoError(t, err)
+
+	t.Run("struct event is compressed", func(t *testing.T) {
+		event := map[string]string{"message": strings.Repeat(testData, 100)}
+		require.NoError(t, c.PublishEvent(ctx, "messages", "test", event, PublishEventWithMetadata(map[string]string{"key": "value"})))
+
+		assert.Equal(t, common.CompressedContentType, rec.req.GetDataContentType())
+		assert.Equal(t, map[string]string{"key": "value"}, rec.req.GetMetadata())
+
+		data, contentType, err := common.DecompressPayload(rec.req.GetData(), rec.req.GetDataContentType())
+		require.NoError(t, err)
+		assert.Equal(t, "application/json", contentType)
+		assert.JSONEq(t, `{"message":"`+strings.Repeat(testData, 100)+`"}`, string(data))
+	})
+
+	t.Run("content type option is preserved", func(t *testing.T) {
+		event := strings.Repeat(testData, 100)
+		require.NoError(t, c.PublishEvent(ctx, "messages", "test", event, PublishEventWithContentType("text/plain")))
+
+		data, contentType, err := common.DecompressPayload(rec.req.GetData(), rec.req.GetDataContentType())
+		require.NoError(t, err)
+		assert.Equal(t, "text/plain", contentType)
+		assert.Equal(t, event, string(data))
+	})
+
+	t.Run("small event is not compressed", func(t *testing.T) {
+		require.NoError(t, c.PublishEvent(ctx, "messages", "test", testData, PublishEventWithContentType("text/plain")))
+		assert.Equal(t, "text/plain", rec.req.GetDataContentType())
+		assert.Equal(t, testData, string(rec.req.GetData()))
+		assert.Nil(t, rec.req.GetMetadata())
+	})
+}
+
+func TestCompressionClientInvoke(t *testing.T) {
+	ctx := t.Context()
+	c, err := NewCompressionClient(testClient, CompressionOptions{Encoding: common.CompressionZlib, MinSize: 64})
+	require.NoError(t, err)
+
+	large := strings.Repeat(testData, 100)
+
+	t.Run("content is compressed", func(t *testing.T) {
+		// The test server echoes the request content back.
+		out, err := c.InvokeMethodWithContent(ctx, "app", "fn", "post", &DataContent{Data: []byte(large), ContentType: "text/plain"})
+		require.NoError(t, err)
+		require.True(t, common.IsCompressedPayload(out))
+
+		data, contentType, err := common.DecompressPayload(out, "")
+		require.NoError(t, err)
+		assert.Equal(t, "text/plain", contentType)
+		assert.Equal(t, large, string(data))
+	})
+
+	t.Run("custom content is compressed", func(t *testing.T) {
+		out, err := c.InvokeMethodWithCustomContent(ctx, "app", "fn", "post", "application/json", map[string]string{"message": large})
+		require.NoError(t, err)
+
+		data, contentType, err := common.DecompressPayload(out, "")
+		require.NoError(t, err)
+		assert.Equal(t, "application/json", contentType)
+		assert.JSONEq(t, `{"message":"`+large+`"}`, string(data))
+	})
+
+	t.Run("small content is not compressed", func(t *testing.T) {
+		out, err := c.InvokeMethodWithContent(ctx, "app", "fn", "post", &DataContent{Data: []byte(testData), ContentType: "text/plain"})
+		require.NoError(t, err)
+		assert.Equal(t, testData, string(out))
+	})
+}
+
+func TestCompressionClientBulkPublish(t *testing.T) {
+	fake := &fakeBulkPublishClient{}
+	c, err := NewCompressionClient(&GRPCClient{protoClient: fake}, CompressionOptions{Encoding: common.CompressionGzip, MinSize: 64})
+	require.NoError(t, err)
+
+	large := strings.Repeat(testData, 100)
+	entries := []BulkPublishEntry{
+		{EntryID: "e1", Data: map[string]string{"message": large}},
+		{EntryID: "e2", Data: testData},
+		{EntryID: "e3", Data: make(chan struct{})},
+	}
+	res, err := c.BulkPublish(t.Context(), "messages", "test", entries)
+	require.NoError(t, err)
+	assert.NoError(t, res.Entries["e1"])
+	assert.NoError(t, res.Entries["e2"])
+	require.Error(t, res.Entries["e3"])
+	assert.IsType(t, map[string]string{}, entries[0].Data)
+
+	require.Len(t, fake.requests, 1)
+	sent := fake.requests[0].GetEntries()
+	require.Len(t, sent, 2)
+
+	assert.Equal(t, common.CompressedContentType, sent[0].GetContentType())
+	data, contentType, err := common.DecompressPayload(sent[0].GetEvent(), sent[0].GetContentType())
+	require.NoError(t, err)
+	assert.Equal(t, "application/json", contentType)
+	assert.JSONEq(t, `{"message":"`+large+`"}`, string(data))
+
+	assert.Equal(t, "text/plain", sent[1].GetContentType())
+	assert.Equal(t, testData, string(sent[1].GetEvent()))
+}
+
+// invokeRecorder records the last request invoked through it.
+type invokeRecorder struct {
+	Client
+	req *InvokeRequest
+}
+
+func (c *invokeRecorder) Invoke(ctx context.Context, req *InvokeRequest) (*InvokeResponse, error) {
+	c.req = req
+	return &InvokeResponse{}, nil
+}
+
+func TestCompressionClientInvokeRequest(t *testing.T) {
+	rec := &invokeRecorder{Client: testClient}
+	c, err := NewCompressionClient(rec, CompressionOptions{Encoding: common.CompressionZlib, MinSize: 64})
+	require.NoError(t, err)
+
+	large := strings.Repeat(testData, 100)
+	req := NewInvokeRequest("app", "fn", WithInvokeHeader("Content-Type", "text/plain"))
+	req.Data = []byte(large)
+	_, err = c.Invoke(t.Context(), req)
+	require.NoError(t, err)
+
+	assert.Equal(t, large, string(req.Data))
+	assert.Equal(t, common.CompressedContentType, rec.req.ContentType)
+	data, contentType, err := common.DecompressPayload(rec.req.Data, rec.req.ContentType)
+	require.NoError(t, err)
+	assert.Equal(t, "text/plain", contentType)
+	assert.Equal(t, large, string(data))
+
+	req = NewInvokeRequest("app", "fn", WithInvokeData("text/plain", []byte(testData)))
+	_, err = c.Invoke(t.Context(), req)
+	require.NoError(t, err)
+	assert.Same(t, req, rec.req)
+}
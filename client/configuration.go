@@ -6,6 +6,10 @@ import (
 	"fmt"
 	"io"
 
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	commonv1pb "github.com/dapr/dapr/pkg/proto/common/v1"
 	pb "github.com/dapr/dapr/pkg/proto/runtime/v1"
 )
 
@@ -49,15 +53,19 @@ func (c *GRPCClient) GetConfigurationItems(ctx context.Context, storeName string
 		return nil, err
 	}
 
-	configItems := make(map[string]*ConfigurationItem)
-	for k, v := range rsp.GetItems() {
+	return toConfigurationItems(rsp.GetItems()), nil
+}
+
+func toConfigurationItems(items map[string]*commonv1pb.ConfigurationItem) map[string]*ConfigurationItem {
+	configItems := make(map[string]*ConfigurationItem, len(items))
+	for k, v := range items {
 		configItems[k] = &ConfigurationItem{
 			Value:    v.GetValue(),
 			Version:  v.GetVersion(),
 			Metadata: v.GetMetadata(),
 		}
 	}
-	return configItems, nil
+	return configItems
 }
 
 type ConfigurationHandleFunction func(string, map[string]*ConfigurationItem)
@@ -76,38 +84,28 @@ func (c *GRPCClient) SubscribeConfigurationItems(ctx context.Context, storeName
 	if err != nil {
 		return "", fmt.Errorf("subscribe configuration failed with error = %w", err)
 	}
-	subscribeIDChan := make(chan string, 1)
+	// Get the subscription ID from the first response.
+	rsp, err := client.Recv()
+	if err != nil {
+		return "", fmt.Errorf("subscribe configuration failed with error = %w", err)
+	}
+	subscribeID := rsp.GetId()
 	go func() {
-		isFirst := true
 		for {
-			rsp, err := client.Recv()
-			if errors.Is(err, io.EOF) || rsp == nil {
-				// receive goroutine would close if unsubscribe is called.
-				fmt.Println("dapr configuration subscribe finished.")
-				break
-			}
-			configurationItems := make(map[string]*ConfigurationItem)
-
-			for k, v := range rsp.GetItems() {
-				configurationItems[k] = &ConfigurationItem{
-					Value:    v.GetValue(),
-					Version:  v.GetVersion(),
-					Metadata: v.GetMetadata(),
-				}
-			}
-			// Get the subscription ID from the first response.
-			if isFirst {
-				subscribeIDChan <- rsp.GetId()
-				isFirst = false
-			}
 			// Do not invoke handler in case there are no items.
-			if len(configurationItems) > 0 {
+			if configurationItems := toConfigurationItems(rsp.GetItems()); len(configurationItems) > 0 {
 				handler(rsp.GetId(), configurationItems)
 			}
+			rsp, err = client.Recv()
+			if err != nil {
+				// receive goroutine would close if unsubscribe is called.
+				if !errors.Is(err, io.EOF) && status.Code(err) != codes.Canceled {
+					logger.Printf("dapr configuration subscription %s failed: %v", subscribeID, err)
+				}
+				return
+			}
 		}
 	}()
-	subscribeID := <-subscribeIDChan
-	close(subscribeIDChan)
 	return subscribeID, nil
 }
 
@@ -0,0 +1,320 @@
+/*
+Copyright 2026 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package client
+
+import (
+	"context"
+	"encoding"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// ConfigurationTag is the struct tag mapping fields to configuration keys, as
+// in `config:"key"`. The options `required` and `json` may follow the key:
+// required fields must have a non-empty value, and json fields are always
+// decoded as JSON.
+const ConfigurationTag = "config"
+
+var (
+	durationType        = reflect.TypeFor[time.Duration]()
+	textUnmarshalerType = reflect.TypeFor[encoding.TextUnmarshaler]()
+)
+
+// ConfigurationBindOptions are the options of BindConfiguration.
+type ConfigurationBindOptions[T any] struct {
+	// Watch are the options of the underlying configuration watcher.
+	Watch ConfigurationWatchOptions
+	// OnChange is called with the new snapshot and the names of the fields
+	// whose value changed. It is called by the configuration watcher, so it
+	// must not call Close, which waits for the watcher to stop and would
+	// deadlock. Close the binding from another goroutine instead.
+	OnChange func(cfg *T, changed []string)
+	// OnError is called when values received after binding cannot be applied.
+	// The snapshot is left unchanged. Like OnChange, it must not call Close.
+	OnError func(err error)
+}
+
+// ConfigurationBinding keeps a struct bound to configuration items up to date.
+type ConfigurationBinding[T any] struct {
+	fields  []boundField
+	opts    ConfigurationBindOptions[T]
+	watcher *ConfigurationWatcher
+	current atomic.Pointer[T]
+	// lock serializes applying values, which happens both on bind and from the watcher.
+	lock sync.Mutex
+}
+
+type boundField struct {
+	index    []int
+	name     string
+	key      string
+	required bool
+	json     bool
+}
+
+// BindConfiguration binds the tagged fields of T to the configuration items of
+// storeName and keeps them updated through a configuration watcher, which
+// re-subscribes when the subscription breaks.
+//
+// Strings, bools, numbers, time.Duration and encoding.TextUnmarshaler
+// implementations are parsed from their text. Slices are decoded from a JSON
+// array or a comma separated list, and maps and structs from JSON. Keys missing
+// from the store keep their current value, and empty values set the zero value.
+//
+// BindConfiguration returns once the current values are applied, or with an
+// error if they cannot be parsed or a required field is missing.
+func BindConfiguration[T any](ctx context.Context, c Client, storeName string, opts ConfigurationBindOptions[T]) (*ConfigurationBinding[T], error) {
+	fields, err := configurationFields(reflect.TypeFor[T]())
+	if err != nil {
+		return nil, err
+	}
+	b := &ConfigurationBinding[T]{fields: fields, opts: opts}
+	b.current.Store(new(T))
+
+	keys := make([]string, 0, len(fields))
+	for _, f := range fields {
+		keys = append(keys, f.key)
+	}
+
+	bound := make(chan error, 1)
+	var once sync.Once
+	b.watcher, err = c.WatchConfigurationItems(ctx, storeName, keys, func(e ConfigurationEvent) {
+		switch e.Type {
+		case ConfigurationSubscribed, ConfigurationUpdated:
+			first := false
+			once.Do(func() { first = true })
+			err := b.apply(e.Items, e.Type == ConfigurationSubscribed, !first)
+			if first {
+				bound <- err
+			} else if err != nil && b.opts.OnError != nil {
+				b.opts.OnError(err)
+			}
+		case ConfigurationClosed:
+			once.Do(func() {
+				bound <- fmt.Errorf("configuration watcher closed: %w", e.Err)
+			})
+		}
+	}, opts.Watch)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = <-bound; err != nil {
+		b.watcher.Close()
+		return nil, err
+	}
+	return b, nil
+}
+
+// Get returns the current snapshot. It must not be modified.
+func (b *ConfigurationBinding[T]) Get() *T {
+	return b.current.Load()
+}
+
+// Close stops updating the snapshot and waits for the configuration watcher to
+// stop. It must not be called from OnChange or OnError.
+func (b *ConfigurationBinding[T]) Close() error {
+	return b.watcher.Close()
+}
+
+// apply sets the fields bound to items on a copy of the current snapshot and
+// stores it, unless a value cannot be parsed. With all set, items holds every
+// bound key and required fields must be present. OnChange is only called if
+// notify is set.
+func (b *ConfigurationBinding[T]) apply(items map[string]*ConfigurationItem, all, notify bool) error {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+
+	prev := b.current.Load()
+	next := new(T)
+	*next = *prev
+	pv, nv := reflect.ValueOf(prev).Elem(), reflect.ValueOf(next).Elem()
+
+	var errs []error
+	var changed []string
+	for _, f := range b.fields {
+		item, ok := items[f.key]
+		if !ok && !all {
+			continue
+		}
+		if f.required && (!ok || item.Value == "") {
+			errs = append(errs, fmt.Errorf("configuration key %q for field %s is required", f.key, f.name))
+			continue
+		}
+		if !ok {
+			continue
+		}
+
+		v := reflect.New(nv.FieldByIndex(f.index).Type()).Elem()
+		if err := setConfigurationValue(v, item.Value, f.json); err != nil {
+			errs = append(errs, fmt.Errorf("error parsing configuration key %q for field %s: %w", f.key, f.name, err))
+			continue
+		}
+		nv.FieldByIndex(f.index).Set(v)
+		if !reflect.DeepEqual(pv.FieldByIndex(f.index).Interface(), v.Interface()) {
+			changed = append(changed, f.name)
+		}
+	}
+	if len(errs) > 0 {
+		return errors.Join(errs...)
+	}
+	if len(changed) == 0 {
+		return nil
+	}
+
+	b.current.Store(next)
+	if notify && b.opts.OnChange != nil {
+		b.opts.OnChange(next, changed)
+	}
+	return nil
+}
+
+// configurationFields returns the fields of t tagged with ConfigurationTag.
+func configurationFields(t reflect.Type) ([]boundField, error) {
+	if t.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("configuration can only be bound to a struct, not %s", t)
+	}
+
+	var fields []boundField
+	for _, sf := range reflect.VisibleFields(t) {
+		tag, ok := sf.Tag.Lookup(ConfigurationTag)
+		if !ok || tag == "-" {
+			continue
+		}
+		if !sf.IsExported() {
+			return nil, fmt.Errorf("field %s is not exported", sf.Name)
+		}
+		key, options, _ := strings.Cut(tag, ",")
+		if key == "" {
+			return nil, fmt.Errorf("field %s has no configuration key", sf.Name)
+		}
+		f := boundField{index: sf.Index, name: sf.Name, key: key}
+		for opt := range strings.SplitSeq(options, ",") {
+			switch opt {
+			case "":
+			case "required":
+				f.required = true
+			case "json":
+				f.json = true
+			default:
+				return nil, fmt.Errorf("field %s has unknown option %q", sf.Name, opt)
+			}
+		}
+		if !f.json && !parsableType(sf.Type) {
+			return nil, fmt.Errorf("field %s has unsupported type %s", sf.Name, sf.Type)
+		}
+		fields = append(fields, f)
+	}
+	if len(fields) == 0 {
+		return nil, fmt.Errorf("%s has no fields tagged with %q", t, ConfigurationTag)
+	}
+	return fields, nil
+}
+
+func parsableType(t reflect.Type) bool {
+	if t == durationType || reflect.PointerTo(t).Implements(textUnmarshalerType) {
+		return true
+	}
+	switch t.Kind() {
+	case reflect.String, reflect.Bool,
+		reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64,
+		reflect.Map, reflect.Struct, reflect.Array:
+		return true
+	case reflect.Slice, reflect.Pointer:
+		return parsableType(t.Elem())
+	default:
+		return false
+	}
+}
+
+// setConfigurationValue parses value into v, which must be settable.
+func setConfigurationValue(v reflect.Value, value string, asJSON bool) error {
+	if value == "" {
+		v.SetZero()
+		return nil
+	}
+	if asJSON {
+		return json.Unmarshal([]byte(value), v.Addr().Interface())
+	}
+	if u, ok := v.Addr().Interface().(encoding.TextUnmarshaler); ok {
+		return u.UnmarshalText([]byte(value))
+	}
+	if v.Type() == durationType {
+		d, err := time.ParseDuration(value)
+		if err != nil {
+			return err
+		}
+		v.SetInt(int64(d))
+		return nil
+	}
+
+	switch v.Kind() {
+	case reflect.String:
+		v.SetString(value)
+	case reflect.Bool:
+		b, err := strconv.ParseBool(value)
+		if err != nil {
+			return err
+		}
+		v.SetBool(b)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		i, err := strconv.ParseInt(value, 10, v.Type().Bits())
+		if err != nil {
+			return err
+		}
+		v.SetInt(i)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		u, err := strconv.ParseUint(value, 10, v.Type().Bits())
+		if err != nil {
+			return err
+		}
+		v.SetUint(u)
+	case reflect.Float32, reflect.Float64:
+		f, err := strconv.ParseFloat(value, v.Type().Bits())
+		if err != nil {
+			return err
+		}
+		v.SetFloat(f)
+	case reflect.Slice:
+		if strings.HasPrefix(strings.TrimSpace(value), "[") {
+			return json.Unmarshal([]byte(value), v.Addr().Interface())
+		}
+		parts := strings.Split(value, ",")
+		s := reflect.MakeSlice(v.Type(), len(parts), len(parts))
+		for i, part := range parts {
+			if err := setConfigurationValue(s.Index(i), strings.TrimSpace(part), false); err != nil {
+				return err
+			}
+		}
+		v.Set(s)
+	case reflect.Pointer:
+		p := reflect.New(v.Type().Elem())
+		if err := setConfigurationValue(p.Elem(), value, false); err != nil {
+			return err
+		}
+		v.Set(p)
+	default:
+		return json.Unmarshal([]byte(value), v.Addr().Interface())
+	}
+	return nil
+}
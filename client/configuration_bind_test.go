@@ -0,0 +1,140 @@
+/*
+Copyright 2026 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package client
+
+import (
+	"net/netip"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type boundSettings struct {
+	Name     string            `config:"name,required"`
+	Workers  int               `config:"workers"`
+	Ratio    float64           `config:"ratio"`
+	Enabled  bool              `config:"enabled"`
+	Timeout  time.Duration     `config:"timeout"`
+	Hosts    []string          `config:"hosts"`
+	Ports    []uint16          `config:"ports"`
+	Addr     netip.Addr        `config:"addr"`
+	Limit    *int              `config:"limit"`
+	Labels   map[string]string `config:"labels"`
+	Retry    retrySettings     `config:"retry,json"`
+	Internal string
+}
+
+type retrySettings struct {
+	Attempts int `json:"attempts"`
+}
+
+func TestBindConfiguration(t *testing.T) {
+	values := map[string]string{
+		"name":    "orders",
+		"workers": "4",
+		"ratio":   "0.5",
+		"enabled": "true",
+		"timeout": "1m30s",
+		"hosts":   "a.example.com, b.example.com",
+		"ports":   "[80, 443]",
+		"addr":    "10.0.0.1",
+		"limit":   "10",
+		"labels":  `{"team":"payments"}`,
+		"retry":   `{"attempts":3}`,
+	}
+	watch := ConfigurationWatchOptions{RetryInterval: 10 * time.Millisecond}
+
+	t.Run("binds and reloads values", func(t *testing.T) {
+		fake := &fakeConfigurationClient{values: values, streams: make(chan *fakeConfigurationStream, 1)}
+		changes := make(chan []string, 1)
+		errs := make(chan error, 1)
+		b, err := BindConfiguration(t.Context(), &GRPCClient{protoClient: fake}, "store", ConfigurationBindOptions[boundSettings]{
+			Watch:    watch,
+			OnChange: func(_ *boundSettings, changed []string) { changes <- changed },
+			OnError:  func(err error) { errs <- err },
+		})
+		require.NoError(t, err)
+		defer b.Close()
+		stream := <-fake.streams
+
+		cfg := b.Get()
+		assert.Equal(t, "orders", cfg.Name)
+		assert.Equal(t, 4, cfg.Workers)
+		assert.InDelta(t, 0.5, cfg.Ratio, 0)
+		assert.True(t, cfg.Enabled)
+		assert.Equal(t, 90*time.Second, cfg.Timeout)
+		assert.Equal(t, []string{"a.example.com", "b.example.com"}, cfg.Hosts)
+		assert.Equal(t, []uint16{80, 443}, cfg.Ports)
+		assert.Equal(t, netip.MustParseAddr("10.0.0.1"), cfg.Addr)
+		require.NotNil(t, cfg.Limit)
+		assert.Equal(t, 10, *cfg.Limit)
+		assert.Equal(t, map[string]string{"team": "payments"}, cfg.Labels)
+		assert.Equal(t, 3, cfg.Retry.Attempts)
+
+		stream.update(t, "workers", "8")
+		select {
+		case changed := <-changes:
+			assert.Equal(t, []string{"Workers"}, changed)
+		case <-time.After(5 * time.Second):
+			t.Fatal("change not reported")
+		}
+		assert.Equal(t, 8, b.Get().Workers)
+		assert.Equal(t, 4, cfg.Workers, "previous snapshot must not change")
+
+		// Unchanged values are not reported.
+		stream.update(t, "enabled", "true")
+		stream.update(t, "timeout", "forever")
+		select {
+		case err := <-errs:
+			require.ErrorContains(t, err, "timeout")
+		case <-time.After(5 * time.Second):
+			t.Fatal("error not reported")
+		}
+		assert.Empty(t, changes)
+		assert.Equal(t, 90*time.Second, b.Get().Timeout)
+
+		stream.update(t, "name", "")
+		require.ErrorContains(t, <-errs, "required")
+		assert.Equal(t, "orders", b.Get().Name)
+	})
+
+	t.Run("missing required value", func(t *testing.T) {
+		fake := &fakeConfigurationClient{values: map[string]string{"workers": "1"}, streams: make(chan *fakeConfigurationStream, 1)}
+		_, err := BindConfiguration(t.Context(), &GRPCClient{protoClient: fake}, "store", ConfigurationBindOptions[boundSettings]{Watch: watch})
+		require.ErrorContains(t, err, `"name"`)
+		assert.Error(t, (<-fake.streams).ctx.Err())
+	})
+
+	t.Run("invalid value", func(t *testing.T) {
+		fake := &fakeConfigurationClient{values: map[string]string{"name": "orders", "workers": "many"}, streams: make(chan *fakeConfigurationStream, 1)}
+		_, err := BindConfiguration(t.Context(), &GRPCClient{protoClient: fake}, "store", ConfigurationBindOptions[boundSettings]{Watch: watch})
+		require.ErrorContains(t, err, "Workers")
+	})
+
+	t.Run("invalid struct", func(t *testing.T) {
+		c := &GRPCClient{protoClient: &fakeConfigurationClient{}}
+		_, err := BindConfiguration(t.Context(), c, "store", ConfigurationBindOptions[struct{ Name string }]{})
+		require.Error(t, err)
+		_, err = BindConfiguration(t.Context(), c, "store", ConfigurationBindOptions[struct {
+			Ch chan int `config:"ch"`
+		}]{})
+		require.Error(t, err)
+		_, err = BindConfiguration(t.Context(), c, "store", ConfigurationBindOptions[struct {
+			Name string `config:"name,optional"`
+		}]{})
+		require.Error(t, err)
+	})
+}
@@ -0,0 +1,338 @@
+/*
+Copyright 2026 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package client
+
+import (
+	"context"
+	"errors"
+	"iter"
+	"maps"
+	"sync"
+	"time"
+
+	"github.com/cenkalti/backoff/v4"
+
+	pb "github.com/dapr/dapr/pkg/proto/runtime/v1"
+)
+
+const (
+	defaultConfigurationRetryInterval    = 500 * time.Millisecond
+	defaultConfigurationMaxRetryInterval = 30 * time.Second
+)
+
+var errConfigurationWatcherClosed = errors.New("configuration watcher closed")
+
+// ConfigurationEventType is the type of a ConfigurationEvent.
+type ConfigurationEventType int
+
+const (
+	// ConfigurationSubscribed is reported when the subscription is established,
+	// and again after every reconnect. Items holds the current values of the
+	// watched keys.
+	ConfigurationSubscribed ConfigurationEventType = iota
+	// ConfigurationUpdated is reported for every change of the watched keys.
+	ConfigurationUpdated
+	// ConfigurationDisconnected is reported when the subscription stream breaks
+	// and when an attempt to re-subscribe fails. Err holds the cause.
+	ConfigurationDisconnected
+	// ConfigurationClosed is the last event of a watcher.
+	ConfigurationClosed
+)
+
+func (t ConfigurationEventType) String() string {
+	switch t {
+	case ConfigurationSubscribed:
+		return "subscribed"
+	case ConfigurationUpdated:
+		return "updated"
+	case ConfigurationDisconnected:
+		return "disconnected"
+	case ConfigurationClosed:
+		return "closed"
+	default:
+		return "unknown"
+	}
+}
+
+// ConfigurationEvent is a change of the watched configuration items or of the
+// state of the watcher.
+type ConfigurationEvent struct {
+	Type ConfigurationEventType
+	// SubscriptionID is the ID of the subscription the event was received on.
+	SubscriptionID string
+	Items          map[string]*ConfigurationItem
+	// Err is the cause of a ConfigurationDisconnected event. For a
+	// ConfigurationClosed event it is nil if the watcher was closed with Close,
+	// or the context error if its context is done.
+	Err error
+}
+
+// ConfigurationEventHandler is called for every event of a ConfigurationWatcher.
+type ConfigurationEventHandler func(ConfigurationEvent)
+
+// ConfigurationWatchOptions are the options of a ConfigurationWatcher.
+type ConfigurationWatchOptions struct {
+	// Metadata is sent with the subscribe and get requests.
+	Metadata map[string]string
+	// RetryInterval is the initial delay before re-subscribing. It doubles
+	// with every failed attempt, with jitter, up to MaxRetryInterval.
+	// Defaults to 500ms.
+	RetryInterval time.Duration
+	// MaxRetryInterval defaults to 30s.
+	MaxRetryInterval time.Duration
+}
+
+// ConfigurationWatcher watches configuration items, re-subscribing with
+// exponential backoff whenever the subscription stream breaks.
+type ConfigurationWatcher struct {
+	protoClient pb.DaprClient
+	storeName   string
+	keys        []string
+	opts        ConfigurationWatchOptions
+	handler     ConfigurationEventHandler
+
+	ctx    context.Context
+	cancel context.CancelCauseFunc
+	done   chan struct{}
+
+	lock      sync.Mutex
+	id        string
+	connected bool
+}
+
+// configurationStream is an established configuration subscription.
+type configurationStream struct {
+	stream pb.Dapr_SubscribeConfigurationClient
+	cancel context.CancelFunc
+	id     string
+	items  map[string]*ConfigurationItem
+}
+
+// WatchConfigurationItems subscribes to changes of the configuration items of
+// keys, or of all items if keys is empty, and calls handler for every event.
+// Once subscribed, the current values are fetched and reported in a
+// ConfigurationSubscribed event. When the subscription stream breaks it is
+// re-established, and the values are fetched again so no update is missed.
+// Handler is called sequentially and must not call Close.
+// Streams ended by the sidecar are re-established as well. The watcher stops
+// when Close is called or ctx is done; a ConfigurationClosed event is always
+// reported last.
+func (c *GRPCClient) WatchConfigurationItems(ctx context.Context, storeName string, keys []string, handler ConfigurationEventHandler, opts ConfigurationWatchOptions) (*ConfigurationWatcher, error) {
+	if storeName == "" {
+		return nil, errors.New("storeName is empty")
+	}
+	if handler == nil {
+		return nil, errors.New("handler is nil")
+	}
+	if opts.RetryInterval <= 0 {
+		opts.RetryInterval = defaultConfigurationRetryInterval
+	}
+	if opts.MaxRetryInterval <= 0 {
+		opts.MaxRetryInterval = defaultConfigurationMaxRetryInterval
+	}
+
+	w := &ConfigurationWatcher{
+		protoClient: c.protoClient,
+		storeName:   storeName,
+		keys:        keys,
+		opts:        opts,
+		handler:     handler,
+		done:        make(chan struct{}),
+	}
+	w.ctx, w.cancel = context.WithCancelCause(ctx)
+
+	s, err := w.subscribe()
+	if err != nil {
+		w.cancel(errConfigurationWatcherClosed)
+		return nil, err
+	}
+
+	go w.run(s)
+	return w, nil
+}
+
+// WatchConfigurationEvents returns an iterator over the events of a
+// configuration watcher, as started by WatchConfigurationItems. Iteration ends
+// after the ConfigurationClosed event, and the watcher is closed when the loop
+// is exited early. If the watcher cannot be started, its error is yielded once.
+func (c *GRPCClient) WatchConfigurationEvents(ctx context.Context, storeName string, keys []string, opts ConfigurationWatchOptions) iter.Seq2[ConfigurationEvent, error] {
+	return func(yield func(ConfigurationEvent, error) bool) {
+		events := make(chan ConfigurationEvent)
+		stop := make(chan struct{})
+		w, err := c.WatchConfigurationItems(ctx, storeName, keys, func(e ConfigurationEvent) {
+			select {
+			case events <- e:
+			case <-stop:
+			}
+		}, opts)
+		if err != nil {
+			yield(ConfigurationEvent{}, err)
+			return
+		}
+		defer func() {
+			// Events are discarded once the loop is exited.
+			close(stop)
+			w.Close()
+		}()
+
+		for {
+			select {
+			case e := <-events:
+				if !yield(e, nil) || e.Type == ConfigurationClosed {
+					return
+				}
+			case <-w.Done():
+				return
+			}
+		}
+	}
+}
+
+// SubscriptionID returns the ID of the current subscription.
+func (w *ConfigurationWatcher) SubscriptionID() string {
+	w.lock.Lock()
+	defer w.lock.Unlock()
+	return w.id
+}
+
+// Connected reports whether the watcher currently has an established subscription.
+func (w *ConfigurationWatcher) Connected() bool {
+	w.lock.Lock()
+	defer w.lock.Unlock()
+	return w.connected
+}
+
+// Done returns a channel which is closed once the watcher has stopped.
+func (w *ConfigurationWatcher) Done() <-chan struct{} {
+	return w.done
+}
+
+// Close stops the watcher and waits for the ConfigurationClosed event to be handled.
+func (w *ConfigurationWatcher) Close() error {
+	w.cancel(errConfigurationWatcherClosed)
+	<-w.done
+	return nil
+}
+
+func (w *ConfigurationWatcher) run(s *configurationStream) {
+	defer close(w.done)
+
+	for {
+		err := w.watch(s)
+		s.cancel()
+		w.setConnected(false, s.id)
+		if w.ctx.Err() != nil {
+			break
+		}
+
+		logger.Printf("configuration subscription store=%s id=%s disconnected: %v", w.storeName, s.id, err)
+		w.handler(ConfigurationEvent{Type: ConfigurationDisconnected, SubscriptionID: s.id, Err: err})
+		// reconnect only fails once the watcher is stopped
+		if s, err = w.reconnect(); err != nil {
+			break
+		}
+	}
+
+	err := context.Cause(w.ctx)
+	if errors.Is(err, errConfigurationWatcherClosed) {
+		err = nil
+	}
+	w.handler(ConfigurationEvent{Type: ConfigurationClosed, SubscriptionID: w.SubscriptionID(), Err: err})
+}
+
+// watch reports the subscription and its updates until the stream ends.
+func (w *ConfigurationWatcher) watch(s *configurationStream) error {
+	w.setConnected(true, s.id)
+	w.handler(ConfigurationEvent{Type: ConfigurationSubscribed, SubscriptionID: s.id, Items: s.items})
+	for {
+		rsp, err := s.stream.Recv()
+		if err != nil {
+			return err
+		}
+		// Do not invoke handler in case there are no items.
+		if items := toConfigurationItems(rsp.GetItems()); len(items) > 0 {
+			w.handler(ConfigurationEvent{Type: ConfigurationUpdated, SubscriptionID: s.id, Items: items})
+		}
+	}
+}
+
+// reconnect re-subscribes with exponential backoff and jitter until it
+// succeeds or the watcher is stopped.
+func (w *ConfigurationWatcher) reconnect() (*configurationStream, error) {
+	bo := backoff.NewExponentialBackOff()
+	bo.InitialInterval = w.opts.RetryInterval
+	bo.MaxInterval = w.opts.MaxRetryInterval
+	bo.MaxElapsedTime = 0
+
+	var s *configurationStream
+	err := backoff.RetryNotify(func() error {
+		var err error
+		s, err = w.subscribe()
+		return err
+	}, backoff.WithContext(bo, w.ctx), func(err error, next time.Duration) {
+		logger.Printf("error re-subscribing to configuration store=%s, retrying in %s: %v", w.storeName, next, err)
+		w.handler(ConfigurationEvent{Type: ConfigurationDisconnected, SubscriptionID: w.SubscriptionID(), Err: err})
+	})
+	return s, err
+}
+
+// subscribe opens a subscription stream and fetches the current values of the
+// watched items.
+func (w *ConfigurationWatcher) subscribe() (*configurationStream, error) {
+	ctx, cancel := context.WithCancel(w.ctx)
+	stream, err := w.protoClient.SubscribeConfiguration(ctx, &pb.SubscribeConfigurationRequest{
+		StoreName: w.storeName,
+		Keys:      w.keys,
+		Metadata:  w.opts.Metadata,
+	})
+	if err != nil {
+		cancel()
+		return nil, err
+	}
+
+	// The first response carries the subscription ID.
+	rsp, err := stream.Recv()
+	if err != nil {
+		cancel()
+		return nil, err
+	}
+
+	// Values are fetched once the subscription is in place, so changes made in
+	// between are received as updates rather than missed.
+	current, err := w.protoClient.GetConfiguration(ctx, &pb.GetConfigurationRequest{
+		StoreName: w.storeName,
+		Keys:      w.keys,
+		Metadata:  w.opts.Metadata,
+	})
+	if err != nil {
+		cancel()
+		return nil, err
+	}
+
+	items := toConfigurationItems(rsp.GetItems())
+	maps.Copy(items, toConfigurationItems(current.GetItems()))
+	return &configurationStream{
+		stream: stream,
+		cancel: cancel,
+		id:     rsp.GetId(),
+		items:  items,
+	}, nil
+}
+
+func (w *ConfigurationWatcher) setConnected(connected bool, id string) {
+	w.lock.Lock()
+	defer w.lock.Unlock()
+	w.connected = connected
+	w.id = id
+}
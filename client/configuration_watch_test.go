@@ -0,0 +1,286 @@
+/*
+Copyright 2026 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package client
+
+import (
+	"context"
+	"io"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	commonv1pb "github.com/dapr/dapr/pkg/proto/common/v1"
+	pb "github.com/dapr/dapr/pkg/proto/runtime/v1"
+)
+
+// fakeConfigurationStream is a configuration subscription stream which first
+// returns the subscription ID, then the updates and errors sent to it.
+type fakeConfigurationStream struct {
+	grpc.ClientStream
+
+	ctx     context.Context
+	id      string
+	sentID  bool
+	updates chan map[string]string
+	errs    chan error
+}
+
+func (f *fakeConfigurationStream) Recv() (*pb.SubscribeConfigurationResponse, error) {
+	if !f.sentID {
+		f.sentID = true
+		return &pb.SubscribeConfigurationResponse{Id: f.id}, nil
+	}
+	select {
+	case values := <-f.updates:
+		return &pb.SubscribeConfigurationResponse{Id: f.id, Items: configurationItems(values)}, nil
+	case err := <-f.errs:
+		return nil, err
+	case <-f.ctx.Done():
+		return nil, status.Error(codes.Canceled, f.ctx.Err().Error())
+	}
+}
+
+func (f *fakeConfigurationStream) update(t *testing.T, key, value string) {
+	t.Helper()
+	select {
+	case f.updates <- map[string]string{key: value}:
+	case <-time.After(5 * time.Second):
+		t.Fatal("update not received")
+	}
+}
+
+type fakeConfigurationClient struct {
+	pb.DaprClient
+
+	lock    sync.Mutex
+	values  map[string]string
+	streams chan *fakeConfigurationStream
+	// failures is the number of subscribe calls which fail before streams are served.
+	failures atomic.Int32
+	count    atomic.Int32
+}
+
+func (f *fakeConfigurationClient) SubscribeConfiguration(ctx context.Context, in *pb.SubscribeConfigurationRequest, opts ...grpc.CallOption) (pb.Dapr_SubscribeConfigurationClient, error) {
+	if f.failures.Add(-1) >= 0 {
+		return nil, status.Error(codes.Unavailable, "sidecar unavailable")
+	}
+	stream := &fakeConfigurationStream{
+		ctx:     ctx,
+		id:      "sub" + string(rune('0'+f.count.Add(1))),
+		updates: make(chan map[string]string),
+		errs:    make(chan error, 1),
+	}
+	f.streams <- stream
+	return stream, nil
+}
+
+func (f *fakeConfigurationClient) GetConfiguration(ctx context.Context, in *pb.GetConfigurationRequest, opts ...grpc.CallOption) (*pb.GetConfigurationResponse, error) {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	values := make(map[string]string)
+	for _, k := range in.GetKeys() {
+		if v, ok := f.values[k]; ok {
+			values[k] = v
+		}
+	}
+	return &pb.GetConfigurationResponse{Items: configurationItems(values)}, nil
+}
+
+func (f *fakeConfigurationClient) set(key, value string) {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	f.values[key] = value
+}
+
+func configurationItems(values map[string]string) map[string]*commonv1pb.ConfigurationItem {
+	items := make(map[string]*commonv1pb.ConfigurationItem, len(values))
+	for k, v := range values {
+		items[k] = &commonv1pb.ConfigurationItem{Value: v}
+	}
+	return items
+}
+
+func nextConfigurationEvent(t *testing.T, events <-chan ConfigurationEvent) ConfigurationEvent {
+	t.Helper()
+	select {
+	case e := <-events:
+		return e
+	case <-time.After(5 * time.Second):
+		t.Fatal("no configuration event")
+		return ConfigurationEvent{}
+	}
+}
+
+func TestWatchConfigurationItems(t *testing.T) {
+	opts := ConfigurationWatchOptions{RetryInterval: 10 * time.Millisecond, MaxRetryInterval: 20 * time.Millisecond}
+
+	t.Run("invalid arguments", func(t *testing.T) {
+		c := &GRPCClient{protoClient: &fakeConfigurationClient{}}
+		_, err := c.WatchConfigurationItems(t.Context(), "", nil, func(ConfigurationEvent) {}, opts)
+		require.Error(t, err)
+		_, err = c.WatchConfigurationItems(t.Context(), "store", nil, nil, opts)
+		require.Error(t, err)
+	})
+
+	t.Run("reconnects and re-fetches values", func(t *testing.T) {
+		fake := &fakeConfigurationClient{
+			values:  map[string]string{"a": "1", "b": "1"},
+			streams: make(chan *fakeConfigurationStream, 2),
+		}
+		c := &GRPCClient{protoClient: fake}
+		events := make(chan ConfigurationEvent, 10)
+		w, err := c.WatchConfigurationItems(t.Context(), "store", []string{"a", "b"}, func(e ConfigurationEvent) {
+			events <- e
+		}, opts)
+		require.NoError(t, err)
+		stream := <-fake.streams
+
+		e := nextConfigurationEvent(t, events)
+		assert.Equal(t, ConfigurationSubscribed, e.Type)
+		assert.Equal(t, "sub1", e.SubscriptionID)
+		require.Len(t, e.Items, 2)
+		assert.Equal(t, "1", e.Items["a"].Value)
+		assert.True(t, w.Connected())
+
+		stream.update(t, "a", "2")
+		e = nextConfigurationEvent(t, events)
+		assert.Equal(t, ConfigurationUpdated, e.Type)
+		assert.Equal(t, "2", e.Items["a"].Value)
+
+		// The sidecar restarts; b changes while the watcher is disconnected.
+		fake.failures.Store(2)
+		fake.set("b", "2")
+		stream.errs <- status.Error(codes.Unavailable, "connection reset")
+		for range 3 {
+			e = nextConfigurationEvent(t, events)
+			assert.Equal(t, ConfigurationDisconnected, e.Type)
+			assert.Equal(t, codes.Unavailable, status.Code(e.Err))
+		}
+		assert.Error(t, stream.ctx.Err())
+
+		stream = <-fake.streams
+		e = nextConfigurationEvent(t, events)
+		assert.Equal(t, ConfigurationSubscribed, e.Type)
+		assert.Equal(t, "sub2", e.SubscriptionID)
+		assert.Equal(t, "2", e.Items["b"].Value)
+		assert.Equal(t, "sub2", w.SubscriptionID())
+
+		require.NoError(t, w.Close())
+		e = nextConfigurationEvent(t, events)
+		assert.Equal(t, ConfigurationClosed, e.Type)
+		require.NoError(t, e.Err)
+		assert.False(t, w.Connected())
+		assert.Error(t, stream.ctx.Err())
+	})
+
+	t.Run("resubscribes when the sidecar ends the subscription", func(t *testing.T) {
+		fake := &fakeConfigurationClient{values: map[string]string{"a": "1"}, streams: make(chan *fakeConfigurationStream, 2)}
+		c := &GRPCClient{protoClient: fake}
+		events := make(chan ConfigurationEvent, 10)
+		w, err := c.WatchConfigurationItems(t.Context(), "store", []string{"a"}, func(e ConfigurationEvent) {
+			events <- e
+		}, opts)
+		require.NoError(t, err)
+		stream := <-fake.streams
+		assert.Equal(t, ConfigurationSubscribed, nextConfigurationEvent(t, events).Type)
+
+		fake.set("a", "2")
+		stream.errs <- io.EOF
+		e := nextConfigurationEvent(t, events)
+		assert.Equal(t, ConfigurationDisconnected, e.Type)
+		require.ErrorIs(t, e.Err, io.EOF)
+
+		stream = <-fake.streams
+		e = nextConfigurationEvent(t, events)
+		assert.Equal(t, ConfigurationSubscribed, e.Type)
+		assert.Equal(t, "sub2", e.SubscriptionID)
+		assert.Equal(t, "2", e.Items["a"].Value)
+		assert.True(t, w.Connected())
+
+		stream.update(t, "a", "3")
+		e = nextConfigurationEvent(t, events)
+		assert.Equal(t, ConfigurationUpdated, e.Type)
+		assert.Equal(t, "3", e.Items["a"].Value)
+
+		require.NoError(t, w.Close())
+		e = nextConfigurationEvent(t, events)
+		assert.Equal(t, ConfigurationClosed, e.Type)
+		require.NoError(t, e.Err)
+	})
+
+	t.Run("closed when the context is done", func(t *testing.T) {
+		fake := &fakeConfigurationClient{values: map[string]string{}, streams: make(chan *fakeConfigurationStream, 1)}
+		c := &GRPCClient{protoClient: fake}
+		ctx, cancel := context.WithCancel(t.Context())
+		events := make(chan ConfigurationEvent, 10)
+		_, err := c.WatchConfigurationItems(ctx, "store", nil, func(e ConfigurationEvent) {
+			events <- e
+		}, opts)
+		require.NoError(t, err)
+		assert.Equal(t, ConfigurationSubscribed, nextConfigurationEvent(t, events).Type)
+
+		cancel()
+		e := nextConfigurationEvent(t, events)
+		assert.Equal(t, ConfigurationClosed, e.Type)
+		require.ErrorIs(t, e.Err, context.Canceled)
+	})
+}
+
+func TestWatchConfigurationEvents(t *testing.T) {
+	opts := ConfigurationWatchOptions{RetryInterval: 10 * time.Millisecond}
+
+	t.Run("yields events until the loop exits", func(t *testing.T) {
+		fake := &fakeConfigurationClient{
+			values:  map[string]string{"a": "1"},
+			streams: make(chan *fakeConfigurationStream, 1),
+		}
+		c := &GRPCClient{protoClient: fake}
+		streams := make(chan *fakeConfigurationStream, 1)
+		go func() {
+			stream := <-fake.streams
+			streams <- stream
+			stream.updates <- map[string]string{"a": "2"}
+		}()
+
+		var got []ConfigurationEvent
+		for e, err := range c.WatchConfigurationEvents(t.Context(), "store", []string{"a"}, opts) {
+			require.NoError(t, err)
+			got = append(got, e)
+			if e.Type == ConfigurationUpdated {
+				break
+			}
+		}
+		require.Len(t, got, 2)
+		assert.Equal(t, "1", got[0].Items["a"].Value)
+		assert.Equal(t, "2", got[1].Items["a"].Value)
+		assert.Error(t, (<-streams).ctx.Err())
+	})
+
+	t.Run("yields the start error", func(t *testing.T) {
+		c := &GRPCClient{protoClient: &fakeConfigurationClient{}}
+		var errs []error
+		for _, err := range c.WatchConfigurationEvents(t.Context(), "", nil, opts) {
+			errs = append(errs, err)
+		}
+		require.Len(t, errs, 1)
+		require.Error(t, errs[0])
+	})
+}
@@ -0,0 +1,340 @@
+/*
+Copyright 2026 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/anypb"
+
+	v1 "github.com/dapr/dapr/pkg/proto/common/v1"
+	pb "github.com/dapr/dapr/pkg/proto/runtime/v1"
+)
+
+const (
+	// daprHTTPStatusHeader is the header of the status code of the app.
+	daprHTTPStatusHeader = "dapr-http-status"
+	// errorInfoHTTPCode and errorInfoHTTPMessage are the metadata keys of the
+	// status code and body of the app in the ErrorInfo of failed invocations.
+	errorInfoHTTPCode    = "http.code"
+	errorInfoHTTPMessage = "http.error_message"
+)
+
+// InvokeRequest is a service invocation request, as sent by Invoke.
+type InvokeRequest struct {
+	AppID  string
+	Method string
+	// Verb is the HTTP method. Defaults to POST.
+	Verb string
+	// Query is the query string of the request.
+	Query url.Values
+	// Header holds the headers passed to the app.
+	Header http.Header
+	// ContentType is the content type of Data.
+	ContentType string
+	Data        []byte
+	// Timeout, if set, bounds the invocation.
+	Timeout time.Duration
+	// err is the error of an option, returned by Invoke.
+	err error
+}
+
+// InvokeOption configures an InvokeRequest.
+type InvokeOption func(*InvokeRequest)
+
+// NewInvokeRequest returns a request invoking method on appID. Query strings
+// are set with WithInvokeQuery, rather than in method.
+func NewInvokeRequest(appID, method string, opts ...InvokeOption) *InvokeRequest {
+	req := &InvokeRequest{
+		AppID:  appID,
+		Method: method,
+		Verb:   http.MethodPost,
+		Query:  url.Values{},
+		Header: http.Header{},
+	}
+	for _, opt := range opts {
+		opt(req)
+	}
+	return req
+}
+
+// WithInvokeVerb sets the HTTP method of the request.
+func WithInvokeVerb(verb string) InvokeOption {
+	return func(r *InvokeRequest) {
+		r.Verb = verb
+	}
+}
+
+// WithInvokeQuery adds a query parameter to the request.
+func WithInvokeQuery(key, value string) InvokeOption {
+	return func(r *InvokeRequest) {
+		r.Query.Add(key, value)
+	}
+}
+
+// WithInvokeHeader adds a header to the request.
+func WithInvokeHeader(key, value string) InvokeOption {
+	return func(r *InvokeRequest) {
+		r.Header.Add(key, value)
+	}
+}
+
+// WithInvokeTimeout bounds the duration of the invocation.
+func WithInvokeTimeout(timeout time.Duration) InvokeOption {
+	return func(r *InvokeRequest) {
+		r.Timeout = timeout
+	}
+}
+
+// WithInvokeData sets the body of the request.
+func WithInvokeData(contentType string, data []byte) InvokeOption {
+	return func(r *InvokeRequest) {
+		r.ContentType = contentType
+		r.Data = data
+	}
+}
+
+// WithInvokeJSON sets the body of the request to v, encoded as JSON.
+func WithInvokeJSON(v any) InvokeOption {
+	return func(r *InvokeRequest) {
+		data, err := json.Marshal(v)
+		if err != nil {
+			r.err = fmt.Errorf("error serializing input struct: %w", err)
+			return
+		}
+		r.ContentType = "application/json"
+		r.Data = data
+	}
+}
+
+// InvokeResponse is the response of the app to an invocation.
+type InvokeResponse struct {
+	// StatusCode is the HTTP status code of the app, or 200 for gRPC apps.
+	StatusCode int
+	// Header holds the headers of the response.
+	Header      http.Header
+	ContentType string
+	Data        []byte
+}
+
+// InvokeError is the error of invocations the app responded to with a
+// status code other than 2xx.
+type InvokeError struct {
+	AppID      string
+	Method     string
+	StatusCode int
+	Header     http.Header
+	// Body is the body of the response.
+	Body []byte
+	// Err is the error returned by Dapr.
+	Err error
+}
+
+func (e *InvokeError) Error() string {
+	msg := fmt.Sprintf("error invoking method %s on app %s: %d %s", e.Method, e.AppID, e.StatusCode, http.StatusText(e.StatusCode))
+	if len(e.Body) > 0 {
+		msg += ": " + string(e.Body)
+	}
+	return msg
+}
+
+func (e *InvokeError) Unwrap() error {
+	return e.Err
+}
+
+// Invoke invokes a method of an app, with the headers and query of req, and
+// returns the status code, headers and body of the response. Responses with a
+// status code other than 2xx are returned as an *InvokeError.
+func (c *GRPCClient) Invoke(ctx context.Context, req *InvokeRequest) (*InvokeResponse, error) {
+	if req == nil {
+		return nil, errors.New("nil request")
+	}
+	if req.err != nil {
+		return nil, req.err
+	}
+	if err := hasRequiredInvokeArgs(req.AppID, req.Method, req.Verb); err != nil {
+		return nil, fmt.Errorf("missing required parameter: %w", err)
+	}
+	if req.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, req.Timeout)
+		defer cancel()
+	}
+
+	contentType := req.ContentType
+	for k, values := range req.Header {
+		if strings.EqualFold(k, "Content-Type") {
+			// The content type of the data is part of the request message,
+			// and gRPC reserves the content-type metadata key.
+			if contentType == "" && len(values) > 0 {
+				contentType = values[0]
+			}
+			continue
+		}
+		for _, v := range values {
+			ctx = metadata.AppendToOutgoingContext(ctx, k, v)
+		}
+	}
+
+	message := &v1.InvokeRequest{
+		Method:        req.Method,
+		ContentType:   contentType,
+		HttpExtension: queryAndVerbToHTTPExtension(req.Query.Encode(), req.Verb),
+	}
+	if req.Data != nil {
+		message.Data = &anypb.Any{Value: req.Data}
+	}
+
+	var header, trailer metadata.MD
+	resp, err := c.protoClient.InvokeService(ctx, &pb.InvokeServiceRequest{Id: req.AppID, Message: message}, grpc.Header(&header), grpc.Trailer(&trailer))
+	respHeader := responseHeader(metadata.Join(header, trailer))
+	statusCode := http.StatusOK
+	if v := respHeader.Get(daprHTTPStatusHeader); v != "" {
+		if code, convErr := strconv.Atoi(v); convErr == nil {
+			statusCode = code
+		}
+		respHeader.Del(daprHTTPStatusHeader)
+	}
+	if err != nil {
+		return nil, invokeError(req, statusCode, respHeader, err)
+	}
+
+	out := &InvokeResponse{
+		StatusCode:  statusCode,
+		Header:      respHeader,
+		ContentType: resp.GetContentType(),
+		Data:        resp.GetData().GetValue(),
+	}
+	if statusCode < 200 || statusCode > 299 {
+		return out, &InvokeError{
+			AppID:      req.AppID,
+			Method:     req.Method,
+			StatusCode: statusCode,
+			Header:     respHeader,
+			Body:       out.Data,
+		}
+	}
+	return out, nil
+}
+
+// invokeError returns err as an *InvokeError if it holds the response of an
+// HTTP app, or as is.
+func invokeError(req *InvokeRequest, statusCode int, header http.Header, err error) error {
+	s, ok := status.FromError(err)
+	if !ok {
+		return err
+	}
+	for _, d := range s.Details() {
+		info, ok := d.(*errdetails.ErrorInfo)
+		if !ok {
+			continue
+		}
+		code, convErr := strconv.Atoi(info.GetMetadata()[errorInfoHTTPCode])
+		if convErr != nil {
+			continue
+		}
+		return &InvokeError{
+			AppID:      req.AppID,
+			Method:     req.Method,
+			StatusCode: code,
+			Header:     header,
+			Body:       []byte(info.GetMetadata()[errorInfoHTTPMessage]),
+			Err:        err,
+		}
+	}
+	if statusCode != http.StatusOK {
+		return &InvokeError{
+			AppID:      req.AppID,
+			Method:     req.Method,
+			StatusCode: statusCode,
+			Header:     header,
+			Body:       []byte(s.Message()),
+			Err:        err,
+		}
+	}
+	return err
+}
+
+// responseHeader returns the gRPC response metadata as HTTP headers, without
+// the metadata of the gRPC protocol itself.
+func responseHeader(md metadata.MD) http.Header {
+	header := make(http.Header, len(md))
+	for k, values := range md {
+		if k == "content-type" || strings.HasPrefix(k, "grpc-") {
+			continue
+		}
+		for _, v := range values {
+			header.Add(k, v)
+		}
+	}
+	return header
+}
+
+// isNil reports whether v is nil, or a nil pointer, map, slice or interface.
+func isNil(v any) bool {
+	if v == nil {
+		return true
+	}
+	switch rv := reflect.ValueOf(v); rv.Kind() {
+	case reflect.Pointer, reflect.Map, reflect.Slice, reflect.Interface:
+		return rv.IsNil()
+	default:
+		return false
+	}
+}
+
+// JSONResponse is the response of InvokeJSON, with its body decoded.
+type JSONResponse[T any] struct {
+	*InvokeResponse
+	Body T
+}
+
+// InvokeJSON invokes a method of an app with in encoded as JSON, unless it is
+// nil, and decodes the JSON response into Resp. The verb defaults to POST, and
+// the other options set the headers, query and timeout of the request. A
+// response with a status code other than 2xx is returned as an *InvokeError.
+func InvokeJSON[Req, Resp any](ctx context.Context, c Client, appID, method string, in Req, opts ...InvokeOption) (*JSONResponse[Resp], error) {
+	req := NewInvokeRequest(appID, method, WithInvokeHeader("Accept", "application/json"))
+	if !isNil(in) {
+		WithInvokeJSON(in)(req)
+	}
+	for _, opt := range opts {
+		opt(req)
+	}
+
+	resp, err := c.Invoke(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	out := &JSONResponse[Resp]{InvokeResponse: resp}
+	if len(resp.Data) > 0 {
+		if err = json.Unmarshal(resp.Data, &out.Body); err != nil {
+			return out, fmt.Errorf("error decoding response of method %s on app %s: %w", method, appID, err)
+		}
+	}
+	return out, nil
+}
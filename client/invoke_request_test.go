@@ -0,0 +1,201 @@
+/*
+Copyright 2026 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package client
+
+import (
+	"context"
+	"errors"
+	"net"
+	"net/http"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+
+	commonv1pb "github.com/dapr/dapr/pkg/proto/common/v1"
+	pb "github.com/dapr/dapr/pkg/proto/runtime/v1"
+)
+
+// fakeInvokeServer responds to invocations as Dapr does for an HTTP app
+// returning the status in the x-status header of the request, echoing the
+// request back in its body and headers.
+type fakeInvokeServer struct {
+	pb.UnimplementedDaprServer
+}
+
+func (s *fakeInvokeServer) InvokeService(ctx context.Context, req *pb.InvokeServiceRequest) (*commonv1pb.InvokeResponse, error) {
+	md, _ := metadata.FromIncomingContext(ctx)
+	code := http.StatusOK
+	if v := md.Get("x-status"); len(v) > 0 {
+		code, _ = strconv.Atoi(v[0])
+	}
+	if v := md.Get("x-sleep"); len(v) > 0 {
+		d, _ := time.ParseDuration(v[0])
+		select {
+		case <-time.After(d):
+		case <-ctx.Done():
+			return nil, status.FromContextError(ctx.Err()).Err()
+		}
+	}
+
+	msg := req.GetMessage()
+	header := metadata.Pairs(
+		daprHTTPStatusHeader, strconv.Itoa(code),
+		"x-method", msg.GetMethod(),
+		"x-verb", msg.GetHttpExtension().GetVerb().String(),
+		"x-query", msg.GetHttpExtension().GetQuerystring(),
+		"x-content-type", msg.GetContentType(),
+		"x-accept", firstOf(md.Get("accept")),
+		"x-tenant", firstOf(md.Get("x-tenant")),
+	)
+	if err := grpc.SetHeader(ctx, header); err != nil {
+		return nil, err
+	}
+	if code < 200 || code > 299 {
+		st, err := status.New(codes.InvalidArgument, http.StatusText(code)).WithDetails(&errdetails.ErrorInfo{
+			Reason:   http.StatusText(code),
+			Domain:   "dapr.io",
+			Metadata: map[string]string{errorInfoHTTPCode: strconv.Itoa(code), errorInfoHTTPMessage: `{"error":"invalid order"}`},
+		})
+		if err != nil {
+			return nil, err
+		}
+		return nil, st.Err()
+	}
+	return &commonv1pb.InvokeResponse{ContentType: "application/json", Data: msg.GetData()}, nil
+}
+
+func firstOf(values []string) string {
+	if len(values) == 0 {
+		return ""
+	}
+	return values[0]
+}
+
+func newFakeInvokeClient(t *testing.T) Client {
+	t.Helper()
+	s := grpc.NewServer()
+	pb.RegisterDaprServer(s, &fakeInvokeServer{})
+	l := bufconn.Listen(testBufSize)
+	go s.Serve(l)
+	t.Cleanup(s.Stop)
+
+	conn, err := grpc.NewClient("passthrough:///bufnet",
+		grpc.WithContextDialer(func(context.Context, string) (net.Conn, error) { return l.Dial() }),
+		grpc.WithTransportCredentials(insecure.NewCredentials()))
+	require.NoError(t, err)
+	t.Cleanup(func() { conn.Close() })
+	return NewClientWithConnection(conn)
+}
+
+func TestInvoke(t *testing.T) {
+	ctx := t.Context()
+	c := newFakeInvokeClient(t)
+
+	t.Run("request", func(t *testing.T) {
+		resp, err := c.Invoke(ctx, NewInvokeRequest("orders", "orders/search",
+			WithInvokeVerb(http.MethodPut),
+			WithInvokeQuery("status", "open"),
+			WithInvokeQuery("q", "a&b"),
+			WithInvokeHeader("X-Tenant", "acme"),
+			WithInvokeData("text/csv", []byte("a,b")),
+		))
+		require.NoError(t, err)
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+		assert.Equal(t, "orders/search", resp.Header.Get("X-Method"))
+		assert.Equal(t, "PUT", resp.Header.Get("X-Verb"))
+		assert.Equal(t, "q=a%26b&status=open", resp.Header.Get("X-Query"))
+		assert.Equal(t, "acme", resp.Header.Get("X-Tenant"))
+		assert.Equal(t, "text/csv", resp.Header.Get("X-Content-Type"))
+		assert.Empty(t, resp.Header.Get(daprHTTPStatusHeader))
+		assert.Equal(t, "application/json", resp.ContentType)
+		assert.Equal(t, []byte("a,b"), resp.Data)
+	})
+
+	t.Run("content type header", func(t *testing.T) {
+		resp, err := c.Invoke(ctx, NewInvokeRequest("orders", "orders", WithInvokeHeader("Content-Type", "text/plain")))
+		require.NoError(t, err)
+		assert.Equal(t, "text/plain", resp.Header.Get("X-Content-Type"))
+		assert.Equal(t, "POST", resp.Header.Get("X-Verb"))
+	})
+
+	t.Run("status", func(t *testing.T) {
+		resp, err := c.Invoke(ctx, NewInvokeRequest("orders", "orders", WithInvokeHeader("X-Status", "202")))
+		require.NoError(t, err)
+		assert.Equal(t, http.StatusAccepted, resp.StatusCode)
+
+		_, err = c.Invoke(ctx, NewInvokeRequest("orders", "orders", WithInvokeHeader("X-Status", "422")))
+		var invokeErr *InvokeError
+		require.ErrorAs(t, err, &invokeErr)
+		assert.Equal(t, http.StatusUnprocessableEntity, invokeErr.StatusCode)
+		assert.JSONEq(t, `{"error":"invalid order"}`, string(invokeErr.Body))
+		assert.Equal(t, codes.InvalidArgument, status.Code(invokeErr.Err))
+		require.EqualError(t, err, `error invoking method orders on app orders: 422 Unprocessable Entity: {"error":"invalid order"}`)
+	})
+
+	t.Run("timeout", func(t *testing.T) {
+		_, err := c.Invoke(ctx, NewInvokeRequest("orders", "orders", WithInvokeHeader("X-Sleep", "1s"), WithInvokeTimeout(10*time.Millisecond)))
+		require.Equal(t, codes.DeadlineExceeded, status.Code(err))
+	})
+
+	t.Run("invalid requests", func(t *testing.T) {
+		_, err := c.Invoke(ctx, nil)
+		require.Error(t, err)
+		_, err = c.Invoke(ctx, NewInvokeRequest("", "orders"))
+		require.ErrorContains(t, err, "missing required parameter")
+		_, err = c.Invoke(ctx, NewInvokeRequest("orders", "orders", WithInvokeJSON(make(chan int))))
+		require.ErrorContains(t, err, "error serializing input struct")
+	})
+}
+
+func TestInvokeJSON(t *testing.T) {
+	ctx := t.Context()
+	c := newFakeInvokeClient(t)
+
+	resp, err := InvokeJSON[order, order](ctx, c, "orders", "orders", order{ID: "o1", Total: 10}, WithInvokeQuery("dryRun", "true"))
+	require.NoError(t, err)
+	assert.Equal(t, order{ID: "o1", Total: 10}, resp.Body)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, "application/json", resp.Header.Get("X-Content-Type"))
+	assert.Equal(t, "application/json", resp.Header.Get("X-Accept"))
+	assert.Equal(t, "dryRun=true", resp.Header.Get("X-Query"))
+
+	empty, err := InvokeJSON[any, *order](ctx, c, "orders", "orders/o1", nil, WithInvokeVerb(http.MethodGet))
+	require.NoError(t, err)
+	assert.Nil(t, empty.Body, "responses without a body decode to the zero value")
+	assert.Equal(t, "GET", empty.Header.Get("X-Verb"))
+
+	nilOrder, err := InvokeJSON[*order, *order](ctx, c, "orders", "orders/o1", (*order)(nil), WithInvokeVerb(http.MethodGet))
+	require.NoError(t, err)
+	assert.Nil(t, nilOrder.Body, "nil pointers are sent without a body")
+	assert.Empty(t, nilOrder.Header.Get("X-Content-Type"))
+
+	_, err = InvokeJSON[order, order](ctx, c, "orders", "orders", order{}, WithInvokeHeader("X-Status", "500"))
+	var invokeErr *InvokeError
+	require.ErrorAs(t, err, &invokeErr)
+	assert.Equal(t, http.StatusInternalServerError, invokeErr.StatusCode)
+
+	_, err = InvokeJSON[string, order](ctx, c, "orders", "orders", "not an order")
+	require.ErrorContains(t, err, "error decoding response of method orders on app orders")
+	require.False(t, errors.As(err, &invokeErr))
+}
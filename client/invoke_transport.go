@@ -0,0 +1,143 @@
+/*
+Copyright 2026 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package client
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+)
+
+const (
+	daprHTTPPortDefault        = "3500"
+	daprHTTPPortEnvVarName     = "DAPR_HTTP_PORT" /* #nosec */
+	daprHTTPEndpointEnvVarName = "DAPR_HTTP_ENDPOINT"
+	// daprAppIDHeader names the app the sidecar proxies a call to, both as an
+	// HTTP header and as gRPC metadata.
+	daprAppIDHeader = "dapr-app-id"
+)
+
+// InvokeTransportOptions are the options of an InvokeTransport.
+type InvokeTransportOptions struct {
+	// AppID is the app invoked by every request. If empty, requests invoke
+	// the app named by the host of their URL, as in http://orders/v1/orders.
+	AppID string
+	// Endpoint is the URL of the HTTP API of the sidecar. Defaults to the
+	// DAPR_HTTP_ENDPOINT environment variable or, if unset, to localhost on
+	// the DAPR_HTTP_PORT environment variable, or port 3500.
+	Endpoint string
+	// AppIDHeader, if set, sends requests to the sidecar with their path
+	// unchanged and the app ID in the dapr-app-id header, rather than under
+	// /v1.0/invoke/<app-id>/method/.
+	AppIDHeader bool
+	// Base sends the requests to the sidecar. Defaults to http.DefaultTransport.
+	Base http.RoundTripper
+}
+
+// InvokeTransport is an http.RoundTripper which sends requests to other apps
+// through Dapr service invocation, so existing HTTP clients can call Dapr apps
+// by setting it as their transport.
+type InvokeTransport struct {
+	appID       string
+	endpoint    *url.URL
+	appIDHeader bool
+	base        http.RoundTripper
+	authToken   *authToken
+}
+
+// NewInvokeTransport returns an InvokeTransport sending requests to the
+// sidecar with the API token of the client.
+func (c *GRPCClient) NewInvokeTransport(opts InvokeTransportOptions) (*InvokeTransport, error) {
+	endpoint := opts.Endpoint
+	if endpoint == "" {
+		endpoint = os.Getenv(daprHTTPEndpointEnvVarName)
+	}
+	if endpoint == "" {
+		port, ok := os.LookupEnv(daprHTTPPortEnvVarName)
+		if !ok {
+			port = daprHTTPPortDefault
+		}
+		endpoint = "http://localhost:" + port
+	}
+	u, err := url.Parse(endpoint)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing Dapr HTTP endpoint %s: %w", endpoint, err)
+	}
+	if (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return nil, fmt.Errorf("invalid Dapr HTTP endpoint %s: an http or https URL is required", endpoint)
+	}
+
+	base := opts.Base
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	at := c.authToken
+	if at == nil {
+		at = &authToken{}
+	}
+	return &InvokeTransport{
+		appID:       opts.AppID,
+		endpoint:    u,
+		appIDHeader: opts.AppIDHeader,
+		base:        base,
+		authToken:   at,
+	}, nil
+}
+
+// RoundTrip sends req to the sidecar, which invokes the app.
+func (t *InvokeTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	appID := t.appID
+	if appID == "" {
+		appID = req.URL.Hostname()
+	}
+	if appID == "" {
+		if req.Body != nil {
+			req.Body.Close()
+		}
+		return nil, errors.New("app ID required: set InvokeTransportOptions.AppID or the host of the request URL")
+	}
+
+	// A RoundTripper must not modify the request.
+	out := req.Clone(req.Context())
+	out.URL.Scheme = t.endpoint.Scheme
+	out.URL.Host = t.endpoint.Host
+	out.URL.User = t.endpoint.User
+	out.Host = ""
+
+	path, rawPath := req.URL.Path, req.URL.EscapedPath()
+	if !strings.HasPrefix(path, "/") {
+		path, rawPath = "/"+path, "/"+rawPath
+	}
+	prefix := strings.TrimSuffix(t.endpoint.Path, "/")
+	if t.appIDHeader {
+		out.Header.Set(daprAppIDHeader, appID)
+	} else {
+		escaped := url.PathEscape(appID)
+		path = "/v1.0/invoke/" + appID + "/method" + path
+		rawPath = "/v1.0/invoke/" + escaped + "/method" + rawPath
+	}
+	out.URL.Path = prefix + path
+	out.URL.RawPath = prefix + rawPath
+	if out.URL.RawPath == out.URL.Path {
+		out.URL.RawPath = ""
+	}
+
+	if token := t.authToken.get(); token != "" && out.Header.Get(apiTokenKey) == "" {
+		out.Header.Set(apiTokenKey, token)
+	}
+	return t.base.RoundTrip(out)
+}
@@ -0,0 +1,130 @@
+/*
+Copyright 2026 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package client
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeSidecar stands in for the HTTP API of Dapr, and records the last request.
+type fakeSidecar struct {
+	*httptest.Server
+	req  *http.Request
+	body string
+}
+
+func newFakeSidecar(t *testing.T) *fakeSidecar {
+	t.Helper()
+	s := &fakeSidecar{}
+	s.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		b, _ := io.ReadAll(r.Body)
+		s.req, s.body = r, string(b)
+		w.Header().Set("X-App", "orders")
+		w.WriteHeader(http.StatusCreated)
+		io.WriteString(w, "created")
+	}))
+	t.Cleanup(s.Close)
+	return s
+}
+
+func TestInvokeTransport(t *testing.T) {
+	sidecar := newFakeSidecar(t)
+	c := &GRPCClient{authToken: &authToken{}}
+
+	t.Run("invoke path", func(t *testing.T) {
+		c.WithAuthToken("secret")
+		defer c.WithAuthToken("")
+		transport, err := c.NewInvokeTransport(InvokeTransportOptions{Endpoint: sidecar.URL})
+		require.NoError(t, err)
+		client := &http.Client{Transport: transport}
+
+		req, err := http.NewRequestWithContext(t.Context(), http.MethodPost, "http://orders/v1/orders/a%2Fb?dryRun=true", strings.NewReader("order"))
+		require.NoError(t, err)
+		req.Header.Set("X-Tenant", "acme")
+		resp, err := client.Do(req)
+		require.NoError(t, err)
+		defer resp.Body.Close()
+		body, _ := io.ReadAll(resp.Body)
+
+		assert.Equal(t, http.StatusCreated, resp.StatusCode)
+		assert.Equal(t, "orders", resp.Header.Get("X-App"))
+		assert.Equal(t, "created", string(body))
+
+		assert.Equal(t, http.MethodPost, sidecar.req.Method)
+		assert.Equal(t, "/v1.0/invoke/orders/method/v1/orders/a%2Fb", sidecar.req.URL.EscapedPath())
+		assert.Equal(t, "dryRun=true", sidecar.req.URL.RawQuery)
+		assert.Equal(t, "acme", sidecar.req.Header.Get("X-Tenant"))
+		assert.Equal(t, "secret", sidecar.req.Header.Get(apiTokenKey))
+		assert.Empty(t, sidecar.req.Header.Get(daprAppIDHeader))
+		assert.Equal(t, "order", sidecar.body)
+
+		assert.Equal(t, "orders", req.URL.Host, "the request is not modified")
+		assert.Empty(t, req.Header.Get(apiTokenKey))
+	})
+
+	t.Run("app ID header", func(t *testing.T) {
+		transport, err := c.NewInvokeTransport(InvokeTransportOptions{
+			AppID:       "orders",
+			Endpoint:    sidecar.URL + "/proxy/",
+			AppIDHeader: true,
+		})
+		require.NoError(t, err)
+
+		resp, err := (&http.Client{Transport: transport}).Get("http://api.example.com/v1/orders")
+		require.NoError(t, err)
+		resp.Body.Close()
+		assert.Equal(t, "/proxy/v1/orders", sidecar.req.URL.Path)
+		assert.Equal(t, "orders", sidecar.req.Header.Get(daprAppIDHeader))
+		assert.Empty(t, sidecar.req.Header.Get(apiTokenKey))
+	})
+
+	t.Run("environment", func(t *testing.T) {
+		u, err := url.Parse(sidecar.URL)
+		require.NoError(t, err)
+		t.Setenv(daprHTTPEndpointEnvVarName, "")
+		t.Setenv(daprHTTPPortEnvVarName, u.Port())
+		transport, err := c.NewInvokeTransport(InvokeTransportOptions{AppID: "orders"})
+		require.NoError(t, err)
+		resp, err := (&http.Client{Transport: transport}).Get("http://ignored/healthz")
+		require.NoError(t, err)
+		resp.Body.Close()
+		assert.Equal(t, "/v1.0/invoke/orders/method/healthz", sidecar.req.URL.Path)
+
+		t.Setenv(daprHTTPEndpointEnvVarName, sidecar.URL+"/endpoint")
+		transport, err = c.NewInvokeTransport(InvokeTransportOptions{AppID: "orders"})
+		require.NoError(t, err)
+		resp, err = (&http.Client{Transport: transport}).Get("http://ignored/healthz")
+		require.NoError(t, err)
+		resp.Body.Close()
+		assert.Equal(t, "/endpoint/v1.0/invoke/orders/method/healthz", sidecar.req.URL.Path)
+	})
+
+	t.Run("errors", func(t *testing.T) {
+		_, err := c.NewInvokeTransport(InvokeTransportOptions{Endpoint: "localhost:3500"})
+		require.ErrorContains(t, err, "invalid Dapr HTTP endpoint")
+
+		transport, err := c.NewInvokeTransport(InvokeTransportOptions{Endpoint: sidecar.URL})
+		require.NoError(t, err)
+		_, err = transport.RoundTrip(&http.Request{Method: http.MethodGet, URL: &url.URL{Path: "/orders"}, Header: http.Header{}})
+		require.ErrorContains(t, err, "app ID required")
+	})
+}
@@ -17,10 +17,24 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"sync"
+	"time"
+
+	"github.com/cenkalti/backoff/v4"
+	"github.com/google/uuid"
 
 	pb "github.com/dapr/dapr/pkg/proto/runtime/v1"
 )
 
+const (
+	// DefaultLockExpiryInSeconds is the default lease duration of locks acquired with Lock.
+	DefaultLockExpiryInSeconds = 30
+
+	defaultLockRetryInterval    = 100 * time.Millisecond
+	defaultLockMaxRetryInterval = 5 * time.Second
+	lockReleaseTimeout          = 5 * time.Second
+)
+
 // LockRequest is the lock request object.
 type LockRequest struct {
 	ResourceID      string
@@ -98,3 +112,230 @@ func (c *GRPCClient) UnlockAlpha1(ctx context.Context, storeName string, request
 		Status:     pb.UnlockResponse_Status_name[int32(resp.GetStatus())],
 	}, nil
 }
+
+// LockOptions contains options passed to Lock.
+type LockOptions struct {
+	// LockOwner identifies the owner of the lock. Defaults to a random UUID.
+	LockOwner string
+	// ExpiryInSeconds is the lease duration of the lock.
+	// Defaults to DefaultLockExpiryInSeconds.
+	ExpiryInSeconds int32
+	// RenewInterval is the interval at which the lease is renewed.
+	// Defaults to a third of the lease duration. A negative value disables
+	// renewal, in which case the lock is lost once the lease expires.
+	RenewInterval time.Duration
+	// RetryInterval is the initial interval between attempts to acquire the
+	// lock, increased exponentially up to MaxRetryInterval. Defaults to 100ms.
+	RetryInterval time.Duration
+	// MaxRetryInterval is the maximum interval between attempts to acquire the lock.
+	// Defaults to 5s.
+	MaxRetryInterval time.Duration
+}
+
+// Locker is the subset of Client used to acquire and renew locks.
+type Locker interface {
+	TryLockAlpha1(ctx context.Context, storeName string, request *LockRequest) (*LockResponse, error)
+	UnlockAlpha1(ctx context.Context, storeName string, request *UnlockRequest) (*UnlockResponse, error)
+}
+
+// LockHandle is a lock acquired with Lock.
+// The lease of the lock is renewed in the background until Unlock is called,
+// the context passed to Lock is done, or renewal fails.
+type LockHandle struct {
+	locker        Locker
+	storeName     string
+	request       LockRequest
+	renewInterval time.Duration
+
+	lost     chan struct{}
+	lostOnce sync.Once
+	stop     chan struct{}
+	stopOnce sync.Once
+	done     chan struct{}
+
+	releaseOnce sync.Once
+	releaseErr  error
+}
+
+// Lock acquires the lock on resourceID from a lock store, retrying with
+// exponential backoff until the lock is acquired or ctx is done.
+// ctx also bounds the lifetime of the returned handle: the lock is released
+// once ctx is done, so ctx must not be a context meant for acquisition only.
+// See AcquireLock for details.
+func (c *GRPCClient) Lock(ctx context.Context, storeName, resourceID string, opts *LockOptions) (*LockHandle, error) {
+	return AcquireLock(ctx, c, storeName, resourceID, opts)
+}
+
+// AcquireLock acquires the lock on resourceID using l, retrying with
+// exponential backoff while the lock is held by another owner or
+// TryLockAlpha1 fails, until the lock is acquired or ctx is done.
+//
+// The returned handle renews the lease by calling TryLockAlpha1 again with the
+// same owner, which extends the lease in lock stores supporting it. If the
+// lease could not be extended, the lock is lost when the lease expires, as
+// another owner may then acquire it. With lock stores which do not extend the
+// lease of its current owner, the lock is therefore held for one lease only.
+//
+// ctx bounds both the acquisition and the lifetime of the handle: when ctx is
+// done, renewal stops, the lock is released and Lost is closed. Passing a
+// context with a timeout therefore limits how long the lock is held, not only
+// how long to wait for it.
+func AcquireLock(ctx context.Context, l Locker, storeName, resourceID string, opts *LockOptions) (*LockHandle, error) {
+	if storeName == "" {
+		return nil, errors.New("storeName is empty")
+	}
+	if resourceID == "" {
+		return nil, errors.New("resourceID is empty")
+	}
+
+	var o LockOptions
+	if opts != nil {
+		o = *opts
+	}
+	if o.LockOwner == "" {
+		o.LockOwner = uuid.NewString()
+	}
+	if o.ExpiryInSeconds <= 0 {
+		o.ExpiryInSeconds = DefaultLockExpiryInSeconds
+	}
+	if o.RenewInterval == 0 {
+		o.RenewInterval = time.Duration(o.ExpiryInSeconds) * time.Second / 3
+	}
+	if o.RetryInterval <= 0 {
+		o.RetryInterval = defaultLockRetryInterval
+	}
+	if o.MaxRetryInterval <= 0 {
+		o.MaxRetryInterval = defaultLockMaxRetryInterval
+	}
+
+	h := &LockHandle{
+		locker:    l,
+		storeName: storeName,
+		request: LockRequest{
+			ResourceID:      resourceID,
+			LockOwner:       o.LockOwner,
+			ExpiryInSeconds: o.ExpiryInSeconds,
+		},
+		renewInterval: o.RenewInterval,
+		lost:          make(chan struct{}),
+		stop:          make(chan struct{}),
+		done:          make(chan struct{}),
+	}
+
+	bo := backoff.NewExponentialBackOff()
+	bo.InitialInterval = o.RetryInterval
+	bo.MaxInterval = o.MaxRetryInterval
+	bo.MaxElapsedTime = 0
+	bo.Reset()
+
+	var lastErr error
+	for {
+		acquiredAt := time.Now()
+		resp, err := l.TryLockAlpha1(ctx, storeName, &h.request)
+		switch {
+		case err != nil:
+			lastErr = err
+		case resp.Success:
+			go h.renew(ctx, acquiredAt)
+			return h, nil
+		}
+
+		t := time.NewTimer(bo.NextBackOff())
+		select {
+		case <-ctx.Done():
+			t.Stop()
+			if lastErr != nil {
+				return nil, fmt.Errorf("error acquiring lock %s: %w (last error: %v)", resourceID, ctx.Err(), lastErr)
+			}
+			return nil, fmt.Errorf("error acquiring lock %s: %w", resourceID, ctx.Err())
+		case <-t.C:
+		}
+	}
+}
+
+// Owner returns the owner the lock is held by.
+func (h *LockHandle) Owner() string {
+	return h.request.LockOwner
+}
+
+// Lost returns a channel which is closed when the lock is no longer held,
+// because renewal failed, the lease expired or the context passed to Lock is done.
+// It is not closed by Unlock.
+func (h *LockHandle) Lost() <-chan struct{} {
+	return h.lost
+}
+
+// Unlock stops renewing the lease and releases the lock.
+// It is safe to call Unlock more than once.
+func (h *LockHandle) Unlock(ctx context.Context) error {
+	h.stopOnce.Do(func() { close(h.stop) })
+	<-h.done
+	return h.release(ctx)
+}
+
+func (h *LockHandle) release(ctx context.Context) error {
+	h.releaseOnce.Do(func() {
+		resp, err := h.locker.UnlockAlpha1(ctx, h.storeName, &UnlockRequest{
+			ResourceID: h.request.ResourceID,
+			LockOwner:  h.request.LockOwner,
+		})
+		switch {
+		case err != nil:
+			h.releaseErr = err
+		case resp.StatusCode != int32(pb.UnlockResponse_SUCCESS):
+			h.releaseErr = fmt.Errorf("error releasing lock %s: %s", h.request.ResourceID, resp.Status)
+		}
+	})
+	return h.releaseErr
+}
+
+func (h *LockHandle) markLost() {
+	h.lostOnce.Do(func() { close(h.lost) })
+}
+
+// renew extends the lease every renewInterval until the handle is unlocked,
+// ctx is done or the lease expires. Failed or rejected renewals are retried on
+// the next interval while the lease is valid. The lease is measured from before
+// the call granting it, so the lock is lost no later than the lock store
+// expires it.
+func (h *LockHandle) renew(ctx context.Context, acquiredAt time.Time) {
+	defer close(h.done)
+
+	lease := time.Duration(h.request.ExpiryInSeconds) * time.Second
+	expired := time.NewTimer(time.Until(acquiredAt.Add(lease)))
+	defer expired.Stop()
+
+	var tick <-chan time.Time
+	if h.renewInterval > 0 {
+		ticker := time.NewTicker(h.renewInterval)
+		defer ticker.Stop()
+		tick = ticker.C
+	}
+
+	for {
+		select {
+		case <-h.stop:
+			return
+		case <-ctx.Done():
+			h.markLost()
+			releaseCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), lockReleaseTimeout)
+			if err := h.release(releaseCtx); err != nil {
+				logger.Printf("error releasing lock %s after context cancellation: %v", h.request.ResourceID, err)
+			}
+			cancel()
+			return
+		case <-expired.C:
+			h.markLost()
+			return
+		case <-tick:
+			start := time.Now()
+			resp, err := h.locker.TryLockAlpha1(ctx, h.storeName, &h.request)
+			switch {
+			case err != nil:
+				logger.Printf("error renewing lock %s: %v", h.request.ResourceID, err)
+			case resp.Success:
+				expired.Reset(time.Until(start.Add(lease)))
+			}
+		}
+	}
+}
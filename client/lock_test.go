@@ -14,7 +14,11 @@ limitations under the License.
 package client
 
 import (
+	"context"
+	"errors"
+	"sync"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/require"
 
@@ -78,3 +82,272 @@ func TestLock(t *testing.T) {
 		assert.Equal(t, pb.UnlockResponse_SUCCESS.String(), r.Status)
 	})
 }
+
+// fakeLockStore is an in-memory lock store with lease expiry.
+// When reentrant is set, TryLockAlpha1 by the current owner extends the lease.
+type fakeLockStore struct {
+	lock      sync.Mutex
+	reentrant bool
+	tryErr    error
+	leases    map[string]fakeLease
+}
+
+type fakeLease struct {
+	owner   string
+	expires time.Time
+}
+
+func newFakeLockStore(reentrant bool) *fakeLockStore {
+	return &fakeLockStore{reentrant: reentrant, leases: make(map[string]fakeLease)}
+}
+
+func (s *fakeLockStore) TryLockAlpha1(ctx context.Context, storeName string, request *LockRequest) (*LockResponse, error) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	if s.tryErr != nil {
+		return nil, s.tryErr
+	}
+	if l, ok := s.leases[request.ResourceID]; ok && time.Now().Before(l.expires) {
+		if l.owner != request.LockOwner || !s.reentrant {
+			return &LockResponse{}, nil
+		}
+	}
+	s.leases[request.ResourceID] = fakeLease{
+		owner:   request.LockOwner,
+		expires: time.Now().Add(time.Duration(request.ExpiryInSeconds) * time.Second),
+	}
+	return &LockResponse{Success: true}, nil
+}
+
+func (s *fakeLockStore) UnlockAlpha1(ctx context.Context, storeName string, request *UnlockRequest) (*UnlockResponse, error) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	status := pb.UnlockResponse_SUCCESS
+	l, ok := s.leases[request.ResourceID]
+	switch {
+	case !ok || !time.Now().Before(l.expires):
+		status = pb.UnlockResponse_LOCK_DOES_NOT_EXIST
+	case l.owner != request.LockOwner:
+		status = pb.UnlockResponse_LOCK_BELONGS_TO_OTHERS
+	default:
+		delete(s.leases, request.ResourceID)
+	}
+	return &UnlockResponse{StatusCode: int32(status), Status: status.String()}, nil
+}
+
+func (s *fakeLockStore) owner(resourceID string) string {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	if l, ok := s.leases[resourceID]; ok && time.Now().Before(l.expires) {
+		return l.owner
+	}
+	return ""
+}
+
+// setLease makes owner hold the lock on resourceID for lease, as if it had
+// acquired it.
+func (s *fakeLockStore) setLease(resourceID, owner string, lease time.Duration) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.leases[resourceID] = fakeLease{owner: owner, expires: time.Now().Add(lease)}
+}
+
+func (s *fakeLockStore) setTryErr(err error) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.tryErr = err
+}
+
+func TestLockHandle(t *testing.T) {
+	ctx := t.Context()
+
+	t.Run("lock and unlock", func(t *testing.T) {
+		h, err := testClient.Lock(ctx, testLockStore, "resource1", nil)
+		require.NoError(t, err)
+		assert.NotEmpty(t, h.Owner())
+		require.NoError(t, h.Unlock(ctx))
+		require.NoError(t, h.Unlock(ctx))
+	})
+
+	t.Run("lock without resource", func(t *testing.T) {
+		_, err := testClient.Lock(ctx, testLockStore, "", nil)
+		require.Error(t, err)
+	})
+
+	t.Run("contention", func(t *testing.T) {
+		store := newFakeLockStore(false)
+		h1, err := AcquireLock(ctx, store, testLockStore, "res", &LockOptions{LockOwner: "owner1"})
+		require.NoError(t, err)
+
+		acquired := make(chan *LockHandle)
+		go func() {
+			h2, err := AcquireLock(ctx, store, testLockStore, "res", &LockOptions{LockOwner: "owner2", RetryInterval: 10 * time.Millisecond})
+			assert.NoError(t, err)
+			acquired <- h2
+		}()
+
+		select {
+		case <-acquired:
+			t.Fatal("lock acquired while held by another owner")
+		case <-time.After(200 * time.Millisecond):
+		}
+		require.NoError(t, h1.Unlock(ctx))
+
+		select {
+		case h2 := <-acquired:
+			assert.Equal(t, "owner2", store.owner("res"))
+			require.NoError(t, h2.Unlock(ctx))
+		case <-time.After(5 * time.Second):
+			t.Fatal("lock not acquired after release")
+		}
+	})
+
+	t.Run("retries errors while acquiring", func(t *testing.T) {
+		store := newFakeLockStore(false)
+		store.setTryErr(errors.New("unavailable"))
+		go func() {
+			time.Sleep(100 * time.Millisecond)
+			store.setTryErr(nil)
+		}()
+
+		h, err := AcquireLock(ctx, store, testLockStore, "res", &LockOptions{RetryInterval: 10 * time.Millisecond})
+		require.NoError(t, err)
+		assert.Equal(t, h.Owner(), store.owner("res"))
+		require.NoError(t, h.Unlock(ctx))
+	})
+
+	t.Run("last error when context is done while acquiring", func(t *testing.T) {
+		store := newFakeLockStore(false)
+		store.setTryErr(errors.New("unavailable"))
+
+		waitCtx, cancel := context.WithTimeout(ctx, 100*time.Millisecond)
+		defer cancel()
+		_, err := AcquireLock(waitCtx, store, testLockStore, "res", &LockOptions{RetryInterval: 10 * time.Millisecond})
+		require.ErrorIs(t, err, context.DeadlineExceeded)
+		assert.ErrorContains(t, err, "unavailable")
+	})
+
+	t.Run("context done while waiting", func(t *testing.T) {
+		store := newFakeLockStore(false)
+		h1, err := AcquireLock(ctx, store, testLockStore, "res", nil)
+		require.NoError(t, err)
+		defer func() { _ = h1.Unlock(ctx) }()
+
+		waitCtx, cancel := context.WithTimeout(ctx, 100*time.Millisecond)
+		defer cancel()
+		_, err = AcquireLock(waitCtx, store, testLockStore, "res", &LockOptions{RetryInterval: 10 * time.Millisecond})
+		require.ErrorIs(t, err, context.DeadlineExceeded)
+	})
+
+	t.Run("lost at lease expiry when the lease is not extended", func(t *testing.T) {
+		store := newFakeLockStore(false)
+		start := time.Now()
+		h, err := AcquireLock(ctx, store, testLockStore, "res", &LockOptions{
+			LockOwner:       "owner1",
+			ExpiryInSeconds: 1,
+			RenewInterval:   100 * time.Millisecond,
+		})
+		require.NoError(t, err)
+
+		select {
+		case <-h.Lost():
+		case <-time.After(3 * time.Second):
+			t.Fatal("lock not lost after lease expiry")
+		}
+		assert.Less(t, time.Since(start), 1200*time.Millisecond)
+
+		// the lock is not re-acquired after the lease expired
+		h2, err := AcquireLock(ctx, store, testLockStore, "res", &LockOptions{LockOwner: "owner2"})
+		require.NoError(t, err)
+		time.Sleep(300 * time.Millisecond)
+		assert.Equal(t, "owner2", store.owner("res"))
+		require.NoError(t, h2.Unlock(ctx))
+	})
+
+	t.Run("lease is extended by re-entrant lock stores", func(t *testing.T) {
+		store := newFakeLockStore(true)
+		h, err := AcquireLock(ctx, store, testLockStore, "res", &LockOptions{
+			LockOwner:       "owner1",
+			ExpiryInSeconds: 1,
+			RenewInterval:   100 * time.Millisecond,
+		})
+		require.NoError(t, err)
+
+		// the lease never expires, so another owner can not take the lock
+		for range 15 {
+			time.Sleep(100 * time.Millisecond)
+			resp, err := store.TryLockAlpha1(ctx, testLockStore, &LockRequest{ResourceID: "res", LockOwner: "owner2", ExpiryInSeconds: 1})
+			require.NoError(t, err)
+			require.False(t, resp.Success)
+		}
+		select {
+		case <-h.Lost():
+			t.Fatal("lock lost while being renewed")
+		default:
+		}
+		require.NoError(t, h.Unlock(ctx))
+	})
+
+	t.Run("lost when lease expires without renewal", func(t *testing.T) {
+		store := newFakeLockStore(false)
+		h, err := AcquireLock(ctx, store, testLockStore, "res", &LockOptions{ExpiryInSeconds: 1, RenewInterval: -1})
+		require.NoError(t, err)
+
+		select {
+		case <-h.Lost():
+		case <-time.After(3 * time.Second):
+			t.Fatal("lock not lost after lease expiry")
+		}
+
+		h2, err := AcquireLock(ctx, store, testLockStore, "res", &LockOptions{LockOwner: "owner2"})
+		require.NoError(t, err)
+		require.Error(t, h.Unlock(ctx))
+		assert.Equal(t, "owner2", store.owner("res"))
+		require.NoError(t, h2.Unlock(ctx))
+	})
+
+	t.Run("lost when another owner holds the lock", func(t *testing.T) {
+		store := newFakeLockStore(true)
+		h, err := AcquireLock(ctx, store, testLockStore, "res", &LockOptions{ExpiryInSeconds: 1, RenewInterval: 50 * time.Millisecond})
+		require.NoError(t, err)
+		store.setLease("res", "owner2", 10*time.Second)
+
+		select {
+		case <-h.Lost():
+		case <-time.After(4 * time.Second):
+			t.Fatal("lock not lost after another owner acquired it")
+		}
+		require.Error(t, h.Unlock(ctx))
+		assert.Equal(t, "owner2", store.owner("res"))
+	})
+
+	t.Run("lost when renewal keeps failing", func(t *testing.T) {
+		store := newFakeLockStore(false)
+		h, err := AcquireLock(ctx, store, testLockStore, "res", &LockOptions{ExpiryInSeconds: 1, RenewInterval: 100 * time.Millisecond})
+		require.NoError(t, err)
+		store.setTryErr(errors.New("unavailable"))
+
+		select {
+		case <-h.Lost():
+		case <-time.After(4 * time.Second):
+			t.Fatal("lock not lost after renewal failed")
+		}
+	})
+
+	t.Run("released when context is done", func(t *testing.T) {
+		store := newFakeLockStore(false)
+		lockCtx, cancel := context.WithCancel(ctx)
+		h, err := AcquireLock(lockCtx, store, testLockStore, "res", nil)
+		require.NoError(t, err)
+		assert.Equal(t, h.Owner(), store.owner("res"))
+
+		cancel()
+		select {
+		case <-h.Lost():
+		case <-time.After(3 * time.Second):
+			t.Fatal("lock not lost after context cancellation")
+		}
+		require.NoError(t, h.Unlock(ctx))
+		assert.Empty(t, store.owner("res"))
+	})
+}
@@ -0,0 +1,383 @@
+/*
+Copyright 2026 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package client
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"path"
+	"strings"
+	"time"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+
+	v1 "github.com/dapr/dapr/pkg/proto/common/v1"
+	pb "github.com/dapr/dapr/pkg/proto/runtime/v1"
+	"github.com/dapr/go-sdk/version"
+)
+
+const (
+	instrumentationName      = "github.com/dapr/go-sdk/client"
+	metricsOperationDuration = "dapr.client.operation.duration"
+)
+
+// Operation is a call of the Dapr API made by the client, as seen by
+// middlewares.
+//
+// Streaming operations are only named: middlewares see the opening of the
+// stream, before any message is sent, so only Name, BuildingBlock and
+// Streaming are set.
+type Operation struct {
+	// Name is the name of the Dapr API called, such as GetState.
+	Name string
+	// BuildingBlock is the building block of the API.
+	BuildingBlock BuildingBlock
+	// Component is the name of the state store, pub/sub, binding, secret
+	// store, configuration store, lock store or conversation component.
+	Component string
+	// Keys are the keys of the state, secrets, configuration items or lock
+	// resources of the call.
+	Keys []string
+	// Topic is the topic events are published to.
+	Topic string
+	// AppID is the ID of the invoked app.
+	AppID string
+	// Method is the invoked method of an app or actor, or the operation of
+	// an output binding.
+	Method    string
+	ActorType string
+	ActorID   string
+	// Streaming is set for streaming APIs. The other fields describing the
+	// call are then empty, and Request and Response are nil.
+	Streaming bool
+	// Request is the request message of the call. Middlewares may modify it
+	// before calling the next handler, the fields above are not read again.
+	Request proto.Message
+	// Response is the response message, set once the call succeeded.
+	Response proto.Message
+}
+
+// String describes the operation, for logs.
+func (op *Operation) String() string {
+	var b strings.Builder
+	b.WriteString(op.Name)
+	for _, kv := range [][2]string{
+		{"component", op.Component},
+		{"topic", op.Topic},
+		{"app", op.AppID},
+		{"actorType", op.ActorType},
+		{"actorID", op.ActorID},
+		{"method", op.Method},
+	} {
+		if kv[1] != "" {
+			fmt.Fprintf(&b, " %s=%s", kv[0], kv[1])
+		}
+	}
+	if len(op.Keys) > 0 {
+		fmt.Fprintf(&b, " keys=%v", op.Keys)
+	}
+	return b.String()
+}
+
+// Handler executes an operation.
+type Handler func(ctx context.Context, op *Operation) error
+
+// Middleware wraps the handler of the operations of a client. A middleware
+// can inspect or modify the operation, fail it without calling next, and
+// inspect the response or error once next returns.
+//
+// Middlewares are gRPC interceptors of the connection of the client, so they
+// have limits:
+//   - Request and Response are the protobuf messages of the Dapr API rather
+//     than the types of the client. OnRequest and OnResponse call typed hooks
+//     with them, and StateKeyMiddleware rewrites state keys both ways.
+//   - Responses are read by the client once the handler returns, so they must
+//     be modified in place; setting Response to another message has no effect.
+//   - Streaming operations are only named, and their messages are not seen.
+//   - Calls not made over the gRPC connection, such as the HTTP requests of
+//     an InvokeTransport, do not go through middlewares.
+type Middleware func(next Handler) Handler
+
+// OnRequest returns a middleware calling fn with the requests of type Req,
+// such as *pb.SaveStateRequest, before they are sent. fn may modify the
+// request, or fail the operation by returning an error.
+func OnRequest[Req proto.Message](fn func(ctx context.Context, req Req) error) Middleware {
+	return func(next Handler) Handler {
+		return func(ctx context.Context, op *Operation) error {
+			if req, ok := op.Request.(Req); ok {
+				if err := fn(ctx, req); err != nil {
+					return err
+				}
+			}
+			return next(ctx, op)
+		}
+	}
+}
+
+// OnResponse returns a middleware calling fn with the responses of type Resp,
+// such as *pb.GetBulkStateResponse, once the call succeeded. fn may modify the
+// response in place, or fail the operation by returning an error.
+func OnResponse[Resp proto.Message](fn func(ctx context.Context, resp Resp) error) Middleware {
+	return func(next Handler) Handler {
+		return func(ctx context.Context, op *Operation) error {
+			if err := next(ctx, op); err != nil {
+				return err
+			}
+			if resp, ok := op.Response.(Resp); ok {
+				return fn(ctx, resp)
+			}
+			return nil
+		}
+	}
+}
+
+// StateKeyMiddleware returns a middleware rewriting the keys of state
+// operations with toStore, and the keys of the items returned by GetBulkState
+// and QueryState back with fromStore, for instance to prefix the keys with a
+// tenant:
+//
+//	prefix := dapr.StateKeyMiddleware(
+//		func(ctx context.Context, key string) string { return "acme||" + key },
+//		func(ctx context.Context, key string) string { return strings.TrimPrefix(key, "acme||") },
+//	)
+//
+// The keys of actor state are not rewritten.
+func StateKeyMiddleware(toStore, fromStore func(ctx context.Context, key string) string) Middleware {
+	return func(next Handler) Handler {
+		return func(ctx context.Context, op *Operation) error {
+			switch r := op.Request.(type) {
+			case *pb.GetStateRequest:
+				r.Key = toStore(ctx, r.GetKey())
+			case *pb.GetBulkStateRequest:
+				for i, k := range r.GetKeys() {
+					r.Keys[i] = toStore(ctx, k)
+				}
+			case *pb.SaveStateRequest:
+				for _, s := range r.GetStates() {
+					s.Key = toStore(ctx, s.GetKey())
+				}
+			case *pb.DeleteStateRequest:
+				r.Key = toStore(ctx, r.GetKey())
+			case *pb.DeleteBulkStateRequest:
+				for _, s := range r.GetStates() {
+					s.Key = toStore(ctx, s.GetKey())
+				}
+			case *pb.ExecuteStateTransactionRequest:
+				for _, o := range r.GetOperations() {
+					if s := o.GetRequest(); s != nil {
+						s.Key = toStore(ctx, s.GetKey())
+					}
+				}
+			}
+
+			if err := next(ctx, op); err != nil {
+				return err
+			}
+
+			switch r := op.Response.(type) {
+			case *pb.GetBulkStateResponse:
+				for _, item := range r.GetItems() {
+					item.Key = fromStore(ctx, item.GetKey())
+				}
+			case *pb.QueryStateResponse:
+				for _, item := range r.GetResults() {
+					item.Key = fromStore(ctx, item.GetKey())
+				}
+			}
+			return nil
+		}
+	}
+}
+
+// MiddlewareDialOptions returns the dial options applying mws to every call of
+// the client, the first middleware being the outermost. For streaming APIs,
+// middlewares wrap the opening of the stream, and the messages of the stream
+// are not seen:
+//
+//	client, err := dapr.NewClient(dapr.MiddlewareDialOptions(
+//		dapr.LoggingMiddleware(nil),
+//		tenantMiddleware,
+//	)...)
+func MiddlewareDialOptions(mws ...Middleware) []grpc.DialOption {
+	return []grpc.DialOption{
+		grpc.WithChainUnaryInterceptor(middlewareUnaryInterceptor(mws)),
+		grpc.WithChainStreamInterceptor(middlewareStreamInterceptor(mws)),
+	}
+}
+
+func chain(mws []Middleware, h Handler) Handler {
+	for i := len(mws) - 1; i >= 0; i-- {
+		h = mws[i](h)
+	}
+	return h
+}
+
+func middlewareUnaryInterceptor(mws []Middleware) grpc.UnaryClientInterceptor {
+	return func(ctx context.Context, method string, req, reply any, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		op := newOperation(method, req)
+		h := chain(mws, func(ctx context.Context, op *Operation) error {
+			if err := invoker(ctx, method, op.Request, reply, cc, opts...); err != nil {
+				return err
+			}
+			op.Response, _ = reply.(proto.Message)
+			return nil
+		})
+		return h(ctx, op)
+	}
+}
+
+func middlewareStreamInterceptor(mws []Middleware) grpc.StreamClientInterceptor {
+	return func(ctx context.Context, desc *grpc.StreamDesc, cc *grpc.ClientConn, method string, streamer grpc.Streamer, opts ...grpc.CallOption) (grpc.ClientStream, error) {
+		op := newOperation(method, nil)
+		op.Streaming = true
+		var stream grpc.ClientStream
+		h := chain(mws, func(ctx context.Context, op *Operation) (err error) {
+			stream, err = streamer(ctx, desc, cc, method, opts...)
+			return err
+		})
+		if err := h(ctx, op); err != nil {
+			return nil, err
+		}
+		return stream, nil
+	}
+}
+
+// newOperation describes the call of method with req, from the fields of the
+// requests of the Dapr APIs.
+func newOperation(method string, req any) *Operation {
+	op := &Operation{
+		Name:          path.Base(method),
+		BuildingBlock: classify(method, req).block,
+	}
+	msg, ok := req.(proto.Message)
+	if !ok {
+		return op
+	}
+	op.Request = msg
+
+	if r, ok := req.(interface{ GetStoreName() string }); ok {
+		op.Component = r.GetStoreName()
+	}
+	if r, ok := req.(interface{ GetPubsubName() string }); ok {
+		op.Component = r.GetPubsubName()
+	}
+	if r, ok := req.(interface{ GetTopic() string }); ok {
+		op.Topic = r.GetTopic()
+	}
+	if r, ok := req.(interface{ GetKey() string }); ok {
+		op.Keys = []string{r.GetKey()}
+	}
+	if r, ok := req.(interface{ GetKeys() []string }); ok {
+		op.Keys = r.GetKeys()
+	}
+	if r, ok := req.(interface{ GetStates() []*v1.StateItem }); ok {
+		for _, s := range r.GetStates() {
+			op.Keys = append(op.Keys, s.GetKey())
+		}
+	}
+	if r, ok := req.(interface{ GetResourceId() string }); ok {
+		op.Keys = []string{r.GetResourceId()}
+	}
+	if r, ok := req.(interface{ GetActorType() string }); ok {
+		op.ActorType = r.GetActorType()
+	}
+	if r, ok := req.(interface{ GetActorId() string }); ok {
+		op.ActorID = r.GetActorId()
+	}
+
+	switch r := req.(type) {
+	case *pb.ExecuteStateTransactionRequest:
+		for _, o := range r.GetOperations() {
+			op.Keys = append(op.Keys, o.GetRequest().GetKey())
+		}
+	case *pb.ExecuteActorStateTransactionRequest:
+		for _, o := range r.GetOperations() {
+			op.Keys = append(op.Keys, o.GetKey())
+		}
+	case *pb.InvokeServiceRequest:
+		op.AppID = r.GetId()
+		op.Method = r.GetMessage().GetMethod()
+	case *pb.InvokeActorRequest:
+		op.Method = r.GetMethod()
+	case *pb.InvokeBindingRequest:
+		op.Component = r.GetName()
+		op.Method = r.GetOperation()
+	case *pb.ConversationRequest:
+		op.Component = r.GetName()
+	case *pb.ConversationRequestAlpha2:
+		op.Component = r.GetName()
+	}
+	return op
+}
+
+// LoggingMiddleware returns a middleware logging every operation with its
+// duration and error to l, or to the logger of the client if l is nil.
+func LoggingMiddleware(l *log.Logger) Middleware {
+	return func(next Handler) Handler {
+		return func(ctx context.Context, op *Operation) error {
+			start := time.Now()
+			err := next(ctx, op)
+			out := l
+			if out == nil {
+				out = logger
+			}
+			if err != nil {
+				out.Printf("dapr client: %s failed after %s: %v", op, time.Since(start), err)
+			} else {
+				out.Printf("dapr client: %s took %s", op, time.Since(start))
+			}
+			return err
+		}
+	}
+}
+
+// MetricsMiddleware returns a middleware recording the duration of every
+// operation in the dapr.client.operation.duration histogram of mp, or of the
+// global meter provider if mp is nil. The durations are recorded with the
+// API, building block, component and gRPC status code of the operation.
+func MetricsMiddleware(mp metric.MeterProvider) (Middleware, error) {
+	if mp == nil {
+		mp = otel.GetMeterProvider()
+	}
+	meter := mp.Meter(instrumentationName, metric.WithInstrumentationVersion(strings.TrimSpace(version.SDKVersion)))
+	duration, err := meter.Float64Histogram(metricsOperationDuration,
+		metric.WithDescription("Duration of the calls of the Dapr API made by the client."),
+		metric.WithUnit("s"))
+	if err != nil {
+		return nil, fmt.Errorf("error creating %s histogram: %w", metricsOperationDuration, err)
+	}
+
+	return func(next Handler) Handler {
+		return func(ctx context.Context, op *Operation) error {
+			start := time.Now()
+			err := next(ctx, op)
+			attrs := []attribute.KeyValue{
+				attribute.String("dapr.operation", op.Name),
+				attribute.String("dapr.building_block", string(op.BuildingBlock)),
+				attribute.Int("rpc.grpc.status_code", int(status.Code(err))),
+			}
+			if op.Component != "" {
+				attrs = append(attrs, attribute.String("dapr.component", op.Component))
+			}
+			duration.Record(ctx, time.Since(start).Seconds(), metric.WithAttributes(attrs...))
+			return err
+		}
+	}, nil
+}
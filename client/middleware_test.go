@@ -0,0 +1,316 @@
+/*
+Copyright 2026 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package client
+
+import (
+	"bytes"
+	"context"
+	"log"
+	"net"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/attribute"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+
+	commonv1pb "github.com/dapr/dapr/pkg/proto/common/v1"
+	pb "github.com/dapr/dapr/pkg/proto/runtime/v1"
+)
+
+func newMiddlewareClient(t *testing.T, mws ...Middleware) (Client, *testDaprServer) {
+	t.Helper()
+	srv := &testDaprServer{
+		state:                       make(map[string][]byte),
+		configurationSubscriptionID: map[string]chan struct{}{},
+	}
+	s := grpc.NewServer()
+	pb.RegisterDaprServer(s, srv)
+	l := bufconn.Listen(testBufSize)
+	go s.Serve(l)
+	t.Cleanup(s.Stop)
+
+	dialOpts := append([]grpc.DialOption{
+		grpc.WithContextDialer(func(context.Context, string) (net.Conn, error) { return l.Dial() }),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	}, MiddlewareDialOptions(mws...)...)
+	c, err := NewClientWithAddressContext(t.Context(), "localhost:50001", dialOpts...)
+	require.NoError(t, err)
+	t.Cleanup(c.Close)
+	return c, srv
+}
+
+// recorder is a middleware recording the operations.
+type recorder struct {
+	lock sync.Mutex
+	ops  []Operation
+}
+
+func (r *recorder) middleware(next Handler) Handler {
+	return func(ctx context.Context, op *Operation) error {
+		err := next(ctx, op)
+		r.lock.Lock()
+		defer r.lock.Unlock()
+		r.ops = append(r.ops, *op)
+		return err
+	}
+}
+
+func (r *recorder) last() Operation {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	return r.ops[len(r.ops)-1]
+}
+
+// tenantMiddleware prefixes the state keys with the tenant.
+func tenantMiddleware(tenant string) Middleware {
+	return StateKeyMiddleware(
+		func(ctx context.Context, key string) string { return tenant + "||" + key },
+		func(ctx context.Context, key string) string { return strings.TrimPrefix(key, tenant+"||") },
+	)
+}
+
+func TestMiddleware(t *testing.T) {
+	ctx := t.Context()
+	rec := &recorder{}
+	var order []string
+	named := func(name string) Middleware {
+		return func(next Handler) Handler {
+			return func(ctx context.Context, op *Operation) error {
+				order = append(order, name)
+				return next(ctx, op)
+			}
+		}
+	}
+	faults := func(next Handler) Handler {
+		return func(ctx context.Context, op *Operation) error {
+			if op.Topic == "broken" {
+				return status.Error(codes.Unavailable, "injected")
+			}
+			return next(ctx, op)
+		}
+	}
+	c, srv := newMiddlewareClient(t, named("outer"), rec.middleware, named("inner"), faults, tenantMiddleware("acme"))
+
+	t.Run("operations", func(t *testing.T) {
+		require.NoError(t, c.SaveState(ctx, "store", "order", []byte("v1"), nil))
+		assert.Equal(t, []string{"outer", "inner"}, order)
+		op := rec.last()
+		assert.Equal(t, "SaveState", op.Name)
+		assert.Equal(t, BuildingBlockState, op.BuildingBlock)
+		assert.Equal(t, "store", op.Component)
+		assert.Equal(t, []string{"order"}, op.Keys)
+		assert.NotNil(t, op.Response)
+
+		item, err := c.GetState(ctx, "store", "order", nil)
+		require.NoError(t, err)
+		assert.Equal(t, []byte("v1"), item.Value)
+		assert.Equal(t, []byte("v1"), rec.last().Response.(*pb.GetStateResponse).GetData())
+
+		srv.stateLock.Lock()
+		assert.Contains(t, srv.state, "acme||order", "middlewares may modify the request")
+		srv.stateLock.Unlock()
+	})
+
+	t.Run("state keys are rewritten both ways", func(t *testing.T) {
+		items, err := c.GetBulkState(ctx, "store", []string{"order"}, nil, 1)
+		require.NoError(t, err)
+		require.Len(t, items, 1)
+		assert.Equal(t, "order", items[0].Key)
+
+		resp, err := c.QueryStateAlpha1(ctx, "store", "{}", nil)
+		require.NoError(t, err)
+		require.Len(t, resp.Results, 1)
+		assert.Equal(t, "order", resp.Results[0].Key)
+
+		require.NoError(t, c.DeleteState(ctx, "store", "order", nil))
+		srv.stateLock.Lock()
+		assert.Empty(t, srv.state)
+		srv.stateLock.Unlock()
+	})
+
+	t.Run("fault injection", func(t *testing.T) {
+		err := c.PublishEvent(ctx, "messages", "broken", []byte("event"))
+		require.Equal(t, codes.Unavailable, status.Code(err))
+		op := rec.last()
+		assert.Equal(t, "PublishEvent", op.Name)
+		assert.Equal(t, "messages", op.Component)
+		assert.Nil(t, op.Response)
+	})
+
+	t.Run("streaming operations are only named", func(t *testing.T) {
+		id, err := c.SubscribeConfigurationItems(ctx, "store", []string{"mykey"}, func(string, map[string]*ConfigurationItem) {})
+		require.NoError(t, err)
+		require.NoError(t, c.UnsubscribeConfigurationItems(ctx, "store", id))
+		var stream Operation
+		rec.lock.Lock()
+		for _, op := range rec.ops {
+			if op.Streaming {
+				stream = op
+			}
+		}
+		rec.lock.Unlock()
+		assert.Equal(t, Operation{
+			Name:          "SubscribeConfiguration",
+			BuildingBlock: BuildingBlockOther,
+			Streaming:     true,
+		}, stream, "the store and keys of the subscription are not exposed")
+		assert.Equal(t, "SubscribeConfiguration", stream.String())
+	})
+}
+
+func TestTypedHooks(t *testing.T) {
+	ctx := t.Context()
+	var sent []string
+	c, _ := newMiddlewareClient(t,
+		OnRequest(func(ctx context.Context, req *pb.SaveStateRequest) error {
+			for _, s := range req.GetStates() {
+				sent = append(sent, s.GetKey())
+			}
+			return nil
+		}),
+		OnRequest(func(ctx context.Context, req *pb.PublishEventRequest) error {
+			return status.Error(codes.PermissionDenied, "denied")
+		}),
+		OnResponse(func(ctx context.Context, resp *pb.GetStateResponse) error {
+			resp.Data = bytes.ToUpper(resp.GetData())
+			return nil
+		}),
+	)
+
+	require.NoError(t, c.SaveState(ctx, "store", "order", []byte("v1"), nil))
+	assert.Equal(t, []string{"order"}, sent)
+
+	item, err := c.GetState(ctx, "store", "order", nil)
+	require.NoError(t, err)
+	assert.Equal(t, []byte("V1"), item.Value, "hooks may modify the response")
+
+	err = c.PublishEvent(ctx, "messages", "orders", []byte("event"))
+	require.Equal(t, codes.PermissionDenied, status.Code(err))
+}
+
+func TestLoggingMiddleware(t *testing.T) {
+	var buf bytes.Buffer
+	c, _ := newMiddlewareClient(t, LoggingMiddleware(log.New(&buf, "", 0)))
+
+	_, err := c.InvokeMethod(t.Context(), "orders", "list", "get")
+	require.NoError(t, err)
+	assert.Contains(t, buf.String(), "dapr client: InvokeService app=orders method=list took ")
+
+	buf.Reset()
+	_, err = c.GetActorState(t.Context(), &GetActorStateRequest{ActorType: "cart", ActorID: "1", KeyName: "items"})
+	require.Equal(t, codes.Unimplemented, status.Code(err))
+	assert.Contains(t, buf.String(), "dapr client: GetActorState actorType=cart actorID=1 keys=[items] failed after ")
+}
+
+func TestMetricsMiddleware(t *testing.T) {
+	reader := sdkmetric.NewManualReader()
+	mw, err := MetricsMiddleware(sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader)))
+	require.NoError(t, err)
+	c, _ := newMiddlewareClient(t, mw)
+
+	require.NoError(t, c.SaveState(t.Context(), "store", "order", []byte("v1"), nil))
+	_, err = c.GetState(t.Context(), "store", "order", nil)
+	require.NoError(t, err)
+	_, err = c.GetState(t.Context(), "store", "order", nil)
+	require.NoError(t, err)
+
+	var rm metricdata.ResourceMetrics
+	require.NoError(t, reader.Collect(t.Context(), &rm))
+	require.Len(t, rm.ScopeMetrics, 1)
+	assert.Equal(t, instrumentationName, rm.ScopeMetrics[0].Scope.Name)
+	require.Len(t, rm.ScopeMetrics[0].Metrics, 1)
+	m := rm.ScopeMetrics[0].Metrics[0]
+	assert.Equal(t, metricsOperationDuration, m.Name)
+	hist, ok := m.Data.(metricdata.Histogram[float64])
+	require.True(t, ok)
+
+	counts := map[string]uint64{}
+	for _, dp := range hist.DataPoints {
+		name, _ := dp.Attributes.Value("dapr.operation")
+		component, _ := dp.Attributes.Value("dapr.component")
+		assert.Equal(t, attribute.StringValue("store"), component)
+		code, _ := dp.Attributes.Value("rpc.grpc.status_code")
+		assert.Equal(t, attribute.IntValue(int(codes.OK)), code)
+		counts[name.AsString()] = dp.Count
+	}
+	assert.Equal(t, map[string]uint64{"SaveState": 1, "GetState": 2}, counts)
+}
+
+func TestNewOperation(t *testing.T) {
+	tests := []struct {
+		name   string
+		method string
+		req    any
+		want   Operation
+	}{
+		{
+			name:   "bulk state",
+			method: pb.Dapr_DeleteBulkState_FullMethodName,
+			req:    &pb.DeleteBulkStateRequest{StoreName: "store", States: []*commonv1pb.StateItem{{Key: "a"}, {Key: "b"}}},
+			want:   Operation{Name: "DeleteBulkState", BuildingBlock: BuildingBlockState, Component: "store", Keys: []string{"a", "b"}},
+		},
+		{
+			name:   "transaction",
+			method: pb.Dapr_ExecuteStateTransaction_FullMethodName,
+			req: &pb.ExecuteStateTransactionRequest{StoreName: "store", Operations: []*pb.TransactionalStateOperation{
+				{OperationType: "upsert", Request: &commonv1pb.StateItem{Key: "a"}},
+			}},
+			want: Operation{Name: "ExecuteStateTransaction", BuildingBlock: BuildingBlockState, Component: "store", Keys: []string{"a"}},
+		},
+		{
+			name:   "publish",
+			method: pb.Dapr_PublishEvent_FullMethodName,
+			req:    &pb.PublishEventRequest{PubsubName: "messages", Topic: "orders"},
+			want:   Operation{Name: "PublishEvent", BuildingBlock: BuildingBlockPubsub, Component: "messages", Topic: "orders"},
+		},
+		{
+			name:   "binding",
+			method: pb.Dapr_InvokeBinding_FullMethodName,
+			req:    &pb.InvokeBindingRequest{Name: "queue", Operation: "create"},
+			want:   Operation{Name: "InvokeBinding", BuildingBlock: BuildingBlockBindings, Component: "queue", Method: "create"},
+		},
+		{
+			name:   "actor",
+			method: pb.Dapr_InvokeActor_FullMethodName,
+			req:    &pb.InvokeActorRequest{ActorType: "cart", ActorId: "1", Method: "add"},
+			want:   Operation{Name: "InvokeActor", BuildingBlock: BuildingBlockActors, ActorType: "cart", ActorID: "1", Method: "add"},
+		},
+		{
+			name:   "lock",
+			method: pb.Dapr_TryLockAlpha1_FullMethodName,
+			req:    &pb.TryLockRequest{StoreName: "locks", ResourceId: "order-1"},
+			want:   Operation{Name: "TryLockAlpha1", BuildingBlock: BuildingBlockOther, Component: "locks", Keys: []string{"order-1"}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			op := newOperation(tt.method, tt.req)
+			assert.Equal(t, tt.req, op.Request)
+			op.Request = nil
+			assert.Equal(t, tt.want, *op)
+		})
+	}
+
+	op := &Operation{Name: "GetState", Component: "store", Keys: []string{"a", "b"}}
+	assert.Equal(t, "GetState component=store keys=[a b]", op.String())
+}
@@ -0,0 +1,320 @@
+/*
+Copyright 2026 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package client
+
+import (
+	"cmp"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"maps"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/dapr/go-sdk/service/common"
+)
+
+const (
+	// CloudEventContentType is the content type of structured CloudEvents.
+	CloudEventContentType = "application/cloudevents+json"
+	// DefaultCloudEventType is the event type Dapr uses when none is given.
+	DefaultCloudEventType = "com.dapr.event.sent"
+
+	cloudEventSpecVersion = "1.0"
+
+	metadataCloudEventID     = "cloudevent.id"
+	metadataCloudEventSource = "cloudevent.source"
+	metadataCloudEventType   = "cloudevent.type"
+)
+
+// Codec encodes payloads of type T for publishing.
+type Codec[T any] interface {
+	// ContentType is the content type of the encoded payloads.
+	ContentType() string
+	Encode(v T) ([]byte, error)
+}
+
+type jsonCodec[T any] struct{}
+
+// JSONCodec returns a Codec encoding payloads as JSON.
+func JSONCodec[T any]() Codec[T] {
+	return jsonCodec[T]{}
+}
+
+func (jsonCodec[T]) ContentType() string { return "application/json" }
+
+func (jsonCodec[T]) Encode(v T) ([]byte, error) { return json.Marshal(v) }
+
+type bytesCodec struct {
+	contentType string
+}
+
+// BytesCodec returns a Codec publishing byte payloads as is, with contentType.
+func BytesCodec(contentType string) Codec[[]byte] {
+	return bytesCodec{contentType: contentType}
+}
+
+func (c bytesCodec) ContentType() string { return c.contentType }
+
+func (bytesCodec) Encode(v []byte) ([]byte, error) { return v, nil }
+
+type textCodec struct{}
+
+// TextCodec returns a Codec publishing strings as text/plain.
+func TextCodec() Codec[string] {
+	return textCodec{}
+}
+
+func (textCodec) ContentType() string { return "text/plain" }
+
+func (textCodec) Encode(v string) ([]byte, error) { return []byte(v), nil }
+
+// CloudEventAttributes are the CloudEvent attributes of a published event.
+// Empty attributes are set by Dapr, or by the Publisher for CloudEvents
+// published as a whole.
+type CloudEventAttributes struct {
+	ID         string
+	Source     string
+	Type       string
+	Subject    string
+	DataSchema string
+	Time       time.Time
+	// Extensions are extension attributes, keyed by their lowercase alphanumeric name.
+	Extensions map[string]any
+}
+
+// CloudEvent is a CloudEvent with a payload of type T.
+type CloudEvent[T any] struct {
+	CloudEventAttributes
+	Data T
+}
+
+// CloudEventOption sets a CloudEvent attribute of an event published with Publisher.Publish.
+type CloudEventOption func(*CloudEventAttributes)
+
+// WithCloudEventID sets the id attribute.
+func WithCloudEventID(id string) CloudEventOption {
+	return func(a *CloudEventAttributes) { a.ID = id }
+}
+
+// WithCloudEventSource sets the source attribute.
+func WithCloudEventSource(source string) CloudEventOption {
+	return func(a *CloudEventAttributes) { a.Source = source }
+}
+
+// WithCloudEventType sets the type attribute.
+func WithCloudEventType(eventType string) CloudEventOption {
+	return func(a *CloudEventAttributes) { a.Type = eventType }
+}
+
+// WithCloudEventSubject sets the subject attribute.
+func WithCloudEventSubject(subject string) CloudEventOption {
+	return func(a *CloudEventAttributes) { a.Subject = subject }
+}
+
+// WithCloudEventDataSchema sets the dataschema attribute.
+func WithCloudEventDataSchema(schema string) CloudEventOption {
+	return func(a *CloudEventAttributes) { a.DataSchema = schema }
+}
+
+// WithCloudEventTime sets the time attribute.
+func WithCloudEventTime(t time.Time) CloudEventOption {
+	return func(a *CloudEventAttributes) { a.Time = t }
+}
+
+// WithCloudEventExtension sets the extension attribute name.
+func WithCloudEventExtension(name string, value any) CloudEventOption {
+	return func(a *CloudEventAttributes) {
+		if a.Extensions == nil {
+			a.Extensions = make(map[string]any)
+		}
+		a.Extensions[name] = value
+	}
+}
+
+// PublisherOptions are the options of a Publisher.
+type PublisherOptions struct {
+	// Source and Type are used for events which do not set them.
+	Source string
+	Type   string
+	// Metadata is sent with every publish request.
+	Metadata map[string]string
+}
+
+// Publisher publishes events with payloads of type T onto a pubsub topic.
+// Payloads are encoded with an explicit codec and never inspected, so a
+// payload is only published as a CloudEvent envelope when asked to.
+type Publisher[T any] struct {
+	client     Client
+	pubsubName string
+	topic      string
+	codec      Codec[T]
+	opts       PublisherOptions
+}
+
+// NewPublisher returns a Publisher of events onto topic of pubsubName.
+func NewPublisher[T any](c Client, pubsubName, topic string, codec Codec[T], opts PublisherOptions) (*Publisher[T], error) {
+	if pubsubName == "" {
+		return nil, errors.New("pubsubName name required")
+	}
+	if topic == "" {
+		return nil, errors.New("topic name required")
+	}
+	if codec == nil {
+		return nil, errors.New("codec required")
+	}
+	return &Publisher[T]{
+		client:     c,
+		pubsubName: pubsubName,
+		topic:      topic,
+		codec:      codec,
+		opts:       opts,
+	}, nil
+}
+
+// Publish publishes data, which Dapr wraps in a CloudEvent. The id, source and
+// type attributes are passed to Dapr as metadata. When any other attribute is
+// set, the event is published with PublishCloudEvent instead.
+func (p *Publisher[T]) Publish(ctx context.Context, data T, opts ...CloudEventOption) error {
+	var attrs CloudEventAttributes
+	for _, o := range opts {
+		o(&attrs)
+	}
+	if attrs.structured() {
+		return p.PublishCloudEvent(ctx, CloudEvent[T]{CloudEventAttributes: attrs, Data: data})
+	}
+
+	payload, err := p.codec.Encode(data)
+	if err != nil {
+		return fmt.Errorf("error encoding event data: %w", err)
+	}
+
+	attrs.Source = cmp.Or(attrs.Source, p.opts.Source)
+	attrs.Type = cmp.Or(attrs.Type, p.opts.Type)
+	return p.client.PublishEvent(ctx, p.pubsubName, p.topic, payload,
+		PublishEventWithContentType(p.codec.ContentType()),
+		PublishEventWithMetadata(attrs.metadata(p.opts.Metadata)))
+}
+
+// PublishCloudEvent publishes event as a fully formed CloudEvent, which Dapr
+// delivers as is. A missing id is generated, and a missing source or type is
+// taken from the PublisherOptions, the type defaulting to DefaultCloudEventType.
+// Unlike with Publish, Dapr does not default the source to the app ID, so it is
+// required.
+func (p *Publisher[T]) PublishCloudEvent(ctx context.Context, event CloudEvent[T]) error {
+	data, err := p.codec.Encode(event.Data)
+	if err != nil {
+		return fmt.Errorf("error encoding event data: %w", err)
+	}
+	attrs := event.CloudEventAttributes
+	attrs.Source = cmp.Or(attrs.Source, p.opts.Source)
+	attrs.Type = cmp.Or(attrs.Type, p.opts.Type)
+	envelope, err := attrs.envelope(p.codec.ContentType(), data)
+	if err != nil {
+		return err
+	}
+	return p.client.PublishEvent(ctx, p.pubsubName, p.topic, envelope,
+		PublishEventWithContentType(CloudEventContentType),
+		PublishEventWithMetadata(maps.Clone(p.opts.Metadata)))
+}
+
+// structured reports whether the attributes can only be published within a
+// structured CloudEvent, as Dapr only takes id, source and type as metadata.
+func (a *CloudEventAttributes) structured() bool {
+	return a.Subject != "" || a.DataSchema != "" || !a.Time.IsZero() || len(a.Extensions) > 0
+}
+
+// metadata returns a copy of metadata with the id, source and type attributes
+// added under the keys Dapr sets them from.
+func (a *CloudEventAttributes) metadata(metadata map[string]string) map[string]string {
+	metadata = maps.Clone(metadata)
+	for key, value := range map[string]string{
+		metadataCloudEventID:     a.ID,
+		metadataCloudEventSource: a.Source,
+		metadataCloudEventType:   a.Type,
+	} {
+		if value == "" {
+			continue
+		}
+		if metadata == nil {
+			metadata = make(map[string]string)
+		}
+		metadata[key] = value
+	}
+	return metadata
+}
+
+// envelope returns the JSON encoding of a CloudEvent with the attributes and
+// data, in the structured content mode. A missing id is generated and the type
+// defaults to DefaultCloudEventType, but the source is required.
+func (a *CloudEventAttributes) envelope(contentType string, data []byte) ([]byte, error) {
+	if a.Source == "" {
+		return nil, errors.New("CloudEvent source required")
+	}
+
+	ce := make(map[string]any, 8+len(a.Extensions))
+	for name, value := range a.Extensions {
+		if !validExtensionName(name) {
+			return nil, fmt.Errorf("invalid CloudEvent extension attribute name %q", name)
+		}
+		ce[name] = value
+	}
+	ce["specversion"] = cloudEventSpecVersion
+	ce["id"] = cmp.Or(a.ID, uuid.NewString())
+	ce["source"] = a.Source
+	ce["type"] = cmp.Or(a.Type, DefaultCloudEventType)
+	if a.Subject != "" {
+		ce["subject"] = a.Subject
+	}
+	if a.DataSchema != "" {
+		ce["dataschema"] = a.DataSchema
+	}
+	if !a.Time.IsZero() {
+		ce["time"] = a.Time.Format(time.RFC3339Nano)
+	}
+
+	ce["datacontenttype"] = contentType
+	switch {
+	case common.IsJSONContentType(contentType):
+		ce["data"] = json.RawMessage(data)
+	case strings.HasPrefix(contentType, "text/"):
+		ce["data"] = string(data)
+	default:
+		ce["data_base64"] = base64.StdEncoding.EncodeToString(data)
+	}
+
+	envelope, err := json.Marshal(ce)
+	if err != nil {
+		return nil, fmt.Errorf("error serializing CloudEvent: %w", err)
+	}
+	return envelope, nil
+}
+
+// validExtensionName reports whether name is a valid CloudEvent extension
+// attribute name which is not one of the context attributes.
+func validExtensionName(name string) bool {
+	if name == "" {
+		return false
+	}
+	for _, r := range name {
+		if (r < 'a' || r > 'z') && (r < '0' || r > '9') {
+			return false
+		}
+	}
+	return len(common.ExtensionAttributes(map[string]any{name: nil})) == 1
+}
@@ -0,0 +1,152 @@
+/*
+Copyright 2026 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/types/known/emptypb"
+
+	pb "github.com/dapr/dapr/pkg/proto/runtime/v1"
+)
+
+// fakePublishClient records published events.
+type fakePublishClient struct {
+	pb.DaprClient
+
+	requests     []*pb.PublishEventRequest
+	bulkRequests []*pb.BulkPublishRequest
+}
+
+func (f *fakePublishClient) PublishEvent(ctx context.Context, in *pb.PublishEventRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	f.requests = append(f.requests, in)
+	return &emptypb.Empty{}, nil
+}
+
+func (f *fakePublishClient) BulkPublishEvent(ctx context.Context, in *pb.BulkPublishRequest, opts ...grpc.CallOption) (*pb.BulkPublishResponse, error) {
+	f.bulkRequests = append(f.bulkRequests, in)
+	return &pb.BulkPublishResponse{}, nil
+}
+
+type order struct {
+	ID    string `json:"id"`
+	Total int    `json:"total"`
+}
+
+func TestPublisher(t *testing.T) {
+	ctx := t.Context()
+
+	t.Run("invalid arguments", func(t *testing.T) {
+		c := &GRPCClient{protoClient: &fakePublishClient{}}
+		_, err := NewPublisher(c, "", "orders", JSONCodec[order](), PublisherOptions{})
+		require.Error(t, err)
+		_, err = NewPublisher(c, "pubsub", "", JSONCodec[order](), PublisherOptions{})
+		require.Error(t, err)
+		_, err = NewPublisher[order](c, "pubsub", "orders", nil, PublisherOptions{})
+		require.Error(t, err)
+	})
+
+	t.Run("publish data with attributes as metadata", func(t *testing.T) {
+		fake := &fakePublishClient{}
+		p, err := NewPublisher(&GRPCClient{protoClient: fake}, "pubsub", "orders", JSONCodec[order](), PublisherOptions{
+			Source:   "checkout",
+			Metadata: map[string]string{"ttlInSeconds": "60"},
+		})
+		require.NoError(t, err)
+
+		require.NoError(t, p.Publish(ctx, order{ID: "o1", Total: 3}, WithCloudEventID("e1"), WithCloudEventType("order.created")))
+		require.Len(t, fake.requests, 1)
+		req := fake.requests[0]
+		assert.Equal(t, "pubsub", req.GetPubsubName())
+		assert.Equal(t, "orders", req.GetTopic())
+		assert.Equal(t, "application/json", req.GetDataContentType())
+		assert.JSONEq(t, `{"id":"o1","total":3}`, string(req.GetData()))
+		assert.Equal(t, map[string]string{
+			"ttlInSeconds":      "60",
+			"cloudevent.id":     "e1",
+			"cloudevent.source": "checkout",
+			"cloudevent.type":   "order.created",
+		}, req.GetMetadata())
+
+		// Options do not leak into the publisher metadata.
+		require.NoError(t, p.Publish(ctx, order{ID: "o2"}))
+		assert.Equal(t, map[string]string{"ttlInSeconds": "60", "cloudevent.source": "checkout"}, fake.requests[1].GetMetadata())
+	})
+
+	t.Run("publish data with envelope attributes", func(t *testing.T) {
+		fake := &fakePublishClient{}
+		p, err := NewPublisher(&GRPCClient{protoClient: fake}, "pubsub", "orders", JSONCodec[order](), PublisherOptions{Source: "checkout"})
+		require.NoError(t, err)
+
+		ts := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+		require.NoError(t, p.Publish(ctx, order{ID: "o1"},
+			WithCloudEventSubject("o1"),
+			WithCloudEventDataSchema("https://example.com/order.json"),
+			WithCloudEventTime(ts),
+			WithCloudEventExtension("tenant", "acme")))
+		require.Len(t, fake.requests, 1)
+		req := fake.requests[0]
+		assert.Equal(t, CloudEventContentType, req.GetDataContentType())
+
+		var ce map[string]any
+		require.NoError(t, json.Unmarshal(req.GetData(), &ce))
+		assert.NotEmpty(t, ce["id"])
+		assert.Equal(t, "1.0", ce["specversion"])
+		assert.Equal(t, "checkout", ce["source"])
+		assert.Equal(t, DefaultCloudEventType, ce["type"])
+		assert.Equal(t, "o1", ce["subject"])
+		assert.Equal(t, "https://example.com/order.json", ce["dataschema"])
+		assert.Equal(t, "2026-01-02T03:04:05Z", ce["time"])
+		assert.Equal(t, "acme", ce["tenant"])
+		assert.Equal(t, "application/json", ce["datacontenttype"])
+		assert.Equal(t, map[string]any{"id": "o1", "total": float64(0)}, ce["data"])
+	})
+
+	t.Run("publish cloud event", func(t *testing.T) {
+		fake := &fakePublishClient{}
+		c := &GRPCClient{protoClient: fake}
+
+		text, err := NewPublisher(c, "pubsub", "greetings", TextCodec(), PublisherOptions{Type: "greeting"})
+		require.NoError(t, err)
+		require.NoError(t, text.PublishCloudEvent(ctx, CloudEvent[string]{
+			CloudEventAttributes: CloudEventAttributes{ID: "e1", Source: "greeter"},
+			Data:                 "hello",
+		}))
+		assert.JSONEq(t, `{"specversion":"1.0","id":"e1","source":"greeter","type":"greeting","datacontenttype":"text/plain","data":"hello"}`,
+			string(fake.requests[0].GetData()))
+
+		bin, err := NewPublisher(c, "pubsub", "blobs", BytesCodec("application/octet-stream"), PublisherOptions{Source: "store"})
+		require.NoError(t, err)
+		require.NoError(t, bin.PublishCloudEvent(ctx, CloudEvent[[]byte]{
+			CloudEventAttributes: CloudEventAttributes{ID: "e2"},
+			Data:                 []byte{0, 1, 2},
+		}))
+		assert.JSONEq(t, `{"specversion":"1.0","id":"e2","source":"store","type":"com.dapr.event.sent","datacontenttype":"application/octet-stream","data_base64":"AAEC"}`,
+			string(fake.requests[1].GetData()))
+
+		err = text.PublishCloudEvent(ctx, CloudEvent[string]{Data: "no source"})
+		require.Error(t, err)
+		for _, name := range []string{"Tenant", "topic", "data"} {
+			err = text.Publish(ctx, "hello", WithCloudEventSource("greeter"), WithCloudEventExtension(name, "x"))
+			require.Error(t, err, name)
+		}
+		assert.Len(t, fake.requests, 2)
+	})
+}
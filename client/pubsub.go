@@ -14,6 +14,7 @@ limitations under the License.
 package client
 
 import (
+	"cmp"
 	"context"
 	"encoding/json"
 	"errors"
@@ -68,6 +69,10 @@ func (c *GRPCClient) PublishEvent(ctx context.Context, pubsubName, topicName str
 		}
 	}
 
+	if err := c.validateEvent(ctx, pubsubName, topicName, request.GetDataContentType(), request.GetData()); err != nil {
+		return err
+	}
+
 	_, err := c.protoClient.PublishEvent(ctx, request)
 	if err != nil {
 		return fmt.Errorf("error publishing event unto %s topic: %w", topicName, err)
@@ -116,7 +121,10 @@ func (c *GRPCClient) PublishEventfromCustomContent(ctx context.Context, pubsubNa
 	return c.PublishEvent(ctx, pubsubName, topicName, enc, PublishEventWithContentType("application/json"))
 }
 
-// PublishEventsEvent is a type of event that can be published using PublishEvents.
+// PublishEventsEvent is an event published with PublishEvents with an explicit
+// entry ID, content type and metadata. Data is published as is, and the entry
+// ID defaults to a random UUID. Use BulkPublishEntry to also set CloudEvent
+// attributes.
 type PublishEventsEvent struct {
 	EntryID     string
 	Data        []byte
@@ -134,6 +142,9 @@ type PublishEventsResponse struct {
 type PublishEventsOption func(*pb.BulkPublishRequest)
 
 // PublishEvents publishes multiple events onto topic in specific pubsub component.
+// Events other than byte slices and strings are encoded as JSON, and are
+// published as structured CloudEvents only when passed as a BulkPublishEntry
+// with CloudEvent attributes or with the CloudEventContentType content type.
 // If all events are successfully published, response Error will be nil.
 // The FailedEvents field will contain all events that failed to publish.
 func (c *GRPCClient) PublishEvents(ctx context.Context, pubsubName, topicName string, events []interface{}, opts ...PublishEventsOption) PublishEventsResponse {
@@ -150,6 +161,7 @@ func (c *GRPCClient) PublishEvents(ctx context.Context, pubsubName, topicName st
 		}
 	}
 
+	var entryErrs []error
 	failedEvents := make([]interface{}, 0, len(events))
 	eventMap := make(map[string]interface{}, len(events))
 	entries := make([]*pb.BulkPublishRequestEntry, 0, len(events))
@@ -157,6 +169,7 @@ func (c *GRPCClient) PublishEvents(ctx context.Context, pubsubName, topicName st
 		entry, err := createBulkPublishRequestEntry(event)
 		if err != nil {
 			failedEvents = append(failedEvents, event)
+			entryErrs = append(entryErrs, err)
 			continue
 		}
 		eventMap[entry.GetEntryId()] = event
@@ -172,11 +185,24 @@ func (c *GRPCClient) PublishEvents(ctx context.Context, pubsubName, topicName st
 		o(request)
 	}
 
-	res, err := c.protoClient.BulkPublishEvent(ctx, request)
-	if err != nil && status.Code(err) == codes.Unimplemented {
-		//nolint:staticcheck // SA1019 Deprecated: use BulkPublishEvent instead.
-		res, err = c.protoClient.BulkPublishEventAlpha1(ctx, request)
+	valid := request.Entries[:0]
+	for _, entry := range request.GetEntries() {
+		if err := c.validateEvent(ctx, pubsubName, topicName, entry.GetContentType(), entry.GetEvent()); err != nil {
+			entryErrs = append(entryErrs, err)
+			failedEvents = append(failedEvents, eventMap[entry.GetEntryId()])
+			continue
+		}
+		valid = append(valid, entry)
+	}
+	request.Entries = valid
+	if len(entryErrs) > 0 && len(valid) == 0 {
+		return PublishEventsResponse{
+			Error:        errors.Join(entryErrs...),
+			FailedEvents: failedEvents,
+		}
 	}
+
+	failedEntries, err := c.bulkPublish(ctx, request)
 	// If there is an error, all events failed to publish.
 	if err != nil {
 		return PublishEventsResponse{
@@ -185,18 +211,19 @@ func (c *GRPCClient) PublishEvents(ctx context.Context, pubsubName, topicName st
 		}
 	}
 
-	for _, failedEntry := range res.GetFailedEntries() {
-		event, ok := eventMap[failedEntry.GetEntryId()]
+	for entryID, entryErr := range failedEntries {
+		event, ok := eventMap[entryID]
 		if !ok {
 			// This should never happen.
-			failedEvents = append(failedEvents, failedEntry.GetEntryId())
+			event = entryID
 		}
 		failedEvents = append(failedEvents, event)
+		entryErrs = append(entryErrs, fmt.Errorf("entry %s: %w", entryID, entryErr))
 	}
 
 	if len(failedEvents) != 0 {
 		return PublishEventsResponse{
-			Error:        fmt.Errorf("error publishing events unto %s topic: %w", topicName, err),
+			Error:        fmt.Errorf("error publishing %d of %d events unto %s topic: %w", len(failedEvents), len(events), topicName, errors.Join(entryErrs...)),
 			FailedEvents: failedEvents,
 		}
 	}
@@ -207,11 +234,33 @@ func (c *GRPCClient) PublishEvents(ctx context.Context, pubsubName, topicName st
 	}
 }
 
+// bulkPublish sends request, falling back to the alpha API of older runtimes,
+// and returns the errors of the entries which failed to publish by entry ID.
+func (c *GRPCClient) bulkPublish(ctx context.Context, request *pb.BulkPublishRequest) (map[string]error, error) {
+	res, err := c.protoClient.BulkPublishEvent(ctx, request)
+	if err != nil && status.Code(err) == codes.Unimplemented {
+		//nolint:staticcheck // SA1019 Deprecated: use BulkPublishEvent instead.
+		res, err = c.protoClient.BulkPublishEventAlpha1(ctx, request)
+	}
+	if err != nil {
+		return nil, err
+	}
+	failed := make(map[string]error, len(res.GetFailedEntries()))
+	for _, entry := range res.GetFailedEntries() {
+		failed[entry.GetEntryId()] = errors.New(entry.GetError())
+	}
+	return failed, nil
+}
+
 // createBulkPublishRequestEntry creates a BulkPublishRequestEntry from an interface{}.
 func createBulkPublishRequestEntry(data interface{}) (*pb.BulkPublishRequestEntry, error) {
 	entry := &pb.BulkPublishRequestEntry{}
 
 	switch d := data.(type) {
+	case BulkPublishEntry:
+		return d.requestEntry(cmp.Or(d.EntryID, uuid.NewString()))
+	case *BulkPublishEntry:
+		return d.requestEntry(cmp.Or(d.EntryID, uuid.NewString()))
 	case PublishEventsEvent:
 		entry.EntryId = d.EntryID
 		entry.Event = d.Data
@@ -230,10 +279,6 @@ func createBulkPublishRequestEntry(data interface{}) (*pb.BulkPublishRequestEntr
 		if err != nil {
 			return &pb.BulkPublishRequestEntry{}, fmt.Errorf("error serializing input struct: %w", err)
 		}
-
-		if isCloudEvent(entry.GetEvent()) {
-			entry.ContentType = "application/cloudevents+json"
-		}
 	}
 
 	if entry.GetEntryId() == "" {
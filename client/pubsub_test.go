@@ -251,7 +251,7 @@ func TestCreateBulkPublishRequestEntry(t *testing.T) {
 				expectedError:       false,
 			},
 			{
-				name: "valid cloudevent",
+				name: "cloudevent is not detected from the data",
 				data: _testCloudEventStruct{
 					ID:          "123",
 					Source:      "test",
@@ -260,6 +260,16 @@ func TestCreateBulkPublishRequestEntry(t *testing.T) {
 					Data:        "foo",
 				},
 				expectedEvent:       []byte(`{"id":"123","source":"test","specversion":"1.0","type":"test","data":"foo"}`),
+				expectedContentType: "application/json",
+				expectedError:       false,
+			},
+			{
+				name: "cloudevent with explicit content type",
+				data: PublishEventsEvent{
+					Data:        []byte(`{"id":"123","source":"test","specversion":"1.0","type":"test","data":"foo"}`),
+					ContentType: CloudEventContentType,
+				},
+				expectedEvent:       []byte(`{"id":"123","source":"test","specversion":"1.0","type":"test","data":"foo"}`),
 				expectedContentType: "application/cloudevents+json",
 				expectedError:       false,
 			},
@@ -0,0 +1,474 @@
+/*
+Copyright 2026 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/cenkalti/backoff/v4"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	v1 "github.com/dapr/dapr/pkg/proto/common/v1"
+	pb "github.com/dapr/dapr/pkg/proto/runtime/v1"
+)
+
+const (
+	defaultRetryMaxRetries              = 3
+	defaultRetryInitialInterval         = 100 * time.Millisecond
+	defaultRetryMaxInterval             = 5 * time.Second
+	defaultCircuitBreakerFailures       = 5
+	defaultCircuitBreakerOpenDuration   = 10 * time.Second
+	defaultCircuitBreakerHalfOpenProbes = 1
+)
+
+// ErrCircuitOpen is the error of calls rejected by an open circuit breaker.
+var ErrCircuitOpen = errors.New("circuit breaker open")
+
+// BuildingBlock is a group of Dapr APIs sharing a resiliency policy.
+type BuildingBlock string
+
+const (
+	BuildingBlockState    BuildingBlock = "state"
+	BuildingBlockPubsub   BuildingBlock = "pubsub"
+	BuildingBlockInvoke   BuildingBlock = "invoke"
+	BuildingBlockActors   BuildingBlock = "actors"
+	BuildingBlockBindings BuildingBlock = "bindings"
+	// BuildingBlockOther groups every other API, such as secrets,
+	// configuration, locks, jobs and workflows.
+	BuildingBlockOther BuildingBlock = "other"
+)
+
+var buildingBlocks = []BuildingBlock{
+	BuildingBlockState,
+	BuildingBlockPubsub,
+	BuildingBlockInvoke,
+	BuildingBlockActors,
+	BuildingBlockBindings,
+	BuildingBlockOther,
+}
+
+// operation classifies a Dapr API.
+type operation struct {
+	block BuildingBlock
+	// retrySafe is set if repeating the call has no other effect than
+	// making it once, so that calls which may have reached Dapr can be retried.
+	retrySafe bool
+}
+
+// operations classifies the unary and streaming Dapr APIs. APIs which are not
+// listed belong to BuildingBlockOther and are not retried.
+var operations = map[string]operation{
+	pb.Dapr_GetState_FullMethodName:         {BuildingBlockState, true},
+	pb.Dapr_GetBulkState_FullMethodName:     {BuildingBlockState, true},
+	pb.Dapr_QueryStateAlpha1_FullMethodName: {BuildingBlockState, true},
+
+	// Writes without etags or first-write concurrency are retry-safe, see
+	// classify. A retried write with an etag fails if the first attempt
+	// succeeded.
+	pb.Dapr_SaveState_FullMethodName:               {BuildingBlockState, false},
+	pb.Dapr_DeleteState_FullMethodName:             {BuildingBlockState, false},
+	pb.Dapr_DeleteBulkState_FullMethodName:         {BuildingBlockState, false},
+	pb.Dapr_ExecuteStateTransaction_FullMethodName: {BuildingBlockState, false},
+
+	// Publishing again delivers the event twice.
+	pb.Dapr_PublishEvent_FullMethodName:               {BuildingBlockPubsub, false},
+	pb.Dapr_BulkPublishEvent_FullMethodName:           {BuildingBlockPubsub, false},
+	pb.Dapr_BulkPublishEventAlpha1_FullMethodName:     {BuildingBlockPubsub, false},
+	pb.Dapr_SubscribeTopicEventsAlpha1_FullMethodName: {BuildingBlockPubsub, false},
+
+	// Invocations with idempotent HTTP methods are retry-safe, see classify.
+	pb.Dapr_InvokeService_FullMethodName: {BuildingBlockInvoke, false},
+
+	pb.Dapr_GetActorState_FullMethodName:                  {BuildingBlockActors, true},
+	pb.Dapr_GetActorReminder_FullMethodName:               {BuildingBlockActors, true},
+	pb.Dapr_ListActorReminders_FullMethodName:             {BuildingBlockActors, true},
+	pb.Dapr_ExecuteActorStateTransaction_FullMethodName:   {BuildingBlockActors, true},
+	pb.Dapr_RegisterActorTimer_FullMethodName:             {BuildingBlockActors, true},
+	pb.Dapr_UnregisterActorTimer_FullMethodName:           {BuildingBlockActors, true},
+	pb.Dapr_RegisterActorReminder_FullMethodName:          {BuildingBlockActors, true},
+	pb.Dapr_UnregisterActorReminder_FullMethodName:        {BuildingBlockActors, true},
+	pb.Dapr_UnregisterActorRemindersByType_FullMethodName: {BuildingBlockActors, true},
+	pb.Dapr_InvokeActor_FullMethodName:                    {BuildingBlockActors, false},
+
+	pb.Dapr_InvokeBinding_FullMethodName: {BuildingBlockBindings, false},
+
+	pb.Dapr_GetSecret_FullMethodName:        {BuildingBlockOther, true},
+	pb.Dapr_GetBulkSecret_FullMethodName:    {BuildingBlockOther, true},
+	pb.Dapr_GetConfiguration_FullMethodName: {BuildingBlockOther, true},
+	pb.Dapr_GetMetadata_FullMethodName:      {BuildingBlockOther, true},
+	pb.Dapr_GetJobAlpha1_FullMethodName:     {BuildingBlockOther, true},
+	pb.Dapr_ListJobsAlpha1_FullMethodName:   {BuildingBlockOther, true},
+}
+
+// classify returns the operation of a call of method with req.
+func classify(method string, req any) operation {
+	op, ok := operations[method]
+	if !ok {
+		return operation{block: BuildingBlockOther}
+	}
+	switch r := req.(type) {
+	case *pb.InvokeServiceRequest:
+		switch r.GetMessage().GetHttpExtension().GetVerb() {
+		case v1.HTTPExtension_GET, v1.HTTPExtension_HEAD, v1.HTTPExtension_OPTIONS,
+			v1.HTTPExtension_TRACE, v1.HTTPExtension_PUT, v1.HTTPExtension_DELETE:
+			op.retrySafe = true
+		}
+	case *pb.SaveStateRequest:
+		op.retrySafe = !slices.ContainsFunc(r.GetStates(), isConditionalWrite)
+	case *pb.DeleteBulkStateRequest:
+		op.retrySafe = !slices.ContainsFunc(r.GetStates(), isConditionalWrite)
+	case *pb.DeleteStateRequest:
+		op.retrySafe = !isConditionalWrite(&v1.StateItem{Etag: r.GetEtag(), Options: r.GetOptions()})
+	case *pb.ExecuteStateTransactionRequest:
+		op.retrySafe = !slices.ContainsFunc(r.GetOperations(), func(o *pb.TransactionalStateOperation) bool {
+			return isConditionalWrite(o.GetRequest())
+		})
+	}
+	return op
+}
+
+// isConditionalWrite reports whether the write of item depends on the state
+// stored, with an etag or first-write concurrency.
+func isConditionalWrite(item *v1.StateItem) bool {
+	return item.GetEtag() != nil || item.GetOptions().GetConcurrency() == v1.StateOptions_CONCURRENCY_FIRST_WRITE
+}
+
+// ResiliencyPolicy is the resiliency policy of the APIs of a building block.
+type ResiliencyPolicy struct {
+	// Timeout, if set, bounds every attempt of a unary call.
+	Timeout time.Duration
+	// Retry, if set, retries failed unary calls.
+	Retry *RetryPolicy
+	// CircuitBreaker, if set, rejects calls after repeated failures.
+	CircuitBreaker *CircuitBreakerPolicy
+}
+
+// RetryPolicy retries unary calls failing with a transient error, with
+// exponential backoff. Unless RetryUnsafe is set, only retry-safe calls are
+// retried: reads and idempotent writes of state, actor state, reminders and
+// timers, reads of secrets, configuration and jobs, and invocations with an
+// idempotent HTTP method. Publishing events, invoking actors and bindings,
+// invoking apps over gRPC or with POST or PATCH, state writes with an etag or
+// first-write concurrency, and the APIs of other building blocks are not
+// retried.
+type RetryPolicy struct {
+	// MaxRetries is the number of retries. Defaults to 3.
+	MaxRetries int
+	// InitialInterval is the delay before the first retry, which doubles with
+	// every retry, with jitter, up to MaxInterval. Defaults to 100ms and 5s.
+	InitialInterval time.Duration
+	MaxInterval     time.Duration
+	// Codes are the status codes of the errors retried. Defaults to
+	// Unavailable and ResourceExhausted. Attempts exceeding the Timeout of
+	// the policy are retried too.
+	Codes []codes.Code
+	// RetryUnsafe retries calls which are not retry-safe, which may then be
+	// executed more than once.
+	RetryUnsafe bool
+}
+
+// CircuitBreakerPolicy opens the circuit of a building block after
+// consecutive calls fail with Unavailable, DeadlineExceeded or
+// ResourceExhausted. Calls are then rejected with ErrCircuitOpen until
+// OpenDuration has elapsed, after which the circuit is half-open and up to
+// HalfOpenRequests probe calls are let through. The circuit closes when a
+// probe succeeds, and opens again when one fails.
+type CircuitBreakerPolicy struct {
+	// ConsecutiveFailures is the number of failures opening the circuit.
+	// Defaults to 5.
+	ConsecutiveFailures int
+	// OpenDuration is how long the circuit stays open. Defaults to 10s.
+	OpenDuration time.Duration
+	// HalfOpenRequests is the number of probe calls of a half-open circuit.
+	// Defaults to 1.
+	HalfOpenRequests int
+}
+
+// ResiliencyOptions are the resiliency policies of the client.
+type ResiliencyOptions struct {
+	// Policies are the policies of building blocks.
+	Policies map[BuildingBlock]ResiliencyPolicy
+	// Default, if set, is the policy of building blocks without a policy.
+	Default *ResiliencyPolicy
+}
+
+// Resiliency applies resiliency policies to the calls of a client, as gRPC
+// interceptors.
+type Resiliency struct {
+	policies map[BuildingBlock]ResiliencyPolicy
+	breakers map[BuildingBlock]*circuitBreaker
+}
+
+// NewResiliency returns the interceptors applying opts. They are set when
+// creating the client, with DialOptions:
+//
+//	r, err := client.NewResiliency(opts)
+//	c, err := client.NewClient(r.DialOptions()...)
+func NewResiliency(opts ResiliencyOptions) (*Resiliency, error) {
+	r := &Resiliency{
+		policies: make(map[BuildingBlock]ResiliencyPolicy),
+		breakers: make(map[BuildingBlock]*circuitBreaker),
+	}
+	for block := range opts.Policies {
+		if !slices.Contains(buildingBlocks, block) {
+			return nil, fmt.Errorf("unknown building block %q", block)
+		}
+	}
+	for _, block := range buildingBlocks {
+		p, ok := opts.Policies[block]
+		if !ok {
+			if opts.Default == nil {
+				continue
+			}
+			p = *opts.Default
+		}
+		p, err := p.withDefaults()
+		if err != nil {
+			return nil, fmt.Errorf("invalid %s resiliency policy: %w", block, err)
+		}
+		r.policies[block] = p
+		if p.CircuitBreaker != nil {
+			r.breakers[block] = &circuitBreaker{policy: *p.CircuitBreaker}
+		}
+	}
+	return r, nil
+}
+
+// withDefaults returns a copy of p with the defaults of unset fields.
+func (p ResiliencyPolicy) withDefaults() (ResiliencyPolicy, error) {
+	if p.Timeout < 0 {
+		return p, errors.New("timeout must not be negative")
+	}
+	if p.Retry != nil {
+		retry := *p.Retry
+		if retry.MaxRetries < 0 || retry.InitialInterval < 0 || retry.MaxInterval < 0 {
+			return p, errors.New("retry settings must not be negative")
+		}
+		if retry.MaxRetries == 0 {
+			retry.MaxRetries = defaultRetryMaxRetries
+		}
+		if retry.InitialInterval == 0 {
+			retry.InitialInterval = defaultRetryInitialInterval
+		}
+		if retry.MaxInterval == 0 {
+			retry.MaxInterval = defaultRetryMaxInterval
+		}
+		if len(retry.Codes) == 0 {
+			retry.Codes = []codes.Code{codes.Unavailable, codes.ResourceExhausted}
+		}
+		p.Retry = &retry
+	}
+	if p.CircuitBreaker != nil {
+		cb := *p.CircuitBreaker
+		if cb.ConsecutiveFailures < 0 || cb.OpenDuration < 0 || cb.HalfOpenRequests < 0 {
+			return p, errors.New("circuit breaker settings must not be negative")
+		}
+		if cb.ConsecutiveFailures == 0 {
+			cb.ConsecutiveFailures = defaultCircuitBreakerFailures
+		}
+		if cb.OpenDuration == 0 {
+			cb.OpenDuration = defaultCircuitBreakerOpenDuration
+		}
+		if cb.HalfOpenRequests == 0 {
+			cb.HalfOpenRequests = defaultCircuitBreakerHalfOpenProbes
+		}
+		p.CircuitBreaker = &cb
+	}
+	return p, nil
+}
+
+// DialOptions returns the dial options setting the interceptors.
+func (r *Resiliency) DialOptions() []grpc.DialOption {
+	return []grpc.DialOption{
+		grpc.WithChainUnaryInterceptor(r.UnaryClientInterceptor()),
+		grpc.WithChainStreamInterceptor(r.StreamClientInterceptor()),
+	}
+}
+
+// UnaryClientInterceptor returns the interceptor applying the timeouts,
+// retries and circuit breakers of the policies to unary calls.
+func (r *Resiliency) UnaryClientInterceptor() grpc.UnaryClientInterceptor {
+	return func(ctx context.Context, method string, req, reply any, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		op := classify(method, req)
+		p, ok := r.policies[op.block]
+		if !ok {
+			return invoker(ctx, method, req, reply, cc, opts...)
+		}
+		cb := r.breakers[op.block]
+
+		attempt := func() (retry bool, err error) {
+			if cb != nil && !cb.allow() {
+				return false, fmt.Errorf("%w for %s APIs", ErrCircuitOpen, op.block)
+			}
+			attemptCtx := ctx
+			if p.Timeout > 0 {
+				var cancel context.CancelFunc
+				attemptCtx, cancel = context.WithTimeout(ctx, p.Timeout)
+				defer cancel()
+			}
+			err = invoker(attemptCtx, method, req, reply, cc, opts...)
+			code := status.Code(err)
+			if cb != nil {
+				cb.record(ctx.Err() == nil && isTransient(code))
+			}
+			if err == nil || ctx.Err() != nil || p.Retry == nil {
+				return false, err
+			}
+			timedOut := code == codes.DeadlineExceeded && attemptCtx.Err() != nil
+			return timedOut || slices.Contains(p.Retry.Codes, code), err
+		}
+
+		retry, err := attempt()
+		if !retry || !(op.retrySafe || p.Retry.RetryUnsafe) {
+			return err
+		}
+		bo := backoff.NewExponentialBackOff()
+		bo.InitialInterval = p.Retry.InitialInterval
+		bo.MaxInterval = p.Retry.MaxInterval
+		bo.MaxElapsedTime = 0
+		bo.Reset()
+		for i := 0; i < p.Retry.MaxRetries && retry; i++ {
+			t := time.NewTimer(bo.NextBackOff())
+			select {
+			case <-ctx.Done():
+				t.Stop()
+				return err
+			case <-t.C:
+			}
+			retry, err = attempt()
+		}
+		return err
+	}
+}
+
+// StreamClientInterceptor returns the interceptor applying the circuit
+// breakers of the policies to streaming calls. Streams are neither retried
+// nor bounded by the timeout of the policy, as they are long-lived.
+func (r *Resiliency) StreamClientInterceptor() grpc.StreamClientInterceptor {
+	return func(ctx context.Context, desc *grpc.StreamDesc, cc *grpc.ClientConn, method string, streamer grpc.Streamer, opts ...grpc.CallOption) (grpc.ClientStream, error) {
+		block := classify(method, nil).block
+		cb := r.breakers[block]
+		if cb == nil {
+			return streamer(ctx, desc, cc, method, opts...)
+		}
+		if !cb.allow() {
+			return nil, fmt.Errorf("%w for %s APIs", ErrCircuitOpen, block)
+		}
+		stream, err := streamer(ctx, desc, cc, method, opts...)
+		cb.record(ctx.Err() == nil && isTransient(status.Code(err)))
+		return stream, err
+	}
+}
+
+// CircuitState returns the state of the circuit breaker of block: "closed",
+// "open" or "half-open", or "" if the policy of block has none.
+func (r *Resiliency) CircuitState(block BuildingBlock) string {
+	cb := r.breakers[block]
+	if cb == nil {
+		return ""
+	}
+	cb.lock.Lock()
+	defer cb.lock.Unlock()
+	return cb.currentLocked().String()
+}
+
+// isTransient reports whether code is the code of a failure of Dapr, rather
+// than of the call.
+func isTransient(code codes.Code) bool {
+	return code == codes.Unavailable || code == codes.DeadlineExceeded || code == codes.ResourceExhausted
+}
+
+type circuitState int
+
+const (
+	circuitClosed circuitState = iota
+	circuitOpen
+	circuitHalfOpen
+)
+
+func (s circuitState) String() string {
+	switch s {
+	case circuitOpen:
+		return "open"
+	case circuitHalfOpen:
+		return "half-open"
+	default:
+		return "closed"
+	}
+}
+
+type circuitBreaker struct {
+	policy CircuitBreakerPolicy
+
+	lock     sync.Mutex
+	state    circuitState
+	failures int
+	openedAt time.Time
+	// probes is the number of calls let through while half-open.
+	probes int
+}
+
+// currentLocked returns the state of the circuit, which becomes half-open
+// once it has been open for OpenDuration.
+func (cb *circuitBreaker) currentLocked() circuitState {
+	if cb.state == circuitOpen && time.Since(cb.openedAt) >= cb.policy.OpenDuration {
+		cb.state, cb.probes = circuitHalfOpen, 0
+	}
+	return cb.state
+}
+
+// allow reports whether a call is let through.
+func (cb *circuitBreaker) allow() bool {
+	cb.lock.Lock()
+	defer cb.lock.Unlock()
+	switch cb.currentLocked() {
+	case circuitOpen:
+		return false
+	case circuitHalfOpen:
+		if cb.probes >= cb.policy.HalfOpenRequests {
+			return false
+		}
+		cb.probes++
+	}
+	return true
+}
+
+// record records the outcome of a call let through.
+func (cb *circuitBreaker) record(failed bool) {
+	cb.lock.Lock()
+	defer cb.lock.Unlock()
+	switch cb.currentLocked() {
+	case circuitClosed:
+		if !failed {
+			cb.failures = 0
+			return
+		}
+		if cb.failures++; cb.failures >= cb.policy.ConsecutiveFailures {
+			cb.state, cb.openedAt = circuitOpen, time.Now()
+		}
+	case circuitHalfOpen:
+		if failed {
+			cb.state, cb.openedAt = circuitOpen, time.Now()
+		} else {
+			cb.state, cb.failures = circuitClosed, 0
+		}
+	}
+}
@@ -0,0 +1,293 @@
+/*
+Copyright 2026 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package client
+
+import (
+	"context"
+	"net"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+	"google.golang.org/protobuf/types/known/emptypb"
+
+	commonv1pb "github.com/dapr/dapr/pkg/proto/common/v1"
+	pb "github.com/dapr/dapr/pkg/proto/runtime/v1"
+)
+
+// flakyDaprServer fails the first calls of every API with Unavailable, as
+// many times as set in failures, and records the number of calls.
+type flakyDaprServer struct {
+	pb.UnimplementedDaprServer
+
+	lock     sync.Mutex
+	failures map[string]int
+	calls    map[string]int
+	delay    time.Duration
+}
+
+func (s *flakyDaprServer) call(ctx context.Context, method string) error {
+	s.lock.Lock()
+	s.calls[method]++
+	fail := s.failures[method] > 0
+	if fail {
+		s.failures[method]--
+	}
+	delay := s.delay
+	s.lock.Unlock()
+
+	if delay > 0 {
+		select {
+		case <-time.After(delay):
+		case <-ctx.Done():
+			return status.FromContextError(ctx.Err()).Err()
+		}
+	}
+	if fail {
+		return status.Error(codes.Unavailable, "sidecar restarting")
+	}
+	return nil
+}
+
+func (s *flakyDaprServer) setFailures(method string, n int) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.failures[method] = n
+	s.calls[method] = 0
+}
+
+func (s *flakyDaprServer) callCount(method string) int {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	return s.calls[method]
+}
+
+func (s *flakyDaprServer) GetState(ctx context.Context, in *pb.GetStateRequest) (*pb.GetStateResponse, error) {
+	if err := s.call(ctx, "GetState"); err != nil {
+		return nil, err
+	}
+	return &pb.GetStateResponse{Data: []byte("value")}, nil
+}
+
+func (s *flakyDaprServer) SaveState(ctx context.Context, in *pb.SaveStateRequest) (*emptypb.Empty, error) {
+	return &emptypb.Empty{}, s.call(ctx, "SaveState")
+}
+
+func (s *flakyDaprServer) PublishEvent(ctx context.Context, in *pb.PublishEventRequest) (*emptypb.Empty, error) {
+	return &emptypb.Empty{}, s.call(ctx, "PublishEvent")
+}
+
+func (s *flakyDaprServer) InvokeService(ctx context.Context, in *pb.InvokeServiceRequest) (*commonv1pb.InvokeResponse, error) {
+	if err := s.call(ctx, "InvokeService"); err != nil {
+		return nil, err
+	}
+	return &commonv1pb.InvokeResponse{}, nil
+}
+
+func newResilientClient(t *testing.T, opts ResiliencyOptions) (Client, *flakyDaprServer, *Resiliency) {
+	t.Helper()
+	srv := &flakyDaprServer{failures: map[string]int{}, calls: map[string]int{}}
+	s := grpc.NewServer()
+	pb.RegisterDaprServer(s, srv)
+	l := bufconn.Listen(testBufSize)
+	go s.Serve(l)
+	t.Cleanup(s.Stop)
+
+	r, err := NewResiliency(opts)
+	require.NoError(t, err)
+	dialOpts := append([]grpc.DialOption{
+		grpc.WithContextDialer(func(context.Context, string) (net.Conn, error) { return l.Dial() }),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	}, r.DialOptions()...)
+	c, err := NewClientWithAddressContext(t.Context(), "localhost:50001", dialOpts...)
+	require.NoError(t, err)
+	t.Cleanup(c.Close)
+	return c, srv, r
+}
+
+func TestResiliencyRetry(t *testing.T) {
+	ctx := t.Context()
+	retry := &RetryPolicy{InitialInterval: time.Millisecond, MaxRetries: 2}
+	c, srv, _ := newResilientClient(t, ResiliencyOptions{
+		Policies: map[BuildingBlock]ResiliencyPolicy{
+			BuildingBlockState:  {Retry: retry},
+			BuildingBlockPubsub: {Retry: retry},
+			BuildingBlockInvoke: {Retry: retry},
+		},
+	})
+
+	t.Run("retries retry-safe calls", func(t *testing.T) {
+		srv.setFailures("GetState", 2)
+		item, err := c.GetState(ctx, "store", "key", nil)
+		require.NoError(t, err)
+		assert.Equal(t, []byte("value"), item.Value)
+		assert.Equal(t, 3, srv.callCount("GetState"))
+
+		srv.setFailures("GetState", 5)
+		_, err = c.GetState(ctx, "store", "key", nil)
+		require.Equal(t, codes.Unavailable, status.Code(err))
+		assert.Equal(t, 3, srv.callCount("GetState"), "up to MaxRetries")
+	})
+
+	t.Run("does not retry unsafe calls", func(t *testing.T) {
+		srv.setFailures("PublishEvent", 1)
+		err := c.PublishEvent(ctx, "pubsub", "orders", []byte("order"))
+		require.Equal(t, codes.Unavailable, status.Code(err))
+		assert.Equal(t, 1, srv.callCount("PublishEvent"))
+	})
+
+	t.Run("classifies invocations by verb", func(t *testing.T) {
+		srv.setFailures("InvokeService", 1)
+		_, err := c.InvokeMethod(ctx, "orders", "orders", "post")
+		require.Error(t, err)
+		assert.Equal(t, 1, srv.callCount("InvokeService"))
+
+		srv.setFailures("InvokeService", 1)
+		_, err = c.InvokeMethod(ctx, "orders", "orders", "get")
+		require.NoError(t, err)
+		assert.Equal(t, 2, srv.callCount("InvokeService"))
+	})
+
+	t.Run("classifies state writes by etag and concurrency", func(t *testing.T) {
+		srv.setFailures("SaveState", 1)
+		require.NoError(t, c.SaveState(ctx, "store", "key", []byte("value"), nil))
+		assert.Equal(t, 2, srv.callCount("SaveState"))
+
+		srv.setFailures("SaveState", 1)
+		err := c.SaveStateWithETag(ctx, "store", "key", []byte("value"), "1", nil)
+		require.Equal(t, codes.Unavailable, status.Code(err))
+		assert.Equal(t, 1, srv.callCount("SaveState"))
+
+		srv.setFailures("SaveState", 1)
+		err = c.SaveState(ctx, "store", "key", []byte("value"), nil, WithConcurrency(StateConcurrencyFirstWrite))
+		require.Equal(t, codes.Unavailable, status.Code(err))
+		assert.Equal(t, 1, srv.callCount("SaveState"))
+	})
+}
+
+func TestResiliencyRetryUnsafe(t *testing.T) {
+	c, srv, _ := newResilientClient(t, ResiliencyOptions{
+		Default: &ResiliencyPolicy{Retry: &RetryPolicy{InitialInterval: time.Millisecond, RetryUnsafe: true}},
+	})
+	srv.setFailures("PublishEvent", 1)
+	require.NoError(t, c.PublishEvent(t.Context(), "pubsub", "orders", []byte("order")))
+	assert.Equal(t, 2, srv.callCount("PublishEvent"))
+}
+
+func TestResiliencyTimeout(t *testing.T) {
+	c, srv, _ := newResilientClient(t, ResiliencyOptions{
+		Policies: map[BuildingBlock]ResiliencyPolicy{
+			BuildingBlockState: {
+				Timeout: 20 * time.Millisecond,
+				Retry:   &RetryPolicy{InitialInterval: time.Millisecond, MaxRetries: 1},
+			},
+		},
+	})
+	srv.lock.Lock()
+	srv.delay = time.Second
+	srv.lock.Unlock()
+	start := time.Now()
+	_, err := c.GetState(t.Context(), "store", "key", nil)
+	require.Equal(t, codes.DeadlineExceeded, status.Code(err))
+	assert.Less(t, time.Since(start), 500*time.Millisecond)
+	assert.Equal(t, 2, srv.callCount("GetState"), "attempts exceeding the timeout are retried")
+}
+
+func TestResiliencyCircuitBreaker(t *testing.T) {
+	ctx := t.Context()
+	c, srv, r := newResilientClient(t, ResiliencyOptions{
+		Policies: map[BuildingBlock]ResiliencyPolicy{
+			BuildingBlockState: {CircuitBreaker: &CircuitBreakerPolicy{ConsecutiveFailures: 2, OpenDuration: 50 * time.Millisecond}},
+		},
+	})
+	assert.Equal(t, "closed", r.CircuitState(BuildingBlockState))
+	assert.Empty(t, r.CircuitState(BuildingBlockPubsub))
+
+	srv.setFailures("GetState", 3)
+	for range 2 {
+		_, err := c.GetState(ctx, "store", "key", nil)
+		require.Equal(t, codes.Unavailable, status.Code(err))
+	}
+	assert.Equal(t, "open", r.CircuitState(BuildingBlockState))
+	_, err := c.GetState(ctx, "store", "key", nil)
+	require.ErrorIs(t, err, ErrCircuitOpen)
+	assert.Equal(t, 2, srv.callCount("GetState"), "calls are rejected while open")
+	require.NoError(t, c.PublishEvent(ctx, "pubsub", "orders", []byte("order")), "other building blocks are not affected")
+
+	time.Sleep(60 * time.Millisecond)
+	assert.Equal(t, "half-open", r.CircuitState(BuildingBlockState))
+	_, err = c.GetState(ctx, "store", "key", nil)
+	require.Equal(t, codes.Unavailable, status.Code(err), "the failed probe opens the circuit again")
+	assert.Equal(t, "open", r.CircuitState(BuildingBlockState))
+
+	time.Sleep(60 * time.Millisecond)
+	_, err = c.GetState(ctx, "store", "key", nil)
+	require.NoError(t, err)
+	assert.Equal(t, "closed", r.CircuitState(BuildingBlockState))
+}
+
+func TestCircuitBreakerHalfOpenProbes(t *testing.T) {
+	cb := &circuitBreaker{policy: CircuitBreakerPolicy{ConsecutiveFailures: 1, OpenDuration: time.Millisecond, HalfOpenRequests: 2}}
+	require.True(t, cb.allow())
+	cb.record(true)
+	require.False(t, cb.allow())
+
+	time.Sleep(2 * time.Millisecond)
+	assert.True(t, cb.allow())
+	assert.True(t, cb.allow())
+	assert.False(t, cb.allow(), "up to HalfOpenRequests probes")
+	cb.record(false)
+	assert.True(t, cb.allow())
+}
+
+func TestNewResiliency(t *testing.T) {
+	_, err := NewResiliency(ResiliencyOptions{Policies: map[BuildingBlock]ResiliencyPolicy{"queues": {}}})
+	require.ErrorContains(t, err, `unknown building block "queues"`)
+	_, err = NewResiliency(ResiliencyOptions{Default: &ResiliencyPolicy{Retry: &RetryPolicy{MaxRetries: -1}}})
+	require.ErrorContains(t, err, "invalid state resiliency policy")
+
+	assert.Equal(t, operation{BuildingBlockOther, false}, classify(pb.Dapr_TryLockAlpha1_FullMethodName, nil))
+	assert.Equal(t, operation{BuildingBlockActors, false}, classify(pb.Dapr_InvokeActor_FullMethodName, nil))
+	assert.Equal(t, operation{BuildingBlockInvoke, true}, classify(pb.Dapr_InvokeService_FullMethodName, &pb.InvokeServiceRequest{
+		Message: &commonv1pb.InvokeRequest{HttpExtension: &commonv1pb.HTTPExtension{Verb: commonv1pb.HTTPExtension_DELETE}},
+	}))
+
+	firstWrite := &commonv1pb.StateOptions{Concurrency: commonv1pb.StateOptions_CONCURRENCY_FIRST_WRITE}
+	lastWrite := &commonv1pb.StateOptions{Concurrency: commonv1pb.StateOptions_CONCURRENCY_LAST_WRITE}
+	assert.True(t, classify(pb.Dapr_DeleteState_FullMethodName, &pb.DeleteStateRequest{Options: lastWrite}).retrySafe)
+	assert.False(t, classify(pb.Dapr_DeleteState_FullMethodName, &pb.DeleteStateRequest{Etag: &commonv1pb.Etag{Value: "1"}}).retrySafe)
+	assert.False(t, classify(pb.Dapr_DeleteState_FullMethodName, &pb.DeleteStateRequest{Options: firstWrite}).retrySafe)
+	assert.True(t, classify(pb.Dapr_DeleteBulkState_FullMethodName, &pb.DeleteBulkStateRequest{
+		States: []*commonv1pb.StateItem{{Key: "a"}, {Key: "b"}},
+	}).retrySafe)
+	assert.False(t, classify(pb.Dapr_DeleteBulkState_FullMethodName, &pb.DeleteBulkStateRequest{
+		States: []*commonv1pb.StateItem{{Key: "a"}, {Key: "b", Etag: &commonv1pb.Etag{Value: "1"}}},
+	}).retrySafe)
+	assert.True(t, classify(pb.Dapr_ExecuteStateTransaction_FullMethodName, &pb.ExecuteStateTransactionRequest{
+		Operations: []*pb.TransactionalStateOperation{{OperationType: "upsert", Request: &commonv1pb.StateItem{Key: "a"}}},
+	}).retrySafe)
+	assert.False(t, classify(pb.Dapr_ExecuteStateTransaction_FullMethodName, &pb.ExecuteStateTransactionRequest{
+		Operations: []*pb.TransactionalStateOperation{
+			{OperationType: "upsert", Request: &commonv1pb.StateItem{Key: "a"}},
+			{OperationType: "delete", Request: &commonv1pb.StateItem{Key: "b", Options: firstWrite}},
+		},
+	}).retrySafe)
+}
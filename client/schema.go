@@ -0,0 +1,87 @@
+/*
+Copyright 2026 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package client
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"mime"
+
+	"github.com/dapr/go-sdk/schema"
+	"github.com/dapr/go-sdk/service/common"
+)
+
+// WithSchemaValidation validates the data of events against the schemas of r
+// before they are published with PublishEvent and PublishEvents. Events are
+// matched by topic, or by the dataschema of structured CloudEvents. Passing
+// nil disables validation.
+func (c *GRPCClient) WithSchemaValidation(r *schema.Registry) {
+	c.schemas.Store(r)
+}
+
+// validateEvent validates the data of an event about to be published. Events
+// compressed by NewCompressionClient are validated once decompressed. The data
+// of structured CloudEvents, published with the CloudEventContentType content
+// type, is taken from the envelope.
+func (c *GRPCClient) validateEvent(ctx context.Context, pubsubName, topicName, contentType string, data []byte) error {
+	r := c.schemas.Load()
+	if r == nil {
+		return nil
+	}
+	if common.IsCompressedPayload(data) {
+		var err error
+		if data, contentType, err = common.DecompressPayload(data, contentType); err != nil {
+			return fmt.Errorf("error decompressing event: %w", err)
+		}
+	}
+	var dataSchema string
+	if mt, _, _ := mime.ParseMediaType(contentType); mt == CloudEventContentType {
+		var err error
+		if dataSchema, data, err = cloudEventData(data); err != nil {
+			return err
+		}
+	}
+	return r.Validate(ctx, pubsubName, topicName, dataSchema, data)
+}
+
+// cloudEventData returns the dataschema and the data of a structured CloudEvent.
+func cloudEventData(event []byte) (dataSchema string, data []byte, err error) {
+	var ce struct {
+		DataSchema      string          `json:"dataschema"`
+		DataContentType string          `json:"datacontenttype"`
+		Data            json.RawMessage `json:"data"`
+		DataBase64      string          `json:"data_base64"`
+	}
+	if err = json.Unmarshal(event, &ce); err != nil {
+		return "", nil, fmt.Errorf("error parsing CloudEvent: %w", err)
+	}
+	switch {
+	case ce.DataBase64 != "":
+		if data, err = base64.StdEncoding.DecodeString(ce.DataBase64); err != nil {
+			return "", nil, fmt.Errorf("error decoding CloudEvent data_base64: %w", err)
+		}
+	case ce.DataContentType != "" && !common.IsJSONContentType(ce.DataContentType):
+		// Non-JSON data is carried as a JSON string.
+		var s string
+		if err = json.Unmarshal(ce.Data, &s); err != nil {
+			return "", nil, fmt.Errorf("error parsing CloudEvent data: %w", err)
+		}
+		data = []byte(s)
+	default:
+		data = ce.Data
+	}
+	return ce.DataSchema, data, nil
+}
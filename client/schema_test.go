@@ -0,0 +1,106 @@
+/*
+Copyright 2026 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package client
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/dapr/go-sdk/schema"
+	"github.com/dapr/go-sdk/service/common"
+)
+
+func TestPublishSchemaValidation(t *testing.T) {
+	ctx := t.Context()
+
+	orderSchema, err := schema.JSONSchema([]byte(`{"type":"object","required":["id"],"properties":{"total":{"type":"integer"}}}`))
+	require.NoError(t, err)
+	v2Schema, err := schema.JSONSchema([]byte(`{"type":"object","required":["id","currency"]}`))
+	require.NoError(t, err)
+	registry := &schema.Registry{}
+	registry.RegisterTopic("pubsub", "orders", orderSchema)
+	registry.RegisterSchema("https://example.com/order/v2", v2Schema)
+
+	fake := &fakePublishClient{}
+	c := &GRPCClient{protoClient: fake}
+	c.WithSchemaValidation(registry)
+
+	t.Run("publish event", func(t *testing.T) {
+		require.NoError(t, c.PublishEvent(ctx, "pubsub", "orders", order{ID: "o1", Total: 3}))
+		require.NoError(t, c.PublishEvent(ctx, "pubsub", "payments", `not json`), "topics without schema are not validated")
+
+		err := c.PublishEvent(ctx, "pubsub", "orders", map[string]any{"total": 3})
+		var verr *schema.ValidationError
+		require.ErrorAs(t, err, &verr)
+		assert.Equal(t, "orders", verr.Topic)
+		assert.Len(t, fake.requests, 2, "invalid events are not sent")
+	})
+
+	t.Run("publish CloudEvent with dataschema", func(t *testing.T) {
+		p, err := NewPublisher(c, "pubsub", "orders", JSONCodec[order](), PublisherOptions{Source: "checkout"})
+		require.NoError(t, err)
+
+		err = p.Publish(ctx, order{ID: "o1"}, WithCloudEventDataSchema("https://example.com/order/v2"))
+		var verr *schema.ValidationError
+		require.ErrorAs(t, err, &verr)
+		assert.Equal(t, "https://example.com/order/v2", verr.DataSchema)
+
+		require.NoError(t, p.Publish(ctx, order{ID: "o1"}, WithCloudEventSubject("o1")))
+	})
+
+	t.Run("CloudEvents are only unwrapped with their content type", func(t *testing.T) {
+		event := []byte(`{"id":"1","source":"checkout","specversion":"1.0","type":"order","data":{"total":3}}`)
+		require.NoError(t, c.PublishEvent(ctx, "pubsub", "orders", event, PublishEventWithContentType("application/json")))
+
+		err := c.PublishEvent(ctx, "pubsub", "orders", event, PublishEventWithContentType(CloudEventContentType))
+		var verr *schema.ValidationError
+		require.ErrorAs(t, err, &verr)
+	})
+
+	t.Run("publish compressed event", func(t *testing.T) {
+		zc, err := NewCompressionClient(c, CompressionOptions{Encoding: common.CompressionGzip, MinSize: 1})
+		require.NoError(t, err)
+
+		sent := len(fake.requests)
+		note := strings.Repeat("compressible ", 20)
+		require.NoError(t, zc.PublishEvent(ctx, "pubsub", "orders", map[string]any{"id": "o1", "note": note}))
+		require.Len(t, fake.requests, sent+1)
+		assert.True(t, common.IsCompressedPayload(fake.requests[sent].GetData()))
+
+		err = zc.PublishEvent(ctx, "pubsub", "orders", map[string]any{"total": 3, "note": note})
+		var verr *schema.ValidationError
+		require.ErrorAs(t, err, &verr)
+		assert.Len(t, fake.requests, sent+1, "invalid events are not sent")
+	})
+
+	t.Run("publish events", func(t *testing.T) {
+		valid, invalid := order{ID: "o1"}, map[string]any{"total": "3"}
+		res := c.PublishEvents(ctx, "pubsub", "orders", []interface{}{valid, invalid})
+		var verr *schema.ValidationError
+		require.ErrorAs(t, res.Error, &verr)
+		assert.Equal(t, []interface{}{invalid}, res.FailedEvents)
+		require.Len(t, fake.bulkRequests, 1)
+		assert.Len(t, fake.bulkRequests[0].GetEntries(), 1)
+
+		res = c.PublishEvents(ctx, "pubsub", "orders", []interface{}{invalid})
+		require.ErrorAs(t, res.Error, &verr)
+		assert.Len(t, fake.bulkRequests, 1, "nothing is sent without valid events")
+	})
+
+	c.WithSchemaValidation(nil)
+	require.NoError(t, c.PublishEvent(ctx, "pubsub", "orders", map[string]any{"total": 3}))
+}
@@ -0,0 +1,245 @@
+/*
+Copyright 2026 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+)
+
+const (
+	// DefaultBulkStateBatchSize is the default number of keys sent in a single chunked bulk state request.
+	DefaultBulkStateBatchSize = 100
+	// DefaultBulkStateConcurrency is the default number of chunked bulk state requests in flight.
+	DefaultBulkStateConcurrency = 1
+)
+
+// BulkStateOptions configures the chunked bulk state operations.
+type BulkStateOptions struct {
+	// BatchSize is the maximum number of keys sent in a single request.
+	// Defaults to DefaultBulkStateBatchSize.
+	BatchSize int
+	// Concurrency is the maximum number of requests in flight.
+	// Defaults to DefaultBulkStateConcurrency.
+	Concurrency int
+	// Metadata is sent with every GetBulkState and DeleteBulkState request.
+	Metadata map[string]string
+	// Parallelism is passed to every GetBulkState request.
+	Parallelism int32
+}
+
+// BulkStateFailure describes a key for which a chunked bulk state operation failed.
+type BulkStateFailure struct {
+	Key string
+	// Item is the item which failed to be saved by SaveBulkStateChunked, nil otherwise.
+	Item *SetStateItem
+	Err  error
+}
+
+// BulkStateReport is the result of a chunked bulk state operation.
+type BulkStateReport struct {
+	// Succeeded are the keys which were processed successfully, in input order.
+	Succeeded []string
+	// Failed are the keys which failed, in input order. Keys which were not
+	// attempted because the context was canceled are reported with the context error.
+	Failed []BulkStateFailure
+	// Items are the items retrieved by GetBulkStateChunked, in input order.
+	Items []*BulkStateItem
+}
+
+// FailedKeys returns the failed keys, which can be passed back to
+// GetBulkStateChunked or DeleteBulkStateChunked to resume the operation.
+func (r *BulkStateReport) FailedKeys() []string {
+	keys := make([]string, len(r.Failed))
+	for i, f := range r.Failed {
+		keys[i] = f.Key
+	}
+	return keys
+}
+
+// FailedItems returns the items which failed to be saved, which can be passed
+// back to SaveBulkStateChunked to resume the operation.
+func (r *BulkStateReport) FailedItems() []*SetStateItem {
+	items := make([]*SetStateItem, 0, len(r.Failed))
+	for _, f := range r.Failed {
+		if f.Item != nil {
+			items = append(items, f.Item)
+		}
+	}
+	return items
+}
+
+// Err returns an error describing the failed keys, or nil if there were none.
+func (r *BulkStateReport) Err() error {
+	if len(r.Failed) == 0 {
+		return nil
+	}
+	errs := make([]error, len(r.Failed))
+	for i, f := range r.Failed {
+		errs[i] = fmt.Errorf("key %s: %w", f.Key, f.Err)
+	}
+	return fmt.Errorf("%d of %d keys failed: %w", len(r.Failed), len(r.Failed)+len(r.Succeeded), errors.Join(errs...))
+}
+
+// batchResult is the outcome of a single chunked request.
+type batchResult struct {
+	succeeded []string
+	failed    []BulkStateFailure
+	items     []*BulkStateItem
+}
+
+// SaveBulkStateChunked saves items to store in batches of at most BatchSize
+// items, with at most Concurrency requests in flight. All items of a batch
+// which fails are reported as failed. The returned error is the report's Err.
+func SaveBulkStateChunked(ctx context.Context, c Client, storeName string, items []*SetStateItem, opts *BulkStateOptions) (*BulkStateReport, error) {
+	if storeName == "" {
+		return nil, errors.New("nil store")
+	}
+	for i, item := range items {
+		if item == nil {
+			return nil, fmt.Errorf("nil item at index %d", i)
+		}
+	}
+	return runBulkState(ctx, items, opts, func(ctx context.Context, batch []*SetStateItem) batchResult {
+		var res batchResult
+		if err := c.SaveBulkState(ctx, storeName, batch...); err != nil {
+			for _, item := range batch {
+				res.failed = append(res.failed, BulkStateFailure{Key: item.Key, Item: item, Err: err})
+			}
+			return res
+		}
+		for _, item := range batch {
+			res.succeeded = append(res.succeeded, item.Key)
+		}
+		return res
+	}, func(item *SetStateItem, err error) BulkStateFailure {
+		return BulkStateFailure{Key: item.Key, Item: item, Err: err}
+	})
+}
+
+// GetBulkStateChunked retrieves state for keys from store in batches of at most
+// BatchSize keys, with at most Concurrency requests in flight. Keys for which
+// the state store returned an item error are reported as failed, and all keys
+// of a batch which fails are reported as failed. The returned error is the report's Err.
+func GetBulkStateChunked(ctx context.Context, c Client, storeName string, keys []string, opts *BulkStateOptions) (*BulkStateReport, error) {
+	if storeName == "" {
+		return nil, errors.New("nil store")
+	}
+	meta, parallelism := bulkStateRequestOptions(opts)
+	return runBulkState(ctx, keys, opts, func(ctx context.Context, batch []string) batchResult {
+		var res batchResult
+		items, err := c.GetBulkState(ctx, storeName, batch, meta, parallelism)
+		if err != nil {
+			for _, key := range batch {
+				res.failed = append(res.failed, BulkStateFailure{Key: key, Err: err})
+			}
+			return res
+		}
+		for _, item := range items {
+			if item.Error != "" {
+				res.failed = append(res.failed, BulkStateFailure{Key: item.Key, Err: errors.New(item.Error)})
+				continue
+			}
+			res.succeeded = append(res.succeeded, item.Key)
+			res.items = append(res.items, item)
+		}
+		return res
+	}, func(key string, err error) BulkStateFailure {
+		return BulkStateFailure{Key: key, Err: err}
+	})
+}
+
+// DeleteBulkStateChunked deletes keys from store in batches of at most
+// BatchSize keys, with at most Concurrency requests in flight. All keys of a
+// batch which fails are reported as failed. The returned error is the report's Err.
+func DeleteBulkStateChunked(ctx context.Context, c Client, storeName string, keys []string, opts *BulkStateOptions) (*BulkStateReport, error) {
+	if storeName == "" {
+		return nil, errors.New("nil store")
+	}
+	meta, _ := bulkStateRequestOptions(opts)
+	return runBulkState(ctx, keys, opts, func(ctx context.Context, batch []string) batchResult {
+		var res batchResult
+		if err := c.DeleteBulkState(ctx, storeName, batch, meta); err != nil {
+			for _, key := range batch {
+				res.failed = append(res.failed, BulkStateFailure{Key: key, Err: err})
+			}
+			return res
+		}
+		res.succeeded = append(res.succeeded, batch...)
+		return res
+	}, func(key string, err error) BulkStateFailure {
+		return BulkStateFailure{Key: key, Err: err}
+	})
+}
+
+func bulkStateRequestOptions(opts *BulkStateOptions) (map[string]string, int32) {
+	if opts == nil {
+		return nil, 0
+	}
+	return opts.Metadata, opts.Parallelism
+}
+
+// runBulkState splits in into batches and runs fn for each of them,
+// collecting the results in input order.
+func runBulkState[T any](ctx context.Context, in []T, opts *BulkStateOptions, fn func(context.Context, []T) batchResult, skipped func(T, error) BulkStateFailure) (*BulkStateReport, error) {
+	batchSize, concurrency := DefaultBulkStateBatchSize, DefaultBulkStateConcurrency
+	if opts != nil && opts.BatchSize > 0 {
+		batchSize = opts.BatchSize
+	}
+	if opts != nil && opts.Concurrency > 0 {
+		concurrency = opts.Concurrency
+	}
+
+	batches := make([][]T, 0, (len(in)+batchSize-1)/batchSize)
+	for start := 0; start < len(in); start += batchSize {
+		batches = append(batches, in[start:min(start+batchSize, len(in))])
+	}
+
+	results := make([]batchResult, len(batches))
+	sem := make(chan struct{}, concurrency)
+	var wg sync.WaitGroup
+	for i, batch := range batches {
+		select {
+		case sem <- struct{}{}:
+		case <-ctx.Done():
+		}
+		if err := ctx.Err(); err != nil {
+			for _, v := range batch {
+				results[i].failed = append(results[i].failed, skipped(v, err))
+			}
+			continue
+		}
+
+		wg.Add(1)
+		go func() {
+			defer func() {
+				<-sem
+				wg.Done()
+			}()
+			results[i] = fn(ctx, batch)
+		}()
+	}
+	wg.Wait()
+
+	report := &BulkStateReport{}
+	for _, res := range results {
+		report.Succeeded = append(report.Succeeded, res.succeeded...)
+		report.Failed = append(report.Failed, res.failed...)
+		report.Items = append(report.Items, res.items...)
+	}
+
+	return report, report.Err()
+}
@@ -0,0 +1,208 @@
+/*
+Copyright 2026 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// flakyStateClient fails bulk requests containing keys prefixed with "fail"
+// and reports item errors for keys prefixed with "bad".
+type flakyStateClient struct {
+	Client
+
+	lock     sync.Mutex
+	requests [][]string
+}
+
+func (c *flakyStateClient) record(keys []string) error {
+	c.lock.Lock()
+	c.requests = append(c.requests, keys)
+	c.lock.Unlock()
+	for _, k := range keys {
+		if strings.HasPrefix(k, "fail") {
+			return errors.New("request too large")
+		}
+	}
+	return nil
+}
+
+func (c *flakyStateClient) SaveBulkState(ctx context.Context, storeName string, items ...*SetStateItem) error {
+	keys := make([]string, len(items))
+	for i, item := range items {
+		keys[i] = item.Key
+	}
+	if err := c.record(keys); err != nil {
+		return err
+	}
+	return c.Client.SaveBulkState(ctx, storeName, items...)
+}
+
+func (c *flakyStateClient) GetBulkState(ctx context.Context, storeName string, keys []string, meta map[string]string, parallelism int32) ([]*BulkStateItem, error) {
+	if err := c.record(keys); err != nil {
+		return nil, err
+	}
+	items := make([]*BulkStateItem, len(keys))
+	for i, k := range keys {
+		items[i] = &BulkStateItem{Key: k, Value: []byte(k)}
+		if strings.HasPrefix(k, "bad") {
+			items[i] = &BulkStateItem{Key: k, Error: "corrupt value"}
+		}
+	}
+	return items, nil
+}
+
+func (c *flakyStateClient) DeleteBulkState(ctx context.Context, storeName string, keys []string, meta map[string]string) error {
+	if err := c.record(keys); err != nil {
+		return err
+	}
+	return c.Client.DeleteBulkState(ctx, storeName, keys, meta)
+}
+
+func bulkTestKeys(prefix string, n int) []string {
+	keys := make([]string, n)
+	for i := range keys {
+		keys[i] = fmt.Sprintf("%s%03d", prefix, i)
+	}
+	return keys
+}
+
+func TestSaveBulkStateChunked(t *testing.T) {
+	ctx := t.Context()
+	store := "test"
+	keys := bulkTestKeys("chunk-", 25)
+	t.Cleanup(func() {
+		_ = testClient.DeleteBulkState(context.Background(), store, keys, nil)
+	})
+
+	items := make([]*SetStateItem, len(keys))
+	for i, k := range keys {
+		items[i] = &SetStateItem{Key: k, Value: []byte("test")}
+	}
+
+	t.Run("without store", func(t *testing.T) {
+		_, err := SaveBulkStateChunked(ctx, testClient, "", items, nil)
+		require.Error(t, err)
+	})
+
+	t.Run("nil item", func(t *testing.T) {
+		withNil := append([]*SetStateItem{}, items...)
+		withNil[7] = nil
+		_, err := SaveBulkStateChunked(ctx, testClient, store, withNil, nil)
+		require.EqualError(t, err, "nil item at index 7")
+	})
+
+	t.Run("chunks requests", func(t *testing.T) {
+		c := &flakyStateClient{Client: testClient}
+		report, err := SaveBulkStateChunked(ctx, c, store, items, &BulkStateOptions{BatchSize: 10, Concurrency: 3})
+		require.NoError(t, err)
+		assert.Equal(t, keys, report.Succeeded)
+		assert.Empty(t, report.Failed)
+		require.Len(t, c.requests, 3)
+		for _, req := range c.requests {
+			assert.LessOrEqual(t, len(req), 10)
+		}
+
+		got, err := testClient.GetBulkState(ctx, store, keys, nil, 1)
+		require.NoError(t, err)
+		assert.Len(t, got, len(keys))
+	})
+
+	t.Run("reports failed batches and resumes", func(t *testing.T) {
+		failing := append([]*SetStateItem{}, items...)
+		failing[12] = &SetStateItem{Key: "fail-once", Value: []byte("test")}
+		t.Cleanup(func() {
+			_ = testClient.DeleteState(context.Background(), store, "fail-once", nil)
+		})
+
+		c := &flakyStateClient{Client: testClient}
+		report, err := SaveBulkStateChunked(ctx, c, store, failing, &BulkStateOptions{BatchSize: 10})
+		require.Error(t, err)
+		assert.Len(t, report.Succeeded, 15)
+		require.Len(t, report.Failed, 10)
+		assert.Equal(t, failing[10:20], report.FailedItems())
+		for _, f := range report.Failed {
+			require.EqualError(t, f.Err, "request too large")
+		}
+
+		retry := report.FailedItems()
+		retry[2] = items[12]
+		report, err = SaveBulkStateChunked(ctx, c, store, retry, &BulkStateOptions{BatchSize: 10})
+		require.NoError(t, err)
+		assert.Len(t, report.Succeeded, 10)
+	})
+
+	t.Run("reports unsent batches on cancel", func(t *testing.T) {
+		canceled, cancel := context.WithCancel(ctx)
+		cancel()
+		report, err := SaveBulkStateChunked(canceled, testClient, store, items, &BulkStateOptions{BatchSize: 10})
+		require.ErrorIs(t, err, context.Canceled)
+		assert.Empty(t, report.Succeeded)
+		assert.Len(t, report.FailedItems(), len(items))
+	})
+}
+
+func TestGetBulkStateChunked(t *testing.T) {
+	ctx := t.Context()
+	c := &flakyStateClient{Client: testClient}
+	keys := append(bulkTestKeys("chunk-", 8), "bad-key", "fail-key")
+
+	report, err := GetBulkStateChunked(ctx, c, "test", keys, &BulkStateOptions{BatchSize: 3, Concurrency: 2})
+	require.Error(t, err)
+	require.Len(t, c.requests, 4)
+	assert.Equal(t, keys[:8], report.Succeeded)
+	require.Len(t, report.Items, 8)
+	for i, item := range report.Items {
+		assert.Equal(t, keys[i], item.Key)
+		assert.Equal(t, []byte(keys[i]), item.Value)
+	}
+	assert.Equal(t, []string{"bad-key", "fail-key"}, report.FailedKeys())
+	require.EqualError(t, report.Failed[0].Err, "corrupt value")
+	require.EqualError(t, report.Failed[1].Err, "request too large")
+	assert.Empty(t, report.FailedItems())
+}
+
+func TestDeleteBulkStateChunked(t *testing.T) {
+	ctx := t.Context()
+	store := "test"
+	keys := bulkTestKeys("chunk-del-", 7)
+	for _, k := range keys {
+		require.NoError(t, testClient.SaveState(ctx, store, k, []byte("test"), nil))
+	}
+	t.Cleanup(func() {
+		_ = testClient.DeleteBulkState(context.Background(), store, keys, nil)
+	})
+
+	c := &flakyStateClient{Client: testClient}
+	report, err := DeleteBulkStateChunked(ctx, c, store, append(keys, "fail-key"), &BulkStateOptions{BatchSize: 4})
+	require.Error(t, err)
+	assert.Equal(t, keys[:4], report.Succeeded)
+	assert.Equal(t, append(keys[4:], "fail-key"), report.FailedKeys())
+
+	report, err = DeleteBulkStateChunked(ctx, c, store, report.FailedKeys()[:3], nil)
+	require.NoError(t, err)
+	assert.Equal(t, keys[4:], report.Succeeded)
+
+	got, err := testClient.GetBulkState(ctx, store, keys, nil, 1)
+	require.NoError(t, err)
+	assert.Empty(t, got)
+}
@@ -0,0 +1,288 @@
+/*
+Copyright 2026 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package client
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+)
+
+// stateEncryptionHeader starts the envelope of encrypted state values. It is
+// followed by the length of the key name as a uvarint, the key name and the
+// document produced by the Dapr cryptography API, which can not start with a
+// NUL byte. The last byte is the version of the envelope.
+const stateEncryptionHeader = "\x00DSE\x01"
+
+// StateEncryptionOptions contains options passed to NewStateEncryptionClient.
+type StateEncryptionOptions struct {
+	// EncryptOptions are used to encrypt every state value. ComponentName,
+	// KeyName and KeyWrapAlgorithm are required.
+	EncryptOptions
+	// Stores restricts encryption to the named state stores.
+	// If empty, values in every state store are encrypted.
+	Stores []string
+}
+
+// stateEncryptionClient is a Client which transparently encrypts state values
+// using the Dapr cryptography API. All other methods are passed through.
+type stateEncryptionClient struct {
+	Client
+
+	opts   EncryptOptions
+	stores map[string]struct{}
+}
+
+// NewStateEncryptionClient returns a Client which encrypts state values with
+// the given crypto component before they are sent to the state store, and
+// decrypts them when they are read back.
+// Values are encrypted by SaveState, SaveStateWithETag, SaveBulkState and the
+// upsert operations of ExecuteStateTransaction, and decrypted by GetState,
+// GetStateWithConsistency, GetBulkState and QueryStateAlpha1.
+// Encrypted values are stored in an envelope recording the name of the key
+// used for encryption, the DecryptionKeyName if set, so values written with a
+// key which has since been rotated remain readable, even when
+// OmitDecryptionKeyName is set. Values without the envelope are decrypted
+// with the key referenced by the encrypted document, or with the current key
+// if OmitDecryptionKeyName is set.
+// Empty values are stored unencrypted.
+func NewStateEncryptionClient(c Client, opts StateEncryptionOptions) (Client, error) {
+	if c == nil {
+		return nil, errors.New("client is required")
+	}
+	if opts.ComponentName == "" {
+		return nil, errors.New("option 'ComponentName' is required")
+	}
+	if opts.KeyName == "" {
+		return nil, errors.New("option 'KeyName' is required")
+	}
+	if opts.KeyWrapAlgorithm == "" {
+		return nil, errors.New("option 'Algorithm' is required")
+	}
+
+	stores := make(map[string]struct{}, len(opts.Stores))
+	for _, s := range opts.Stores {
+		stores[s] = struct{}{}
+	}
+
+	return &stateEncryptionClient{
+		Client: c,
+		opts:   opts.EncryptOptions,
+		stores: stores,
+	}, nil
+}
+
+// SaveState encrypts data and saves it into store, default options: strong, last-write.
+func (c *stateEncryptionClient) SaveState(ctx context.Context, storeName, key string, data []byte, meta map[string]string, so ...StateOption) error {
+	return c.SaveStateWithETag(ctx, storeName, key, data, "", meta, so...)
+}
+
+// SaveStateWithETag encrypts data and saves it into store using provided state options and etag.
+func (c *stateEncryptionClient) SaveStateWithETag(ctx context.Context, storeName, key string, data []byte, etag string, meta map[string]string, so ...StateOption) error {
+	if !c.encrypts(storeName) || len(data) == 0 {
+		return c.Client.SaveStateWithETag(ctx, storeName, key, data, etag, meta, so...)
+	}
+
+	enc, err := c.encrypt(ctx, data)
+	if err != nil {
+		return fmt.Errorf("error encrypting state for key %s: %w", key, err)
+	}
+
+	return c.Client.SaveStateWithETag(ctx, storeName, key, enc, etag, meta, so...)
+}
+
+// SaveBulkState encrypts the values of items and saves them to store.
+// The given items are not modified.
+func (c *stateEncryptionClient) SaveBulkState(ctx context.Context, storeName string, items ...*SetStateItem) error {
+	if !c.encrypts(storeName) {
+		return c.Client.SaveBulkState(ctx, storeName, items...)
+	}
+
+	encItems := make([]*SetStateItem, len(items))
+	for i, item := range items {
+		encItem, err := c.encryptItem(ctx, item)
+		if err != nil {
+			return err
+		}
+		encItems[i] = encItem
+	}
+
+	return c.Client.SaveBulkState(ctx, storeName, encItems...)
+}
+
+// ExecuteStateTransaction encrypts the values of upsert operations and executes
+// the operations on store. The given operations are not modified.
+func (c *stateEncryptionClient) ExecuteStateTransaction(ctx context.Context, storeName string, meta map[string]string, ops []*StateOperation) error {
+	if !c.encrypts(storeName) {
+		return c.Client.ExecuteStateTransaction(ctx, storeName, meta, ops)
+	}
+
+	encOps := make([]*StateOperation, len(ops))
+	for i, op := range ops {
+		if op == nil || op.Type != StateOperationTypeUpsert {
+			encOps[i] = op
+			continue
+		}
+
+		encItem, err := c.encryptItem(ctx, op.Item)
+		if err != nil {
+			return err
+		}
+		encOps[i] = &StateOperation{
+			Type: op.Type,
+			Item: encItem,
+		}
+	}
+
+	return c.Client.ExecuteStateTransaction(ctx, storeName, meta, encOps)
+}
+
+// GetState retrieves and decrypts state from specific store using default consistency option.
+func (c *stateEncryptionClient) GetState(ctx context.Context, storeName, key string, meta map[string]string) (*StateItem, error) {
+	return c.GetStateWithConsistency(ctx, storeName, key, meta, StateConsistencyStrong)
+}
+
+// GetStateWithConsistency retrieves and decrypts state from specific store using provided state consistency.
+func (c *stateEncryptionClient) GetStateWithConsistency(ctx context.Context, storeName, key string, meta map[string]string, sc StateConsistency) (*StateItem, error) {
+	item, err := c.Client.GetStateWithConsistency(ctx, storeName, key, meta, sc)
+	if err != nil || !c.encrypts(storeName) || item == nil || len(item.Value) == 0 {
+		return item, err
+	}
+
+	item.Value, err = c.decrypt(ctx, item.Value)
+	if err != nil {
+		return nil, fmt.Errorf("error decrypting state for key %s: %w", key, err)
+	}
+
+	return item, nil
+}
+
+// GetBulkState retrieves and decrypts state for multiple keys from specific store.
+// Items which fail to decrypt have their Value cleared and Error set.
+func (c *stateEncryptionClient) GetBulkState(ctx context.Context, storeName string, keys []string, meta map[string]string, parallelism int32) ([]*BulkStateItem, error) {
+	items, err := c.Client.GetBulkState(ctx, storeName, keys, meta, parallelism)
+	if err != nil || !c.encrypts(storeName) {
+		return items, err
+	}
+
+	for _, item := range items {
+		if item == nil || item.Error != "" || len(item.Value) == 0 {
+			continue
+		}
+		if item.Value, err = c.decrypt(ctx, item.Value); err != nil {
+			item.Error = fmt.Sprintf("error decrypting state: %v", err)
+		}
+	}
+
+	return items, nil
+}
+
+// QueryStateAlpha1 runs a query against state store and decrypts the results.
+// Results which fail to decrypt have their Value cleared and Error set.
+// Note that the state store can only filter and sort on the encrypted values.
+func (c *stateEncryptionClient) QueryStateAlpha1(ctx context.Context, storeName, query string, meta map[string]string) (*QueryResponse, error) {
+	resp, err := c.Client.QueryStateAlpha1(ctx, storeName, query, meta)
+	if err != nil || !c.encrypts(storeName) {
+		return resp, err
+	}
+
+	for i := range resp.Results {
+		res := &resp.Results[i]
+		if res.Error != "" || len(res.Value) == 0 {
+			continue
+		}
+		if res.Value, err = c.decrypt(ctx, res.Value); err != nil {
+			res.Error = fmt.Sprintf("error decrypting state: %v", err)
+		}
+	}
+
+	return resp, nil
+}
+
+func (c *stateEncryptionClient) encrypts(storeName string) bool {
+	if len(c.stores) == 0 {
+		return true
+	}
+	_, ok := c.stores[storeName]
+	return ok
+}
+
+func (c *stateEncryptionClient) encryptItem(ctx context.Context, item *SetStateItem) (*SetStateItem, error) {
+	if item == nil || len(item.Value) == 0 {
+		return item, nil
+	}
+
+	enc, err := c.encrypt(ctx, item.Value)
+	if err != nil {
+		return nil, fmt.Errorf("error encrypting state for key %s: %w", item.Key, err)
+	}
+
+	return &SetStateItem{
+		Key:      item.Key,
+		Value:    enc,
+		Etag:     item.Etag,
+		Metadata: item.Metadata,
+		Options:  item.Options,
+	}, nil
+}
+
+// keyName returns the key reference required to decrypt values encrypted by this client.
+func (c *stateEncryptionClient) keyName() string {
+	if c.opts.DecryptionKeyName != "" {
+		return c.opts.DecryptionKeyName
+	}
+	return c.opts.KeyName
+}
+
+// encrypt returns data encrypted in an envelope recording the key name.
+func (c *stateEncryptionClient) encrypt(ctx context.Context, data []byte) ([]byte, error) {
+	out, err := c.Client.Encrypt(ctx, bytes.NewReader(data), c.opts)
+	if err != nil {
+		return nil, err
+	}
+	keyName := c.keyName()
+	env := make([]byte, 0, len(stateEncryptionHeader)+binary.MaxVarintLen64+len(keyName))
+	env = append(env, stateEncryptionHeader...)
+	env = binary.AppendUvarint(env, uint64(len(keyName)))
+	env = append(env, keyName...)
+	buf := bytes.NewBuffer(env)
+	if _, err = buf.ReadFrom(out); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+func (c *stateEncryptionClient) decrypt(ctx context.Context, data []byte) ([]byte, error) {
+	opts := DecryptOptions{ComponentName: c.opts.ComponentName}
+	if rest, ok := bytes.CutPrefix(data, []byte(stateEncryptionHeader)); ok {
+		n, size := binary.Uvarint(rest)
+		if size <= 0 || n > uint64(len(rest)-size) {
+			return nil, errors.New("invalid encrypted state envelope")
+		}
+		opts.KeyName = string(rest[size : size+int(n)])
+		data = rest[size+int(n):]
+	} else if c.opts.OmitDecryptionKeyName {
+		// Without a reference embedded in the document, the key must be supplied.
+		opts.KeyName = c.keyName()
+	}
+
+	out, err := c.Client.Decrypt(ctx, bytes.NewReader(data), opts)
+	if err != nil {
+		return nil, err
+	}
+	return io.ReadAll(out)
+}
@@ -0,0 +1,211 @@
+/*
+Copyright 2026 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package client
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeCryptoClient wraps the test client with a reversible "encryption" which
+// embeds the key name, so ciphertext can be told apart from plaintext.
+type fakeCryptoClient struct {
+	Client
+	decryptKeys []string
+}
+
+func (c *fakeCryptoClient) Encrypt(ctx context.Context, in io.Reader, opts EncryptOptions) (io.Reader, error) {
+	data, err := io.ReadAll(in)
+	if err != nil {
+		return nil, err
+	}
+	return strings.NewReader("enc(" + opts.KeyName + "):" + string(data)), nil
+}
+
+func (c *fakeCryptoClient) Decrypt(ctx context.Context, in io.Reader, opts DecryptOptions) (io.Reader, error) {
+	data, err := io.ReadAll(in)
+	if err != nil {
+		return nil, err
+	}
+	c.decryptKeys = append(c.decryptKeys, opts.KeyName)
+	_, plain, ok := bytes.Cut(data, []byte("):"))
+	if !bytes.HasPrefix(data, []byte("enc(")) || !ok {
+		return nil, errors.New("not encrypted")
+	}
+	return bytes.NewReader(plain), nil
+}
+
+func TestNewStateEncryptionClient(t *testing.T) {
+	t.Run("nil client", func(t *testing.T) {
+		_, err := NewStateEncryptionClient(nil, StateEncryptionOptions{})
+		require.Error(t, err)
+	})
+
+	t.Run("missing options", func(t *testing.T) {
+		_, err := NewStateEncryptionClient(testClient, StateEncryptionOptions{
+			EncryptOptions: EncryptOptions{ComponentName: "crypto", KeyName: "key"},
+		})
+		require.ErrorContains(t, err, "Algorithm")
+	})
+}
+
+func TestStateEncryptionClient(t *testing.T) {
+	ctx := t.Context()
+	crypto := &fakeCryptoClient{Client: testClient}
+	c, err := NewStateEncryptionClient(crypto, StateEncryptionOptions{
+		EncryptOptions: EncryptOptions{
+			ComponentName:    "crypto",
+			KeyName:          "key1",
+			KeyWrapAlgorithm: "A256KW",
+		},
+		Stores: []string{testStore},
+	})
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		keys := []string{"enc-key1", "enc-key2", "enc-key3", "plain-key", "plain-key2"}
+		require.NoError(t, testClient.DeleteBulkState(context.Background(), testStore, keys, nil))
+	})
+
+	t.Run("save and get state", func(t *testing.T) {
+		require.NoError(t, c.SaveState(ctx, testStore, "enc-key1", []byte(testData), nil))
+
+		raw, err := testClient.GetState(ctx, testStore, "enc-key1", nil)
+		require.NoError(t, err)
+		assert.Equal(t, stateEncryptionHeader+"\x04key1enc(key1):"+testData, string(raw.Value))
+
+		item, err := c.GetState(ctx, testStore, "enc-key1", nil)
+		require.NoError(t, err)
+		assert.Equal(t, testData, string(item.Value))
+	})
+
+	t.Run("save bulk state does not modify items", func(t *testing.T) {
+		item := &SetStateItem{Key: "enc-key2", Value: []byte(testData)}
+		require.NoError(t, c.SaveBulkState(ctx, testStore, item))
+		assert.Equal(t, testData, string(item.Value))
+		assert.Nil(t, item.Metadata)
+
+		items, err := c.GetBulkState(ctx, testStore, []string{"enc-key2"}, nil, 1)
+		require.NoError(t, err)
+		require.Len(t, items, 1)
+		assert.Empty(t, items[0].Error)
+		assert.Equal(t, testData, string(items[0].Value))
+	})
+
+	t.Run("execute state transaction", func(t *testing.T) {
+		ops := []*StateOperation{
+			{Type: StateOperationTypeUpsert, Item: &SetStateItem{Key: "enc-key3", Value: []byte(testData)}},
+			{Type: StateOperationTypeDelete, Item: &SetStateItem{Key: "enc-key1"}},
+		}
+		require.NoError(t, c.ExecuteStateTransaction(ctx, testStore, nil, ops))
+
+		raw, err := testClient.GetState(ctx, testStore, "enc-key3", nil)
+		require.NoError(t, err)
+		assert.Equal(t, stateEncryptionHeader+"\x04key1enc(key1):"+testData, string(raw.Value))
+
+		item, err := c.GetState(ctx, testStore, "enc-key1", nil)
+		require.NoError(t, err)
+		assert.Empty(t, item.Value)
+	})
+
+	t.Run("unencrypted bulk item reports error", func(t *testing.T) {
+		require.NoError(t, testClient.SaveState(ctx, testStore, "plain-key", []byte(testData), nil))
+
+		items, err := c.GetBulkState(ctx, testStore, []string{"plain-key"}, nil, 1)
+		require.NoError(t, err)
+		require.Len(t, items, 1)
+		assert.NotEmpty(t, items[0].Error)
+		assert.Nil(t, items[0].Value)
+
+		_, err = c.GetState(ctx, testStore, "plain-key", nil)
+		require.Error(t, err)
+	})
+
+	t.Run("other stores are not encrypted", func(t *testing.T) {
+		require.NoError(t, c.SaveState(ctx, "other", "plain-key2", []byte(testData), nil))
+
+		raw, err := testClient.GetState(ctx, "other", "plain-key2", nil)
+		require.NoError(t, err)
+		assert.Equal(t, testData, string(raw.Value))
+	})
+}
+
+func TestStateEncryptionClientKeyRotation(t *testing.T) {
+	ctx := t.Context()
+	t.Cleanup(func() {
+		require.NoError(t, testClient.DeleteState(context.Background(), testStore, "rotated-key", nil))
+	})
+	newClient := func(crypto Client, keyName string) Client {
+		c, err := NewStateEncryptionClient(crypto, StateEncryptionOptions{
+			EncryptOptions: EncryptOptions{
+				ComponentName:         "crypto",
+				KeyName:               keyName,
+				KeyWrapAlgorithm:      "A256KW",
+				OmitDecryptionKeyName: true,
+			},
+		})
+		require.NoError(t, err)
+		return c
+	}
+
+	// The test server drops request metadata, as state stores do.
+	require.NoError(t, newClient(&fakeCryptoClient{Client: testClient}, "key1").SaveState(ctx, testStore, "rotated-key", []byte(testData), nil))
+
+	crypto := &fakeCryptoClient{Client: testClient}
+	item, err := newClient(crypto, "key2").GetState(ctx, testStore, "rotated-key", nil)
+	require.NoError(t, err)
+	assert.Equal(t, testData, string(item.Value))
+	assert.Equal(t, []string{"key1"}, crypto.decryptKeys)
+}
+
+func TestStateEncryptionClientDecryptKey(t *testing.T) {
+	ctx := t.Context()
+	data := []byte("enc(key):" + testData)
+
+	t.Run("key from envelope", func(t *testing.T) {
+		crypto := &fakeCryptoClient{}
+		c := &stateEncryptionClient{Client: crypto, opts: EncryptOptions{KeyName: "key2", OmitDecryptionKeyName: true}}
+		_, err := c.decrypt(ctx, append([]byte(stateEncryptionHeader+"\x07key1/v1"), data...))
+		require.NoError(t, err)
+		assert.Equal(t, []string{"key1/v1"}, crypto.decryptKeys)
+	})
+
+	t.Run("truncated envelope", func(t *testing.T) {
+		c := &stateEncryptionClient{Client: &fakeCryptoClient{}, opts: EncryptOptions{KeyName: "key2"}}
+		_, err := c.decrypt(ctx, []byte(stateEncryptionHeader+"\x07key"))
+		require.Error(t, err)
+	})
+
+	t.Run("key embedded in document without envelope", func(t *testing.T) {
+		crypto := &fakeCryptoClient{}
+		c := &stateEncryptionClient{Client: crypto, opts: EncryptOptions{KeyName: "key2"}}
+		_, err := c.decrypt(ctx, data)
+		require.NoError(t, err)
+		assert.Equal(t, []string{""}, crypto.decryptKeys)
+	})
+
+	t.Run("key omitted from document without envelope", func(t *testing.T) {
+		crypto := &fakeCryptoClient{}
+		c := &stateEncryptionClient{Client: crypto, opts: EncryptOptions{KeyName: "key2", OmitDecryptionKeyName: true}}
+		_, err := c.decrypt(ctx, data)
+		require.NoError(t, err)
+		assert.Equal(t, []string{"key2"}, crypto.decryptKeys)
+	})
+}
@@ -15,15 +15,16 @@ package client
 
 import (
 	"context"
-	"encoding/json"
 	"errors"
 	"fmt"
-	"mime"
-	"strings"
+	"hash/fnv"
 	"sync"
 	"sync/atomic"
+	"time"
 
+	"github.com/cenkalti/backoff/v4"
 	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
 	"google.golang.org/grpc/status"
 
 	pb "github.com/dapr/dapr/pkg/proto/runtime/v1"
@@ -32,11 +33,34 @@ import (
 
 type SubscriptionHandleFunction func(event *common.TopicEvent) common.SubscriptionResponseStatus
 
+// SubscriptionContextHandleFunction handles an event received by SubscribeWithContextHandler.
+// ctx is canceled when the subscription context is done, and propagates the
+// trace context of the event to outgoing Dapr calls.
+type SubscriptionContextHandleFunction func(ctx context.Context, event *common.TopicEvent) common.SubscriptionResponseStatus
+
 type SubscriptionOptions struct {
 	PubsubName      string
 	Topic           string
 	DeadLetterTopic *string
 	Metadata        map[string]string
+
+	// MaxInFlight limits the number of messages handled concurrently by
+	// SubscribeWithHandler. While the limit is reached, at most one further
+	// message is received from the stream. Zero means no limit.
+	MaxInFlight int
+	// OrderingKey returns the key of a message for SubscribeWithHandler.
+	// Messages with the same non-empty key are handled one at a time in the
+	// order they were received. Requires MaxInFlight, which is the number of
+	// keys handled concurrently.
+	OrderingKey func(event *common.TopicEvent) string
+	// DrainTimeout is the maximum time SubscribeWithHandler waits for
+	// in-flight messages to be handled and acknowledged when the subscription
+	// is closed or its context is done. Zero waits until all are acknowledged.
+	DrainTimeout time.Duration
+	// MaxDecompressedSize enables the decompression of events compressed by
+	// NewCompressionClient, up to this number of bytes. Events exceeding it
+	// are dropped. Zero passes compressed events through as received.
+	MaxDecompressedSize int64
 }
 
 type Subscription struct {
@@ -49,8 +73,34 @@ type Subscription struct {
 
 	createStream func(ctx context.Context, opts SubscriptionOptions) (pb.Dapr_SubscribeTopicEventsAlpha1Client, error)
 	opts         SubscriptionOptions
+
+	healthLock sync.Mutex
+	connected  bool
+	reconnects int
+	lastErr    error
 }
 
+// SubscriptionHealth is the state of the stream of a subscription.
+type SubscriptionHealth struct {
+	PubsubName string
+	Topic      string
+	// Connected is true while the stream is established.
+	Connected bool
+	// Reconnects is the number of times the stream was re-established.
+	Reconnects int
+	// LastError is the last error received from the stream, if any.
+	LastError error
+}
+
+var (
+	// subscriptionReconnectInterval is the initial interval between attempts
+	// to re-establish a broken subscription stream.
+	subscriptionReconnectInterval = 500 * time.Millisecond
+	// subscriptionMaxReconnectInterval is the maximum interval between attempts
+	// to re-establish a broken subscription stream.
+	subscriptionMaxReconnectInterval = 30 * time.Second
+)
+
 type SubscriptionMessage struct {
 	*common.TopicEvent
 	sub *Subscription
@@ -67,45 +117,283 @@ func (c *GRPCClient) Subscribe(ctx context.Context, opts SubscriptionOptions) (*
 		stream:       stream,
 		createStream: c.subscribeInitialRequest,
 		opts:         opts,
+		connected:    true,
 	}
 
 	return s, nil
 }
 
+// Health returns the state of the subscription stream.
+func (s *Subscription) Health() SubscriptionHealth {
+	s.healthLock.Lock()
+	defer s.healthLock.Unlock()
+	return SubscriptionHealth{
+		PubsubName: s.opts.PubsubName,
+		Topic:      s.opts.Topic,
+		Connected:  s.connected && !s.closed.Load(),
+		Reconnects: s.reconnects,
+		LastError:  s.lastErr,
+	}
+}
+
+func (s *Subscription) setHealth(connected bool, err error) {
+	s.healthLock.Lock()
+	defer s.healthLock.Unlock()
+	if connected && !s.connected {
+		s.reconnects++
+	}
+	s.connected = connected
+	if err != nil {
+		s.lastErr = err
+	}
+}
+
+// SubscribeWithHandler subscribes to a pubsub topic and calls handler for
+// every message. See SubscribeWithContextHandler.
 func (c *GRPCClient) SubscribeWithHandler(ctx context.Context, opts SubscriptionOptions, handler SubscriptionHandleFunction) (func() error, error) {
-	s, err := c.Subscribe(ctx, opts)
+	return c.SubscribeWithContextHandler(ctx, opts, func(_ context.Context, event *common.TopicEvent) common.SubscriptionResponseStatus {
+		return handler(event)
+	})
+}
+
+// SubscribeWithContextHandler subscribes to a pubsub topic and calls handler
+// for every message, as limited by the MaxInFlight and OrderingKey options.
+// When the returned function is called or ctx is done, no further messages are
+// handled and the stream is closed once in-flight messages are acknowledged.
+func (c *GRPCClient) SubscribeWithContextHandler(ctx context.Context, opts SubscriptionOptions, handler SubscriptionContextHandleFunction) (func() error, error) {
+	d, err := c.startDispatcher(ctx, opts, handler)
 	if err != nil {
 		return nil, err
 	}
+	return d.close, nil
+}
 
-	go func() {
-		defer s.Close()
+// startDispatcher subscribes to a pubsub topic and dispatches its messages to
+// handler until the dispatcher is closed or ctx is done.
+func (c *GRPCClient) startDispatcher(ctx context.Context, opts SubscriptionOptions, handler SubscriptionContextHandleFunction) (*subscriptionDispatcher, error) {
+	if opts.OrderingKey != nil && opts.MaxInFlight <= 0 {
+		return nil, errors.New("MaxInFlight is required with OrderingKey")
+	}
 
-		for {
-			msg, err := s.Receive()
-			if err != nil {
-				if !s.closed.Load() {
-					logger.Printf("Error receiving messages from subscription pubsub=%s topic=%s, closing subscription: %s",
-						opts.PubsubName, opts.Topic, err)
-				}
-				return
-			}
+	// The stream outlives ctx so in-flight messages can be acknowledged while draining.
+	streamCtx, cancelStream := context.WithCancel(context.WithoutCancel(ctx))
+	s, err := c.Subscribe(streamCtx, opts)
+	if err != nil {
+		cancelStream()
+		return nil, err
+	}
 
-			go func() {
-				if err := msg.respondStatus(handler(msg.TopicEvent)); err != nil {
-					logger.Printf("Error responding to topic with event status pubsub=%s topic=%s message_id=%s: %s",
-						opts.PubsubName, opts.Topic, msg.ID, err)
-				}
-			}()
+	d := newSubscriptionDispatcher(ctx, s, opts, handler, cancelStream)
+	go d.run()
+	d.stopAfter = context.AfterFunc(ctx, func() {
+		if err := d.shutdown(); err != nil {
+			logger.Printf("Error closing subscription pubsub=%s topic=%s: %s", opts.PubsubName, opts.Topic, err)
+		}
+	})
+
+	return d, nil
+}
+
+// subscriptionDispatcher hands messages received from a subscription to the
+// handler, with at most maxInFlight messages being handled at once.
+type subscriptionDispatcher struct {
+	ctx          context.Context
+	sub          *Subscription
+	opts         SubscriptionOptions
+	handler      SubscriptionContextHandleFunction
+	cancelStream context.CancelFunc
+	stopAfter    func() bool
+	done         chan struct{}
+
+	// partitions are read by the workers. Without an ordering key, all workers
+	// read the same partition.
+	partitions []chan *SubscriptionMessage
+	next       atomic.Uint32
+
+	lock     sync.Mutex
+	draining bool
+	stop     chan struct{}
+	inFlight sync.WaitGroup
+
+	closeOnce sync.Once
+	closeErr  error
+}
+
+func newSubscriptionDispatcher(ctx context.Context, s *Subscription, opts SubscriptionOptions, handler SubscriptionContextHandleFunction, cancelStream context.CancelFunc) *subscriptionDispatcher {
+	d := &subscriptionDispatcher{
+		ctx:          ctx,
+		sub:          s,
+		opts:         opts,
+		handler:      handler,
+		cancelStream: cancelStream,
+		stop:         make(chan struct{}),
+		done:         make(chan struct{}),
+	}
+
+	switch {
+	case opts.MaxInFlight <= 0:
+	case opts.OrderingKey != nil:
+		d.partitions = make([]chan *SubscriptionMessage, opts.MaxInFlight)
+		for i := range d.partitions {
+			d.partitions[i] = make(chan *SubscriptionMessage)
+			go d.work(d.partitions[i])
+		}
+	default:
+		d.partitions = []chan *SubscriptionMessage{make(chan *SubscriptionMessage)}
+		for range opts.MaxInFlight {
+			go d.work(d.partitions[0])
+		}
+	}
+
+	return d
+}
+
+func (d *subscriptionDispatcher) run() {
+	defer close(d.done)
+	defer func() {
+		if err := d.shutdown(); err != nil && !errors.Is(err, errSubscriptionClosed) {
+			logger.Printf("Error closing subscription pubsub=%s topic=%s: %s", d.opts.PubsubName, d.opts.Topic, err)
 		}
 	}()
 
-	return s.Close, nil
+	for {
+		msg, err := d.sub.Receive()
+		if err != nil {
+			if !d.sub.closed.Load() && !d.isDraining() {
+				logger.Printf("Error receiving messages from subscription pubsub=%s topic=%s, closing subscription: %s",
+					d.opts.PubsubName, d.opts.Topic, err)
+			}
+			return
+		}
+
+		// Messages which are not dispatched are not acknowledged, and are
+		// redelivered by the sidecar.
+		if !d.dispatch(msg) {
+			return
+		}
+	}
 }
 
+// dispatch hands msg to a worker, blocking while all workers are busy.
+// It returns false if the dispatcher is draining.
+func (d *subscriptionDispatcher) dispatch(msg *SubscriptionMessage) bool {
+	d.lock.Lock()
+	if d.draining {
+		d.lock.Unlock()
+		return false
+	}
+	d.inFlight.Add(1)
+	d.lock.Unlock()
+
+	if d.partitions == nil {
+		go d.handle(msg)
+		return true
+	}
+
+	select {
+	case d.partitions[d.partition(msg)] <- msg:
+		return true
+	case <-d.stop:
+		d.inFlight.Done()
+		return false
+	}
+}
+
+func (d *subscriptionDispatcher) partition(msg *SubscriptionMessage) int {
+	if len(d.partitions) == 1 {
+		return 0
+	}
+	key := d.opts.OrderingKey(msg.TopicEvent)
+	if key == "" {
+		return int(d.next.Add(1) % uint32(len(d.partitions)))
+	}
+	h := fnv.New32a()
+	h.Write([]byte(key))
+	return int(h.Sum32() % uint32(len(d.partitions)))
+}
+
+func (d *subscriptionDispatcher) work(messages <-chan *SubscriptionMessage) {
+	for {
+		select {
+		case msg := <-messages:
+			d.handle(msg)
+		case <-d.stop:
+			return
+		}
+	}
+}
+
+func (d *subscriptionDispatcher) handle(msg *SubscriptionMessage) {
+	defer d.inFlight.Done()
+	if err := msg.respondStatus(d.handler(eventContext(d.ctx, msg.TopicEvent), msg.TopicEvent)); err != nil {
+		logger.Printf("Error responding to topic with event status pubsub=%s topic=%s message_id=%s: %s",
+			d.opts.PubsubName, d.opts.Topic, msg.ID, err)
+	}
+}
+
+// eventContext returns ctx with the trace context of event added to its
+// outgoing metadata.
+func eventContext(ctx context.Context, event *common.TopicEvent) context.Context {
+	if event.TraceParent == "" {
+		return ctx
+	}
+	kv := []string{traceparentKey, event.TraceParent}
+	if event.TraceState != "" {
+		kv = append(kv, tracestateKey, event.TraceState)
+	}
+	return metadata.AppendToOutgoingContext(ctx, kv...)
+}
+
+func (d *subscriptionDispatcher) isDraining() bool {
+	d.lock.Lock()
+	defer d.lock.Unlock()
+	return d.draining
+}
+
+// close stops the dispatcher, as when its context is done.
+func (d *subscriptionDispatcher) close() error {
+	d.stopAfter()
+	return d.shutdown()
+}
+
+// shutdown stops dispatching messages, waits up to DrainTimeout for in-flight
+// messages to be acknowledged and closes the subscription.
+func (d *subscriptionDispatcher) shutdown() error {
+	d.closeOnce.Do(func() {
+		d.lock.Lock()
+		d.draining = true
+		close(d.stop)
+		d.lock.Unlock()
+
+		drained := make(chan struct{})
+		go func() {
+			d.inFlight.Wait()
+			close(drained)
+		}()
+
+		var timeout <-chan time.Time
+		if d.opts.DrainTimeout > 0 {
+			t := time.NewTimer(d.opts.DrainTimeout)
+			defer t.Stop()
+			timeout = t.C
+		}
+		select {
+		case <-drained:
+		case <-timeout:
+			d.closeErr = errors.New("timed out waiting for in-flight messages to be acknowledged")
+		}
+
+		d.closeErr = errors.Join(d.closeErr, d.sub.Close())
+		d.cancelStream()
+	})
+	return d.closeErr
+}
+
+var errSubscriptionClosed = errors.New("subscription already closed")
+
 func (s *Subscription) Close() error {
 	if !s.closed.CompareAndSwap(false, true) {
-		return errors.New("subscription already closed")
+		return errSubscriptionClosed
 	}
 
 	return s.stream.CloseSend()
@@ -132,23 +420,10 @@ func (s *Subscription) Receive() (*SubscriptionMessage, error) {
 			case codes.Unavailable, codes.Unknown:
 				logger.Printf("gRPC error while reading from stream: %s (code=%v)",
 					st.Message(), st.Code())
-				// close the current stream and reconnect
-				if s.closed.Load() {
-					return nil, errors.New("subscription is permanently closed; cannot reconnect")
-				}
-				if err := s.closeStreamOnly(); err != nil {
-					logger.Printf("error closing current stream: %v", err)
-				}
-
-				newStream, nerr := s.createStream(s.ctx, s.opts)
-				if nerr != nil {
-					return nil, errors.New("re-subscribe failed")
+				s.setHealth(false, err)
+				if err := s.reconnect(); err != nil {
+					return nil, err
 				}
-
-				s.lock.Lock()
-				s.stream = newStream
-				s.lock.Unlock()
-
 				// try receiving again
 				continue
 
@@ -161,40 +436,16 @@ func (s *Subscription) Receive() (*SubscriptionMessage, error) {
 		}
 
 		event := resp.GetEventMessage()
-		data := any(event.GetData())
-		if len(event.GetData()) > 0 {
-			mediaType, _, err := mime.ParseMediaType(event.GetDataContentType())
-			if err == nil {
-				var v interface{}
-				switch mediaType {
-				case "application/json":
-					if err := json.Unmarshal(event.GetData(), &v); err == nil {
-						data = v
-					}
-				case "text/plain":
-					// Assume UTF-8 encoded string.
-					data = string(event.GetData())
-				default:
-					if strings.HasPrefix(mediaType, "application/") &&
-						strings.HasSuffix(mediaType, "+json") {
-						if err := json.Unmarshal(event.GetData(), &v); err == nil {
-							data = v
-						}
-					}
-				}
+		topicEvent, err := common.NewTopicEvent(event, nil, s.opts.MaxDecompressedSize)
+		if err != nil {
+			logger.Printf("Error decoding event, dropping message pubsub=%s topic=%s message_id=%s: %s",
+				event.GetPubsubName(), event.GetTopic(), event.GetId(), err)
+			msg := &SubscriptionMessage{sub: s, TopicEvent: &common.TopicEvent{ID: event.GetId()}}
+			if err = msg.Drop(); err != nil {
+				logger.Printf("Error dropping message pubsub=%s topic=%s message_id=%s: %s",
+					event.GetPubsubName(), event.GetTopic(), event.GetId(), err)
 			}
-		}
-
-		topicEvent := &common.TopicEvent{
-			ID:              event.GetId(),
-			Source:          event.GetSource(),
-			Type:            event.GetType(),
-			SpecVersion:     event.GetSpecVersion(),
-			DataContentType: event.GetDataContentType(),
-			Data:            data,
-			RawData:         event.GetData(),
-			Topic:           event.GetTopic(),
-			PubsubName:      event.GetPubsubName(),
+			continue
 		}
 
 		return &SubscriptionMessage{
@@ -288,6 +539,48 @@ func (c *GRPCClient) subscribeInitialRequest(ctx context.Context, opts Subscript
 	return stream, nil
 }
 
+// reconnect closes the current stream and re-subscribes with exponential
+// backoff and jitter until a new stream is established, the subscription is
+// closed or its context is done.
+func (s *Subscription) reconnect() error {
+	if s.closed.Load() {
+		return errors.New("subscription is permanently closed; cannot reconnect")
+	}
+	if err := s.closeStreamOnly(); err != nil {
+		logger.Printf("error closing current stream: %v", err)
+	}
+
+	bo := backoff.NewExponentialBackOff()
+	bo.InitialInterval = subscriptionReconnectInterval
+	bo.MaxInterval = subscriptionMaxReconnectInterval
+	bo.MaxElapsedTime = 0
+	bo.Reset()
+
+	err := backoff.RetryNotify(func() error {
+		if s.closed.Load() {
+			return backoff.Permanent(errors.New("subscription is permanently closed; cannot reconnect"))
+		}
+		stream, err := s.createStream(s.ctx, s.opts)
+		if err != nil {
+			return err
+		}
+		s.lock.Lock()
+		s.stream = stream
+		s.lock.Unlock()
+		return nil
+	}, backoff.WithContext(bo, s.ctx), func(err error, next time.Duration) {
+		s.setHealth(false, err)
+		logger.Printf("error re-subscribing pubsub=%s topic=%s, retrying in %s: %v",
+			s.opts.PubsubName, s.opts.Topic, next, err)
+	})
+	if err != nil {
+		return fmt.Errorf("re-subscribe failed: %w", err)
+	}
+
+	s.setHealth(true, nil)
+	return nil
+}
+
 func (s *Subscription) closeStreamOnly() error {
 	s.lock.Lock()
 	defer s.lock.Unlock()
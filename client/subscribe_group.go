@@ -0,0 +1,102 @@
+/*
+Copyright 2026 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+)
+
+// GroupSubscription is a topic subscription of a SubscriptionGroup.
+type GroupSubscription struct {
+	Options SubscriptionOptions
+	Handler SubscriptionContextHandleFunction
+}
+
+// SubscriptionGroup manages the streaming subscriptions of several topics
+// under a single lifecycle.
+type SubscriptionGroup struct {
+	dispatchers []*subscriptionDispatcher
+}
+
+// SubscribeGroup subscribes to every topic of subs, calling its handler for
+// every message as SubscribeWithContextHandler does. Broken streams are
+// re-established with exponential backoff and jitter.
+// If any subscription fails, the ones already established are closed and the error is returned.
+// The group is closed when ctx is done or Close is called.
+func (c *GRPCClient) SubscribeGroup(ctx context.Context, subs ...GroupSubscription) (*SubscriptionGroup, error) {
+	if len(subs) == 0 {
+		return nil, errors.New("at least one subscription is required")
+	}
+
+	g := &SubscriptionGroup{dispatchers: make([]*subscriptionDispatcher, 0, len(subs))}
+	for _, sub := range subs {
+		if sub.Handler == nil {
+			return nil, errors.Join(
+				fmt.Errorf("handler required for pubsub=%s topic=%s", sub.Options.PubsubName, sub.Options.Topic),
+				g.Close())
+		}
+		d, err := c.startDispatcher(ctx, sub.Options, sub.Handler)
+		if err != nil {
+			return nil, errors.Join(
+				fmt.Errorf("error subscribing to pubsub=%s topic=%s: %w", sub.Options.PubsubName, sub.Options.Topic, err),
+				g.Close())
+		}
+		g.dispatchers = append(g.dispatchers, d)
+	}
+
+	return g, nil
+}
+
+// Health returns the state of every subscription of the group, in the order
+// they were given to SubscribeGroup.
+func (g *SubscriptionGroup) Health() []SubscriptionHealth {
+	health := make([]SubscriptionHealth, len(g.dispatchers))
+	for i, d := range g.dispatchers {
+		health[i] = d.sub.Health()
+	}
+	return health
+}
+
+// Done returns a channel which is closed once every subscription of the group has stopped.
+func (g *SubscriptionGroup) Done() <-chan struct{} {
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for _, d := range g.dispatchers {
+			<-d.done
+		}
+	}()
+	return done
+}
+
+// Close closes every subscription of the group concurrently, waiting for
+// in-flight messages to be acknowledged.
+func (g *SubscriptionGroup) Close() error {
+	errs := make([]error, len(g.dispatchers))
+	done := make(chan struct{})
+	for i, d := range g.dispatchers {
+		go func() {
+			defer func() { done <- struct{}{} }()
+			if err := d.close(); err != nil {
+				errs[i] = fmt.Errorf("error closing subscription pubsub=%s topic=%s: %w", d.opts.PubsubName, d.opts.Topic, err)
+			}
+		}()
+	}
+	for range g.dispatchers {
+		<-done
+	}
+	return errors.Join(errs...)
+}
@@ -0,0 +1,112 @@
+/*
+Copyright 2026 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package client
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	pb "github.com/dapr/dapr/pkg/proto/runtime/v1"
+	"github.com/dapr/go-sdk/service/common"
+)
+
+func TestSubscribeGroup(t *testing.T) {
+	interval := subscriptionReconnectInterval
+	subscriptionReconnectInterval = 10 * time.Millisecond
+	t.Cleanup(func() { subscriptionReconnectInterval = interval })
+
+	recv := make(chan *common.TopicEvent, 10)
+	handler := func(_ context.Context, e *common.TopicEvent) common.SubscriptionResponseStatus {
+		recv <- e
+		return common.SubscriptionResponseStatusSuccess
+	}
+
+	t.Run("no subscriptions", func(t *testing.T) {
+		c := &GRPCClient{protoClient: &fakeSubscribeClient{}}
+		_, err := c.SubscribeGroup(t.Context())
+		require.Error(t, err)
+	})
+
+	t.Run("closes established subscriptions on error", func(t *testing.T) {
+		fake := &fakeSubscribeClient{streams: make(chan *fakeTopicStream, 2)}
+		c := &GRPCClient{protoClient: fake}
+		_, err := c.SubscribeGroup(t.Context(),
+			GroupSubscription{Options: SubscriptionOptions{PubsubName: "messages", Topic: "orders"}, Handler: handler},
+			GroupSubscription{Options: SubscriptionOptions{PubsubName: "messages"}, Handler: handler},
+		)
+		require.Error(t, err)
+		stream := <-fake.streams
+		assert.True(t, stream.isClosed())
+	})
+
+	t.Run("multiplexes topics", func(t *testing.T) {
+		fake := &fakeSubscribeClient{streams: make(chan *fakeTopicStream, 3)}
+		c := &GRPCClient{protoClient: fake}
+		g, err := c.SubscribeGroup(t.Context(),
+			GroupSubscription{Options: SubscriptionOptions{PubsubName: "messages", Topic: "orders"}, Handler: handler},
+			GroupSubscription{Options: SubscriptionOptions{PubsubName: "messages", Topic: "payments", MaxInFlight: 2}, Handler: handler},
+		)
+		require.NoError(t, err)
+
+		streams := map[string]*fakeTopicStream{}
+		for range 2 {
+			s := <-fake.streams
+			streams[s.topic] = s
+		}
+		require.Len(t, streams, 2)
+
+		streams["orders"].send(t, &pb.TopicEventRequest{Id: "o1", Topic: "orders", PubsubName: "messages"})
+		streams["payments"].send(t, &pb.TopicEventRequest{Id: "p1", Topic: "payments", PubsubName: "messages"})
+		got := map[string]string{}
+		for range 2 {
+			e := <-recv
+			got[e.Topic] = e.ID
+		}
+		assert.Equal(t, map[string]string{"orders": "o1", "payments": "p1"}, got)
+
+		health := g.Health()
+		require.Len(t, health, 2)
+		assert.Equal(t, "orders", health[0].Topic)
+		assert.Equal(t, "payments", health[1].Topic)
+		assert.True(t, health[0].Connected)
+		assert.True(t, health[1].Connected)
+
+		// A broken stream is re-established without affecting the others.
+		streams["payments"].errs <- status.Error(codes.Unavailable, "connection reset")
+		next := <-fake.streams
+		next.send(t, &pb.TopicEventRequest{Id: "p2", Topic: "payments", PubsubName: "messages"})
+		assert.Equal(t, "p2", (<-recv).ID)
+		health = g.Health()
+		assert.Equal(t, 0, health[0].Reconnects)
+		assert.Equal(t, 1, health[1].Reconnects)
+
+		require.NoError(t, g.Close())
+		select {
+		case <-g.Done():
+		case <-time.After(5 * time.Second):
+			t.Fatal("subscriptions not stopped")
+		}
+		assert.True(t, streams["orders"].isClosed())
+		assert.True(t, next.isClosed())
+		for _, h := range g.Health() {
+			assert.False(t, h.Connected)
+		}
+	})
+}
@@ -0,0 +1,453 @@
+/*
+Copyright 2026 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package client
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/structpb"
+
+	pb "github.com/dapr/dapr/pkg/proto/runtime/v1"
+	"github.com/dapr/go-sdk/service/common"
+)
+
+// fakeTopicStream is a pb.Dapr_SubscribeTopicEventsAlpha1Client which serves
+// the events written to its events channel and records acknowledgements.
+type fakeTopicStream struct {
+	grpc.ClientStream
+
+	ctx     context.Context
+	initial bool
+	events  chan *pb.TopicEventRequest
+	errs    chan error
+	topic   string
+
+	lock       sync.Mutex
+	acks       []*pb.SubscribeTopicEventsRequestProcessedAlpha1
+	closed     chan struct{}
+	closeOnce  sync.Once
+	ackedAfter bool
+}
+
+func newFakeTopicStream(ctx context.Context) *fakeTopicStream {
+	return &fakeTopicStream{
+		ctx:    ctx,
+		events: make(chan *pb.TopicEventRequest),
+		errs:   make(chan error, 1),
+		closed: make(chan struct{}),
+	}
+}
+
+func (f *fakeTopicStream) Send(req *pb.SubscribeTopicEventsRequestAlpha1) error {
+	if initial := req.GetInitialRequest(); initial != nil {
+		f.topic = initial.GetTopic()
+	}
+	if processed := req.GetEventProcessed(); processed != nil {
+		f.lock.Lock()
+		defer f.lock.Unlock()
+		select {
+		case <-f.closed:
+			f.ackedAfter = true
+			return io.EOF
+		default:
+		}
+		f.acks = append(f.acks, processed)
+	}
+	return nil
+}
+
+func (f *fakeTopicStream) Recv() (*pb.SubscribeTopicEventsResponseAlpha1, error) {
+	if !f.initial {
+		f.initial = true
+		return &pb.SubscribeTopicEventsResponseAlpha1{
+			SubscribeTopicEventsResponseType: &pb.SubscribeTopicEventsResponseAlpha1_InitialResponse{
+				InitialResponse: &pb.SubscribeTopicEventsResponseInitialAlpha1{},
+			},
+		}, nil
+	}
+
+	select {
+	case event := <-f.events:
+		return &pb.SubscribeTopicEventsResponseAlpha1{
+			SubscribeTopicEventsResponseType: &pb.SubscribeTopicEventsResponseAlpha1_EventMessage{
+				EventMessage: event,
+			},
+		}, nil
+	case err := <-f.errs:
+		return nil, err
+	case <-f.closed:
+		return nil, io.EOF
+	case <-f.ctx.Done():
+		return nil, status.Error(codes.Canceled, f.ctx.Err().Error())
+	}
+}
+
+func (f *fakeTopicStream) CloseSend() error {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	f.closeOnce.Do(func() { close(f.closed) })
+	return nil
+}
+
+func (f *fakeTopicStream) isClosed() bool {
+	select {
+	case <-f.closed:
+		return true
+	default:
+		return false
+	}
+}
+
+func (f *fakeTopicStream) acked() []*pb.SubscribeTopicEventsRequestProcessedAlpha1 {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	return append([]*pb.SubscribeTopicEventsRequestProcessedAlpha1{}, f.acks...)
+}
+
+// publish sends a text event to the subscriber, failing the test if it is not received.
+func (f *fakeTopicStream) publish(t *testing.T, id, data string) {
+	t.Helper()
+	f.send(t, &pb.TopicEventRequest{
+		Id:              id,
+		Topic:           "test",
+		PubsubName:      "messages",
+		DataContentType: "text/plain",
+		Data:            []byte(data),
+	})
+}
+
+// send sends an event to the subscriber, failing the test if it is not received.
+func (f *fakeTopicStream) send(t *testing.T, event *pb.TopicEventRequest) {
+	t.Helper()
+	select {
+	case f.events <- event:
+	case <-time.After(5 * time.Second):
+		t.Fatalf("event %s not received", event.GetId())
+	}
+}
+
+// fakeSubscribeClient serves fakeTopicStreams to SubscribeTopicEventsAlpha1.
+type fakeSubscribeClient struct {
+	pb.DaprClient
+
+	streams chan *fakeTopicStream
+	// failures is the number of subscribe calls which fail before streams are served.
+	failures atomic.Int32
+}
+
+func (f *fakeSubscribeClient) SubscribeTopicEventsAlpha1(ctx context.Context, opts ...grpc.CallOption) (pb.Dapr_SubscribeTopicEventsAlpha1Client, error) {
+	if f.failures.Add(-1) >= 0 {
+		return nil, status.Error(codes.Unavailable, "sidecar unavailable")
+	}
+	stream := newFakeTopicStream(ctx)
+	f.streams <- stream
+	return stream, nil
+}
+
+// newFakeSubscription starts SubscribeWithHandler against a fake stream.
+func newFakeSubscription(t *testing.T, ctx context.Context, opts SubscriptionOptions, handler SubscriptionHandleFunction) (*fakeTopicStream, func() error) {
+	t.Helper()
+	return newFakeContextSubscription(t, ctx, opts, func(_ context.Context, e *common.TopicEvent) common.SubscriptionResponseStatus {
+		return handler(e)
+	})
+}
+
+// newFakeContextSubscription starts SubscribeWithContextHandler against a fake stream.
+func newFakeContextSubscription(t *testing.T, ctx context.Context, opts SubscriptionOptions, handler SubscriptionContextHandleFunction) (*fakeTopicStream, func() error) {
+	t.Helper()
+	fake := &fakeSubscribeClient{streams: make(chan *fakeTopicStream, 1)}
+	c := &GRPCClient{protoClient: fake}
+
+	opts.PubsubName, opts.Topic = "messages", "test"
+	closeFn, err := c.SubscribeWithContextHandler(ctx, opts, handler)
+	require.NoError(t, err)
+	return <-fake.streams, closeFn
+}
+
+func TestSubscribeWithHandlerOptions(t *testing.T) {
+	c := &GRPCClient{protoClient: &fakeSubscribeClient{}}
+	_, err := c.SubscribeWithHandler(t.Context(), SubscriptionOptions{
+		PubsubName:  "messages",
+		Topic:       "test",
+		OrderingKey: func(*common.TopicEvent) string { return "" },
+	}, nil)
+	require.Error(t, err)
+}
+
+func TestSubscribeWithHandlerMaxInFlight(t *testing.T) {
+	var current, peak atomic.Int32
+	release := make(chan struct{})
+	handler := func(e *common.TopicEvent) common.SubscriptionResponseStatus {
+		n := current.Add(1)
+		for {
+			p := peak.Load()
+			if n <= p || peak.CompareAndSwap(p, n) {
+				break
+			}
+		}
+		<-release
+		current.Add(-1)
+		return common.SubscriptionResponseStatusSuccess
+	}
+
+	stream, closeFn := newFakeSubscription(t, t.Context(), SubscriptionOptions{MaxInFlight: 3}, handler)
+	// The fourth message waits in the receive loop for a free worker.
+	for i := range 4 {
+		stream.publish(t, fmt.Sprintf("id%d", i), "data")
+	}
+
+	// All workers are busy, so no further messages are received.
+	select {
+	case stream.events <- &pb.TopicEventRequest{Id: "id4"}:
+		t.Fatal("message received while the in-flight limit was reached")
+	case <-time.After(100 * time.Millisecond):
+	}
+	assert.Equal(t, int32(3), current.Load())
+
+	close(release)
+	for i := 4; i < 10; i++ {
+		stream.publish(t, fmt.Sprintf("id%d", i), "data")
+	}
+	require.Eventually(t, func() bool { return len(stream.acked()) == 10 }, 5*time.Second, 10*time.Millisecond)
+	assert.LessOrEqual(t, peak.Load(), int32(3))
+	for _, ack := range stream.acked() {
+		assert.Equal(t, pb.TopicEventResponse_SUCCESS, ack.GetStatus().GetStatus())
+	}
+
+	require.NoError(t, closeFn())
+	assert.True(t, stream.isClosed())
+}
+
+func TestSubscribeWithHandlerOrdering(t *testing.T) {
+	var lock sync.Mutex
+	handled := map[string][]string{}
+	handler := func(e *common.TopicEvent) common.SubscriptionResponseStatus {
+		key, seq, _ := strings.Cut(e.Data.(string), ":")
+		// Handle earlier messages slowly so reordering would show.
+		if seq == "0" {
+			time.Sleep(50 * time.Millisecond)
+		}
+		lock.Lock()
+		handled[key] = append(handled[key], seq)
+		lock.Unlock()
+		return common.SubscriptionResponseStatusSuccess
+	}
+
+	stream, closeFn := newFakeSubscription(t, t.Context(), SubscriptionOptions{
+		MaxInFlight: 4,
+		OrderingKey: func(e *common.TopicEvent) string {
+			key, _, _ := strings.Cut(e.Data.(string), ":")
+			return key
+		},
+	}, handler)
+
+	keys := []string{"a", "b", "c", "d", "e"}
+	for seq := range 5 {
+		for _, key := range keys {
+			stream.publish(t, key+fmt.Sprint(seq), fmt.Sprintf("%s:%d", key, seq))
+		}
+	}
+	require.Eventually(t, func() bool { return len(stream.acked()) == 25 }, 5*time.Second, 10*time.Millisecond)
+
+	lock.Lock()
+	defer lock.Unlock()
+	for _, key := range keys {
+		assert.Equal(t, []string{"0", "1", "2", "3", "4"}, handled[key], "key %s", key)
+	}
+	require.NoError(t, closeFn())
+}
+
+func TestSubscribeWithHandlerDrain(t *testing.T) {
+	for name, maxInFlight := range map[string]int{"unbounded": 0, "bounded": 2} {
+		t.Run(name+" on close", func(t *testing.T) {
+			started := make(chan struct{})
+			release := make(chan struct{})
+			stream, closeFn := newFakeSubscription(t, t.Context(), SubscriptionOptions{MaxInFlight: maxInFlight},
+				func(e *common.TopicEvent) common.SubscriptionResponseStatus {
+					started <- struct{}{}
+					<-release
+					return common.SubscriptionResponseStatusRetry
+				})
+			stream.publish(t, "id1", "data")
+			<-started
+
+			closed := make(chan error)
+			go func() { closed <- closeFn() }()
+			select {
+			case <-closed:
+				t.Fatal("closed before the in-flight message was acknowledged")
+			case <-time.After(100 * time.Millisecond):
+			}
+
+			close(release)
+			require.NoError(t, <-closed)
+			assert.True(t, stream.isClosed())
+			acks := stream.acked()
+			require.Len(t, acks, 1)
+			assert.Equal(t, "id1", acks[0].GetId())
+			assert.Equal(t, pb.TopicEventResponse_RETRY, acks[0].GetStatus().GetStatus())
+			assert.False(t, stream.ackedAfter)
+		})
+
+		t.Run(name+" on context done", func(t *testing.T) {
+			ctx, cancel := context.WithCancel(t.Context())
+			started := make(chan struct{})
+			release := make(chan struct{})
+			stream, closeFn := newFakeSubscription(t, ctx, SubscriptionOptions{MaxInFlight: maxInFlight},
+				func(e *common.TopicEvent) common.SubscriptionResponseStatus {
+					started <- struct{}{}
+					<-release
+					return common.SubscriptionResponseStatusSuccess
+				})
+			stream.publish(t, "id1", "data")
+			<-started
+
+			cancel()
+			time.Sleep(50 * time.Millisecond)
+			assert.False(t, stream.isClosed())
+
+			close(release)
+			require.Eventually(t, stream.isClosed, 5*time.Second, 10*time.Millisecond)
+			require.Len(t, stream.acked(), 1)
+			require.NoError(t, closeFn())
+		})
+	}
+
+	t.Run("drain timeout", func(t *testing.T) {
+		started := make(chan struct{})
+		release := make(chan struct{})
+		defer close(release)
+		stream, closeFn := newFakeSubscription(t, t.Context(), SubscriptionOptions{MaxInFlight: 1, DrainTimeout: 50 * time.Millisecond},
+			func(e *common.TopicEvent) common.SubscriptionResponseStatus {
+				close(started)
+				<-release
+				return common.SubscriptionResponseStatusSuccess
+			})
+		stream.publish(t, "id1", "data")
+		<-started
+
+		require.Error(t, closeFn())
+		assert.True(t, stream.isClosed())
+	})
+}
+
+func TestSubscribeWithContextHandler(t *testing.T) {
+	ctx, cancel := context.WithCancel(t.Context())
+	type received struct {
+		ctx   context.Context
+		event *common.TopicEvent
+	}
+	recv := make(chan received, 1)
+	stream, closeFn := newFakeContextSubscription(t, ctx, SubscriptionOptions{},
+		func(ctx context.Context, e *common.TopicEvent) common.SubscriptionResponseStatus {
+			recv <- received{ctx: ctx, event: e}
+			return common.SubscriptionResponseStatusSuccess
+		})
+
+	ext, err := structpb.NewStruct(map[string]any{
+		"subject":     "orders/1",
+		"traceid":     "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01",
+		"traceparent": "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01",
+		"tracestate":  "vendor=value",
+		"tenant":      "acme",
+	})
+	require.NoError(t, err)
+	stream.send(t, &pb.TopicEventRequest{
+		Id:              "id1",
+		Source:          "orders",
+		Type:            "order.created",
+		SpecVersion:     "1.0",
+		Topic:           "test",
+		PubsubName:      "messages",
+		DataContentType: "application/json",
+		Data:            []byte(`{"id":1}`),
+		Extensions:      ext,
+	})
+
+	r := <-recv
+	assert.Equal(t, "orders/1", r.event.Subject)
+	assert.Equal(t, "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01", r.event.TraceID)
+	assert.Equal(t, "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01", r.event.TraceParent)
+	assert.Equal(t, "vendor=value", r.event.TraceState)
+	assert.Equal(t, map[string]any{"tenant": "acme"}, r.event.Extensions)
+	assert.Equal(t, map[string]any{"id": float64(1)}, r.event.Data)
+
+	md, ok := metadata.FromOutgoingContext(r.ctx)
+	require.True(t, ok)
+	assert.Equal(t, []string{"00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01"}, md.Get("traceparent"))
+	assert.Equal(t, []string{"vendor=value"}, md.Get("tracestate"))
+
+	require.NoError(t, r.ctx.Err())
+	cancel()
+	require.Error(t, r.ctx.Err())
+	require.Eventually(t, stream.isClosed, 5*time.Second, 10*time.Millisecond)
+	require.NoError(t, closeFn())
+}
+
+func TestSubscriptionReconnect(t *testing.T) {
+	interval := subscriptionReconnectInterval
+	subscriptionReconnectInterval = 10 * time.Millisecond
+	t.Cleanup(func() { subscriptionReconnectInterval = interval })
+
+	fake := &fakeSubscribeClient{streams: make(chan *fakeTopicStream, 2)}
+	c := &GRPCClient{protoClient: fake}
+	sub, err := c.Subscribe(t.Context(), SubscriptionOptions{PubsubName: "messages", Topic: "test"})
+	require.NoError(t, err)
+	stream := <-fake.streams
+	assert.True(t, sub.Health().Connected)
+
+	received := make(chan *SubscriptionMessage)
+	go func() {
+		msg, err := sub.Receive()
+		assert.NoError(t, err)
+		received <- msg
+	}()
+
+	// The stream breaks and the first re-subscribe attempts fail.
+	fake.failures.Store(3)
+	stream.errs <- status.Error(codes.Unavailable, "connection reset")
+
+	var next *fakeTopicStream
+	select {
+	case next = <-fake.streams:
+	case <-time.After(5 * time.Second):
+		t.Fatal("subscription not re-established")
+	}
+	assert.True(t, stream.isClosed())
+	next.publish(t, "id1", "data")
+
+	msg := <-received
+	assert.Equal(t, "id1", msg.ID)
+	health := sub.Health()
+	assert.True(t, health.Connected)
+	assert.Equal(t, 1, health.Reconnects)
+	require.Error(t, health.LastError)
+	assert.Equal(t, codes.Unavailable, status.Code(health.LastError))
+
+	require.NoError(t, sub.Close())
+	assert.False(t, sub.Health().Connected)
+}
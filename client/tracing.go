@@ -0,0 +1,98 @@
+/*
+Copyright 2026 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package client
+
+import (
+	"context"
+	"strings"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	otelcodes "go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	pb "github.com/dapr/dapr/pkg/proto/runtime/v1"
+	"github.com/dapr/go-sdk/internal/tracing"
+	"github.com/dapr/go-sdk/version"
+)
+
+// TracingMiddleware returns a middleware starting an OpenTelemetry client span
+// for every operation, with the tracer provider tp, or the global one if tp is
+// nil. The trace context of the span is propagated to Dapr in the W3C
+// traceparent and tracestate metadata.
+//
+// Operations made with a context without a span, but with a traceparent in its
+// outgoing metadata, as set by WithTraceID or in the context of the handlers
+// of streaming subscriptions, are part of that trace. The spans of streaming
+// APIs end once the stream is opened.
+func TracingMiddleware(tp trace.TracerProvider) Middleware {
+	if tp == nil {
+		tp = otel.GetTracerProvider()
+	}
+	tracer := tp.Tracer(instrumentationName, trace.WithInstrumentationVersion(strings.TrimSpace(version.SDKVersion)))
+	propagator := propagation.TraceContext{}
+
+	return func(next Handler) Handler {
+		return func(ctx context.Context, op *Operation) error {
+			md, _ := metadata.FromOutgoingContext(ctx)
+			md = md.Copy()
+			if !trace.SpanContextFromContext(ctx).IsValid() {
+				ctx = propagator.Extract(ctx, tracing.MetadataCarrier(md))
+			}
+
+			ctx, span := tracer.Start(ctx, pb.Dapr_ServiceDesc.ServiceName+"/"+op.Name,
+				trace.WithSpanKind(trace.SpanKindClient),
+				trace.WithAttributes(operationAttributes(op)...))
+			defer span.End()
+
+			propagator.Inject(ctx, tracing.MetadataCarrier(md))
+			err := next(metadata.NewOutgoingContext(ctx, md), op)
+			span.SetAttributes(attribute.Int("rpc.grpc.status_code", int(status.Code(err))))
+			if err != nil {
+				span.RecordError(err)
+				span.SetStatus(otelcodes.Error, err.Error())
+			}
+			return err
+		}
+	}
+}
+
+// operationAttributes returns the span attributes describing op.
+func operationAttributes(op *Operation) []attribute.KeyValue {
+	attrs := []attribute.KeyValue{
+		attribute.String("rpc.system", "grpc"),
+		attribute.String("rpc.service", pb.Dapr_ServiceDesc.ServiceName),
+		attribute.String("rpc.method", op.Name),
+		attribute.String("dapr.building_block", string(op.BuildingBlock)),
+	}
+	for _, kv := range []struct {
+		key   attribute.Key
+		value string
+	}{
+		{"dapr.component", op.Component},
+		{"messaging.destination.name", op.Topic},
+		{"dapr.app_id", op.AppID},
+		{"dapr.method", op.Method},
+		{"dapr.actor.type", op.ActorType},
+		{"dapr.actor.id", op.ActorID},
+	} {
+		if kv.value != "" {
+			attrs = append(attrs, kv.key.String(kv.value))
+		}
+	}
+	return attrs
+}
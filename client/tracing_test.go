@@ -0,0 +1,88 @@
+/*
+Copyright 2026 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package client
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/attribute"
+	otelcodes "go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+	"google.golang.org/grpc/metadata"
+)
+
+func TestTracingMiddleware(t *testing.T) {
+	ctx := t.Context()
+	spans := tracetest.NewSpanRecorder()
+	tp := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(spans))
+
+	var traceparent string
+	capture := func(next Handler) Handler {
+		return func(ctx context.Context, op *Operation) error {
+			md, _ := metadata.FromOutgoingContext(ctx)
+			traceparent = md.Get(traceparentKey)[0]
+			return next(ctx, op)
+		}
+	}
+	c, _ := newMiddlewareClient(t, TracingMiddleware(tp), capture)
+
+	last := func() sdktrace.ReadOnlySpan {
+		ended := spans.Ended()
+		require.NotEmpty(t, ended)
+		return ended[len(ended)-1]
+	}
+
+	t.Run("client span", func(t *testing.T) {
+		parentCtx, parent := tp.Tracer("test").Start(ctx, "parent")
+		defer parent.End()
+		require.NoError(t, c.PublishEvent(parentCtx, "messages", "orders", []byte("order")))
+
+		span := last()
+		assert.Equal(t, "dapr.proto.runtime.v1.Dapr/PublishEvent", span.Name())
+		assert.Equal(t, trace.SpanKindClient, span.SpanKind())
+		assert.Equal(t, parent.SpanContext().SpanID(), span.Parent().SpanID())
+		assert.Contains(t, span.Attributes(), attribute.String("dapr.component", "messages"))
+		assert.Contains(t, span.Attributes(), attribute.String("messaging.destination.name", "orders"))
+		assert.Contains(t, span.Attributes(), attribute.Int("rpc.grpc.status_code", 0))
+
+		sc := span.SpanContext()
+		assert.Equal(t, "00-"+sc.TraceID().String()+"-"+sc.SpanID().String()+"-01", traceparent)
+	})
+
+	t.Run("trace ID of the context", func(t *testing.T) {
+		const traceID = "4bf92f3577b34da6a3ce929d0e0e4736"
+		_, err := c.InvokeMethod(c.WithTraceID(ctx, "00-"+traceID+"-00f067aa0ba902b7-01"), "orders", "list", "get")
+		require.NoError(t, err)
+
+		span := last()
+		assert.Equal(t, traceID, span.SpanContext().TraceID().String())
+		assert.Equal(t, "00f067aa0ba902b7", span.Parent().SpanID().String())
+		assert.Contains(t, span.Attributes(), attribute.String("dapr.app_id", "orders"))
+		assert.Contains(t, traceparent, span.SpanContext().SpanID().String())
+	})
+
+	t.Run("error", func(t *testing.T) {
+		_, err := c.GetActorState(ctx, &GetActorStateRequest{ActorType: "cart", ActorID: "1", KeyName: "items"})
+		require.Error(t, err)
+
+		span := last()
+		assert.Equal(t, otelcodes.Error, span.Status().Code)
+		assert.Contains(t, span.Attributes(), attribute.String("dapr.actor.type", "cart"))
+	})
+}
@@ -17,58 +17,9 @@ import (
 	"errors"
 	"io"
 	"sync"
-	"testing"
 	"time"
 )
 
-func TestIsCloudEvent(t *testing.T) {
-	testcases := []struct {
-		name     string
-		event    []byte
-		expected bool
-	}{
-		{
-			name:     "empty event",
-			event:    []byte{},
-			expected: false,
-		},
-		{
-			name:     "event in invalid format",
-			event:    []byte(`foo`),
-			expected: false,
-		},
-		{
-			name:     "event in JSON format without cloudevent fields",
-			event:    []byte(`{"foo":"bar"}`),
-			expected: false,
-		},
-		{
-			name:     "event with id, source, specversion and type",
-			event:    []byte(`{"id":"123","source":"source","specversion":"1.0","type":"type"}`),
-			expected: true,
-		},
-		{
-			name:     "event with missing id",
-			event:    []byte(`{"source":"source","specversion":"1.0","type":"type"}`),
-			expected: false,
-		},
-		{
-			name:     "event with extra fields",
-			event:    []byte(`{"id":"123","source":"source","specversion":"1.0","type":"type","foo":"bar"}`),
-			expected: true,
-		},
-	}
-
-	for _, tc := range testcases {
-		t.Run(tc.name, func(t *testing.T) {
-			actual := isCloudEvent(tc.event)
-			if actual != tc.expected {
-				t.Errorf("expected %v, got %v", tc.expected, actual)
-			}
-		})
-	}
-}
-
 // Implements an io.Reader that simulates failures (after optionally reading from a stream in full)
 type failingReader struct {
 	// Data to return before returning an error
@@ -6,24 +6,25 @@ import (
 	"os"
 	"time"
 
-	"google.golang.org/grpc"
 	pb "google.golang.org/grpc/examples/helloworld/helloworld"
-	"google.golang.org/grpc/metadata"
-)
 
-const (
-	address = "localhost:50007"
+	dapr "github.com/dapr/go-sdk/client"
 )
 
 var logger = log.New(os.Stdout, "", log.LstdFlags)
 
 func main() {
-	// Set up a connection to the server.
-	conn, err := grpc.Dial(address, grpc.WithInsecure(), grpc.WithBlock())
+	// Set up a connection to the sidecar, which proxies calls to the server.
+	client, err := dapr.NewClient()
+	if err != nil {
+		logger.Fatalf("did not connect: %v", err)
+	}
+	defer client.Close()
+
+	conn, err := client.AppClientConn("grpc-server")
 	if err != nil {
 		logger.Fatalf("did not connect: %v", err)
 	}
-	defer conn.Close()
 	c := pb.NewGreeterClient(conn)
 
 	var r *pb.HelloReply
@@ -44,6 +45,5 @@ func main() {
 func sendSayHello(c pb.GreeterClient) (*pb.HelloReply, error) {
 	ctx, cancel := context.WithTimeout(context.Background(), time.Second*2)
 	defer cancel()
-	ctx = metadata.AppendToOutgoingContext(ctx, "dapr-app-id", "grpc-server")
 	return c.SayHello(ctx, &pb.HelloRequest{Name: "Dapr"})
 }
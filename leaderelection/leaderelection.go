@@ -0,0 +1,204 @@
+/*
+Copyright 2026 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package leaderelection elects a single leader among application instances
+// using the Dapr distributed lock API.
+package leaderelection
+
+import (
+	"context"
+	"errors"
+	"log"
+	"sync/atomic"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/dapr/go-sdk/client"
+)
+
+const (
+	// DefaultExpiryInSeconds is the default lease duration of the leadership lock.
+	DefaultExpiryInSeconds = 15
+	// DefaultRetryInterval is the default interval between failed campaigns.
+	DefaultRetryInterval = time.Second
+
+	releaseTimeout = 5 * time.Second
+)
+
+// Config contains the configuration of a LeaderElector.
+type Config struct {
+	// Locker is used to acquire and renew the leadership lock, usually a client.Client. Required.
+	Locker client.Locker
+	// StoreName is the name of the lock store component. Required.
+	StoreName string
+	// ResourceID identifies the leadership lock. Instances campaigning for the
+	// same resource in the same store elect a single leader. Required.
+	ResourceID string
+	// Identity is the lock owner of this instance. Defaults to a random UUID.
+	Identity string
+	// ExpiryInSeconds is the lease duration of the leadership lock.
+	// Defaults to DefaultExpiryInSeconds.
+	ExpiryInSeconds int32
+	// RenewInterval is the interval at which leadership is renewed.
+	// Defaults to a third of the lease duration. With lock stores which do not
+	// extend the lease of its current owner, leadership is lost when the lease
+	// expires, see client.AcquireLock.
+	RenewInterval time.Duration
+	// RetryInterval is the initial interval between attempts to acquire leadership.
+	// Defaults to DefaultRetryInterval.
+	RetryInterval time.Duration
+
+	// OnStartedLeading is called in its own goroutine when this instance becomes
+	// the leader. ctx is canceled when leadership is lost or the elector is
+	// stopped, after which the function should return promptly. Leadership is
+	// held until then, even if the function returns earlier. Required.
+	OnStartedLeading func(ctx context.Context)
+	// OnStoppedLeading is called once OnStartedLeading has returned after
+	// leadership was lost or given up. Optional.
+	OnStoppedLeading func()
+	// Logger receives campaign and renewal errors. Defaults to the logger of
+	// the client package, see client.SetLogger.
+	Logger *log.Logger
+}
+
+// LeaderElector campaigns for leadership until its context is done.
+type LeaderElector struct {
+	cfg     Config
+	leading atomic.Bool
+}
+
+// New returns a LeaderElector for the given configuration.
+func New(cfg Config) (*LeaderElector, error) {
+	if cfg.Locker == nil {
+		return nil, errors.New("locker is required")
+	}
+	if cfg.StoreName == "" {
+		return nil, errors.New("store name is required")
+	}
+	if cfg.ResourceID == "" {
+		return nil, errors.New("resource ID is required")
+	}
+	if cfg.OnStartedLeading == nil {
+		return nil, errors.New("OnStartedLeading callback is required")
+	}
+	if cfg.Identity == "" {
+		cfg.Identity = uuid.NewString()
+	}
+	if cfg.ExpiryInSeconds <= 0 {
+		cfg.ExpiryInSeconds = DefaultExpiryInSeconds
+	}
+	if cfg.RetryInterval <= 0 {
+		cfg.RetryInterval = DefaultRetryInterval
+	}
+	if cfg.Logger == nil {
+		cfg.Logger = client.Logger()
+	}
+
+	return &LeaderElector{cfg: cfg}, nil
+}
+
+// Identity returns the lock owner of this instance.
+func (le *LeaderElector) Identity() string {
+	return le.cfg.Identity
+}
+
+// IsLeader returns true while this instance is the leader.
+func (le *LeaderElector) IsLeader() bool {
+	return le.leading.Load()
+}
+
+// Run campaigns for leadership and leads whenever it is acquired, until ctx is done.
+// When leadership is lost, Run waits for OnStartedLeading to return and campaigns again.
+// When ctx is done, leadership is given up gracefully: the leading context is
+// canceled, OnStartedLeading is awaited and the lock is released so another
+// instance can take over without waiting for the lease to expire.
+func (le *LeaderElector) Run(ctx context.Context) {
+	for ctx.Err() == nil {
+		lock, release, err := le.acquire(ctx)
+		if err != nil {
+			if ctx.Err() != nil {
+				return
+			}
+			le.cfg.Logger.Printf("error acquiring leadership of %s: %v", le.cfg.ResourceID, err)
+			select {
+			case <-ctx.Done():
+				return
+			case <-time.After(le.cfg.RetryInterval):
+			}
+			continue
+		}
+		if ctx.Err() != nil {
+			release()
+			return
+		}
+
+		le.lead(ctx, lock)
+		release()
+	}
+}
+
+// acquire blocks until the leadership lock is acquired or ctx is done.
+// The lock is held until the returned release function is called, even if ctx
+// is done in the meantime, so leadership can be given up gracefully.
+func (le *LeaderElector) acquire(ctx context.Context) (*client.LockHandle, func(), error) {
+	lockCtx, cancelLock := context.WithCancel(context.WithoutCancel(ctx))
+	stop := context.AfterFunc(ctx, cancelLock)
+
+	lock, err := client.AcquireLock(lockCtx, le.cfg.Locker, le.cfg.StoreName, le.cfg.ResourceID, &client.LockOptions{
+		LockOwner:       le.cfg.Identity,
+		ExpiryInSeconds: le.cfg.ExpiryInSeconds,
+		RenewInterval:   le.cfg.RenewInterval,
+		RetryInterval:   le.cfg.RetryInterval,
+	})
+	stop()
+	if err != nil {
+		cancelLock()
+		return nil, nil, err
+	}
+
+	return lock, func() {
+		releaseCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), releaseTimeout)
+		defer cancel()
+		if err := lock.Unlock(releaseCtx); err != nil {
+			le.cfg.Logger.Printf("error releasing leadership of %s: %v", le.cfg.ResourceID, err)
+		}
+		cancelLock()
+	}, nil
+}
+
+// lead runs OnStartedLeading until leadership is lost or ctx is done.
+func (le *LeaderElector) lead(ctx context.Context, lock *client.LockHandle) {
+	leadCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	le.leading.Store(true)
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		le.cfg.OnStartedLeading(leadCtx)
+	}()
+
+	select {
+	case <-lock.Lost():
+		le.cfg.Logger.Printf("lost leadership of %s", le.cfg.ResourceID)
+	case <-ctx.Done():
+	}
+
+	cancel()
+	<-done
+	le.leading.Store(false)
+	if le.cfg.OnStoppedLeading != nil {
+		le.cfg.OnStoppedLeading()
+	}
+}
@@ -0,0 +1,287 @@
+/*
+Copyright 2026 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package leaderelection
+
+import (
+	"bytes"
+	"context"
+	"log"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	pb "github.com/dapr/dapr/pkg/proto/runtime/v1"
+	"github.com/dapr/go-sdk/client"
+)
+
+// memLocker is an in-memory lock store with lease expiry. Like most lock
+// stores, a held lock can not be locked again by its owner, unless reentrant
+// is set, in which case the owner extends its lease.
+type memLocker struct {
+	lock      sync.Mutex
+	reentrant bool
+	owner     string
+	expires   time.Time
+}
+
+func (m *memLocker) TryLockAlpha1(ctx context.Context, storeName string, request *client.LockRequest) (*client.LockResponse, error) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	if m.owner != "" && time.Now().Before(m.expires) && (m.owner != request.LockOwner || !m.reentrant) {
+		return &client.LockResponse{}, nil
+	}
+	m.owner = request.LockOwner
+	m.expires = time.Now().Add(time.Duration(request.ExpiryInSeconds) * time.Second)
+	return &client.LockResponse{Success: true}, nil
+}
+
+func (m *memLocker) UnlockAlpha1(ctx context.Context, storeName string, request *client.UnlockRequest) (*client.UnlockResponse, error) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	status := pb.UnlockResponse_SUCCESS
+	switch {
+	case m.owner == "" || !time.Now().Before(m.expires):
+		status = pb.UnlockResponse_LOCK_DOES_NOT_EXIST
+	case m.owner != request.LockOwner:
+		status = pb.UnlockResponse_LOCK_BELONGS_TO_OTHERS
+	default:
+		m.owner = ""
+	}
+	return &client.UnlockResponse{StatusCode: int32(status), Status: status.String()}, nil
+}
+
+// steal hands the lock to another owner, so renewals by the current leader fail.
+func (m *memLocker) steal(owner string) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	m.owner = owner
+	m.expires = time.Now().Add(time.Hour)
+}
+
+func (m *memLocker) currentOwner() string {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	return m.owner
+}
+
+// testCandidate records the callbacks of a LeaderElector.
+type testCandidate struct {
+	*LeaderElector
+
+	started chan struct{}
+	stopped chan struct{}
+	running atomic.Int32
+}
+
+func newTestCandidate(t *testing.T, locker client.Locker, identity string) *testCandidate {
+	t.Helper()
+	c := &testCandidate{
+		started: make(chan struct{}, 10),
+		stopped: make(chan struct{}, 10),
+	}
+	le, err := New(Config{
+		Locker:          locker,
+		StoreName:       "lockstore",
+		ResourceID:      "leader",
+		Identity:        identity,
+		ExpiryInSeconds: 1,
+		RenewInterval:   50 * time.Millisecond,
+		RetryInterval:   10 * time.Millisecond,
+		OnStartedLeading: func(ctx context.Context) {
+			c.running.Add(1)
+			c.started <- struct{}{}
+			<-ctx.Done()
+			c.running.Add(-1)
+		},
+		OnStoppedLeading: func() {
+			c.stopped <- struct{}{}
+		},
+	})
+	require.NoError(t, err)
+	c.LeaderElector = le
+	return c
+}
+
+func waitFor(t *testing.T, ch <-chan struct{}, msg string) {
+	t.Helper()
+	select {
+	case <-ch:
+	case <-time.After(5 * time.Second):
+		t.Fatal(msg)
+	}
+}
+
+func TestNew(t *testing.T) {
+	valid := Config{
+		Locker:           &memLocker{},
+		StoreName:        "lockstore",
+		ResourceID:       "leader",
+		OnStartedLeading: func(context.Context) {},
+	}
+
+	le, err := New(valid)
+	require.NoError(t, err)
+	assert.NotEmpty(t, le.Identity())
+	assert.False(t, le.IsLeader())
+	assert.Same(t, client.Logger(), le.cfg.Logger)
+
+	for name, mutate := range map[string]func(*Config){
+		"without locker":     func(c *Config) { c.Locker = nil },
+		"without store":      func(c *Config) { c.StoreName = "" },
+		"without resource":   func(c *Config) { c.ResourceID = "" },
+		"without on started": func(c *Config) { c.OnStartedLeading = nil },
+	} {
+		t.Run(name, func(t *testing.T) {
+			cfg := valid
+			mutate(&cfg)
+			_, err := New(cfg)
+			require.Error(t, err)
+		})
+	}
+}
+
+func TestLeaderElection(t *testing.T) {
+	t.Run("single leader and graceful handover", func(t *testing.T) {
+		locker := &memLocker{}
+		a := newTestCandidate(t, locker, "a")
+		b := newTestCandidate(t, locker, "b")
+
+		ctxA, cancelA := context.WithCancel(t.Context())
+		doneA := make(chan struct{})
+		go func() {
+			defer close(doneA)
+			a.Run(ctxA)
+		}()
+		waitFor(t, a.started, "a did not start leading")
+		assert.True(t, a.IsLeader())
+
+		ctxB, cancelB := context.WithCancel(t.Context())
+		doneB := make(chan struct{})
+		go func() {
+			defer close(doneB)
+			b.Run(ctxB)
+		}()
+
+		time.Sleep(200 * time.Millisecond)
+		assert.False(t, b.IsLeader())
+		assert.Equal(t, "a", locker.currentOwner())
+
+		// Shutting down a releases leadership without waiting for the lease to expire.
+		cancelA()
+		waitFor(t, a.stopped, "a did not stop leading")
+		waitFor(t, doneA, "a did not return")
+		assert.False(t, a.IsLeader())
+		assert.Zero(t, a.running.Load())
+
+		waitFor(t, b.started, "b did not take over leadership")
+		assert.True(t, b.IsLeader())
+		assert.Equal(t, "b", locker.currentOwner())
+
+		cancelB()
+		waitFor(t, doneB, "b did not return")
+		assert.Empty(t, locker.currentOwner())
+	})
+
+	t.Run("leadership kept across renewals", func(t *testing.T) {
+		locker := &memLocker{reentrant: true}
+		a := newTestCandidate(t, locker, "a")
+
+		ctx, cancel := context.WithCancel(t.Context())
+		done := make(chan struct{})
+		go func() {
+			defer close(done)
+			a.Run(ctx)
+		}()
+		waitFor(t, a.started, "a did not start leading")
+
+		// several renew intervals and lease durations
+		select {
+		case <-a.stopped:
+			t.Fatal("a stopped leading while renewing leadership")
+		case <-time.After(2500 * time.Millisecond):
+		}
+		assert.True(t, a.IsLeader())
+		assert.Equal(t, int32(1), a.running.Load())
+		assert.Equal(t, "a", locker.currentOwner())
+
+		cancel()
+		waitFor(t, done, "a did not return")
+	})
+
+	t.Run("leadership lost at lease expiry by stores not extending leases", func(t *testing.T) {
+		locker := &memLocker{}
+		a := newTestCandidate(t, locker, "a")
+
+		ctx, cancel := context.WithCancel(t.Context())
+		done := make(chan struct{})
+		go func() {
+			defer close(done)
+			a.Run(ctx)
+		}()
+		waitFor(t, a.started, "a did not start leading")
+		start := time.Now()
+
+		waitFor(t, a.stopped, "a did not stop leading")
+		assert.Less(t, time.Since(start), 1200*time.Millisecond)
+
+		cancel()
+		waitFor(t, done, "a did not return")
+	})
+
+	t.Run("leadership lost when renewal fails", func(t *testing.T) {
+		locker := &memLocker{}
+		a := newTestCandidate(t, locker, "a")
+		logs := &syncBuffer{}
+		a.cfg.Logger = log.New(logs, "", 0)
+
+		ctx, cancel := context.WithCancel(t.Context())
+		done := make(chan struct{})
+		go func() {
+			defer close(done)
+			a.Run(ctx)
+		}()
+		waitFor(t, a.started, "a did not start leading")
+
+		locker.steal("other")
+		waitFor(t, a.stopped, "a did not stop leading")
+		assert.False(t, a.IsLeader())
+		assert.Zero(t, a.running.Load())
+		assert.Contains(t, logs.String(), "lost leadership of leader")
+
+		cancel()
+		waitFor(t, done, "a did not return")
+	})
+}
+
+// syncBuffer is a bytes.Buffer safe for concurrent use.
+type syncBuffer struct {
+	lock sync.Mutex
+	buf  bytes.Buffer
+}
+
+func (b *syncBuffer) Write(p []byte) (int, error) {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+	return b.buf.Write(p)
+}
+
+func (b *syncBuffer) String() string {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+	return b.buf.String()
+}
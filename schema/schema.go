@@ -0,0 +1,161 @@
+/*
+Copyright 2026 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package schema validates the data of pub/sub events against schemas,
+// registered by topic or by the CloudEvent dataschema attribute. It is used by
+// the client before publishing and by the service middleware before invoking
+// topic handlers.
+package schema
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/santhosh-tekuri/jsonschema/v6"
+)
+
+// Validator validates event data against a schema.
+type Validator interface {
+	Validate(ctx context.Context, data []byte) error
+}
+
+// ValidatorFunc adapts a function to a Validator.
+type ValidatorFunc func(ctx context.Context, data []byte) error
+
+// Validate calls f.
+func (f ValidatorFunc) Validate(ctx context.Context, data []byte) error {
+	return f(ctx, data)
+}
+
+// ValidationError is returned for event data which does not match its schema.
+type ValidationError struct {
+	PubsubName string
+	Topic      string
+	// DataSchema is the dataschema of the event, if the validator was
+	// registered for it.
+	DataSchema string
+	Err        error
+}
+
+func (e *ValidationError) Error() string {
+	if e.DataSchema != "" {
+		return fmt.Sprintf("event data does not match schema %s: %v", e.DataSchema, e.Err)
+	}
+	return fmt.Sprintf("event data does not match schema of topic %s: %v", e.Topic, e.Err)
+}
+
+func (e *ValidationError) Unwrap() error {
+	return e.Err
+}
+
+type topicKey struct {
+	pubsubName string
+	topic      string
+}
+
+// Registry holds the validators of topics and dataschemas. The zero value is
+// an empty registry, and a Registry is safe for concurrent use.
+type Registry struct {
+	lock    sync.RWMutex
+	topics  map[topicKey]Validator
+	schemas map[string]Validator
+}
+
+// RegisterTopic registers v for events of topic. An empty pubsubName matches
+// the topic of any pubsub.
+func (r *Registry) RegisterTopic(pubsubName, topic string, v Validator) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	if r.topics == nil {
+		r.topics = make(map[topicKey]Validator)
+	}
+	r.topics[topicKey{pubsubName: pubsubName, topic: topic}] = v
+}
+
+// RegisterSchema registers v for events with the given dataschema.
+func (r *Registry) RegisterSchema(dataSchema string, v Validator) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	if r.schemas == nil {
+		r.schemas = make(map[string]Validator)
+	}
+	r.schemas[dataSchema] = v
+}
+
+// Lookup returns the validator of an event. A validator registered for the
+// dataschema takes precedence over those registered for the topic.
+func (r *Registry) Lookup(pubsubName, topic, dataSchema string) (v Validator, bySchema bool, ok bool) {
+	r.lock.RLock()
+	defer r.lock.RUnlock()
+	if dataSchema != "" {
+		if v, ok = r.schemas[dataSchema]; ok {
+			return v, true, true
+		}
+	}
+	if v, ok = r.topics[topicKey{pubsubName: pubsubName, topic: topic}]; ok {
+		return v, false, true
+	}
+	v, ok = r.topics[topicKey{topic: topic}]
+	return v, false, ok
+}
+
+// Validate validates data of an event with its validator, returning a
+// *ValidationError if it does not match. Events without a validator are valid.
+func (r *Registry) Validate(ctx context.Context, pubsubName, topic, dataSchema string, data []byte) error {
+	v, bySchema, ok := r.Lookup(pubsubName, topic, dataSchema)
+	if !ok {
+		return nil
+	}
+	if err := v.Validate(ctx, data); err != nil {
+		verr := &ValidationError{PubsubName: pubsubName, Topic: topic, Err: err}
+		if bySchema {
+			verr.DataSchema = dataSchema
+		}
+		return verr
+	}
+	return nil
+}
+
+// JSONSchema returns a Validator for JSON data, compiled from a JSON Schema
+// document. The draft is taken from its $schema, and defaults to 2020-12.
+// References to other documents are not resolved.
+func JSONSchema(schema []byte) (Validator, error) {
+	doc, err := jsonschema.UnmarshalJSON(bytes.NewReader(schema))
+	if err != nil {
+		return nil, fmt.Errorf("error parsing JSON schema: %w", err)
+	}
+	const url = "mem://schema.json"
+	c := jsonschema.NewCompiler()
+	if err = c.AddResource(url, doc); err != nil {
+		return nil, fmt.Errorf("error adding JSON schema: %w", err)
+	}
+	sch, err := c.Compile(url)
+	if err != nil {
+		return nil, fmt.Errorf("error compiling JSON schema: %w", err)
+	}
+	return jsonValidator{schema: sch}, nil
+}
+
+type jsonValidator struct {
+	schema *jsonschema.Schema
+}
+
+func (v jsonValidator) Validate(_ context.Context, data []byte) error {
+	doc, err := jsonschema.UnmarshalJSON(bytes.NewReader(data))
+	if err != nil {
+		return fmt.Errorf("invalid JSON: %w", err)
+	}
+	return v.schema.Validate(doc)
+}
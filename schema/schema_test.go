@@ -0,0 +1,87 @@
+/*
+Copyright 2026 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package schema
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestJSONSchema(t *testing.T) {
+	_, err := JSONSchema([]byte(`{"type":`))
+	require.Error(t, err)
+	_, err = JSONSchema([]byte(`{"type":"unknown"}`))
+	require.Error(t, err)
+
+	v, err := JSONSchema([]byte(`{
+		"$schema": "http://json-schema.org/draft-07/schema#",
+		"type": "object",
+		"required": ["id"],
+		"properties": {"id": {"type": "integer"}}
+	}`))
+	require.NoError(t, err)
+
+	ctx := t.Context()
+	require.NoError(t, v.Validate(ctx, []byte(`{"id":1}`)))
+	require.Error(t, v.Validate(ctx, []byte(`{"id":"1"}`)))
+	require.Error(t, v.Validate(ctx, []byte(`{}`)))
+	require.Error(t, v.Validate(ctx, []byte(`not json`)))
+}
+
+func TestRegistry(t *testing.T) {
+	ctx := t.Context()
+	reject := func(name string) Validator {
+		return ValidatorFunc(func(ctx context.Context, data []byte) error {
+			return errors.New(name)
+		})
+	}
+
+	var r Registry
+	require.NoError(t, r.Validate(ctx, "pubsub", "orders", "", nil), "events without validator are valid")
+
+	r.RegisterTopic("", "orders", reject("any pubsub"))
+	r.RegisterTopic("pubsub", "orders", reject("pubsub"))
+	r.RegisterSchema("order/v1", reject("schema"))
+
+	tests := map[string]struct {
+		pubsubName, topic, dataSchema string
+		expected                      string
+	}{
+		"topic of pubsub":          {pubsubName: "pubsub", topic: "orders", expected: "pubsub"},
+		"topic of any pubsub":      {pubsubName: "other", topic: "orders", expected: "any pubsub"},
+		"dataschema":               {pubsubName: "pubsub", topic: "orders", dataSchema: "order/v1", expected: "schema"},
+		"unknown dataschema":       {pubsubName: "pubsub", topic: "orders", dataSchema: "order/v2", expected: "pubsub"},
+		"dataschema of any topics": {pubsubName: "pubsub", topic: "payments", dataSchema: "order/v1", expected: "schema"},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			err := r.Validate(ctx, tt.pubsubName, tt.topic, tt.dataSchema, nil)
+			var verr *ValidationError
+			require.ErrorAs(t, err, &verr)
+			assert.EqualError(t, verr.Err, tt.expected)
+			assert.Equal(t, tt.topic, verr.Topic)
+			if tt.expected == "schema" {
+				assert.Equal(t, tt.dataSchema, verr.DataSchema)
+			} else {
+				assert.Empty(t, verr.DataSchema)
+			}
+		})
+	}
+
+	require.NoError(t, r.Validate(ctx, "pubsub", "payments", "", nil))
+}
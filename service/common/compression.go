@@ -0,0 +1,250 @@
+/*
+Copyright 2026 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package common
+
+import (
+	"bytes"
+	"compress/flate"
+	"compress/gzip"
+	"compress/zlib"
+	"errors"
+	"fmt"
+	"io"
+	"sync"
+)
+
+const (
+	// CompressionGzip is the gzip content encoding.
+	CompressionGzip = "gzip"
+	// CompressionDeflate is the raw DEFLATE content encoding.
+	CompressionDeflate = "deflate"
+	// CompressionZlib is the zlib content encoding.
+	CompressionZlib = "zlib"
+
+	// CompressedContentType is the content type compressed payloads are sent with.
+	// The original content type is preserved inside the payload.
+	CompressedContentType = "application/octet-stream"
+
+	// DefaultMaxDecompressedSize is the default maximum size, in bytes, of
+	// decompressed payloads.
+	DefaultMaxDecompressedSize = 16 << 20
+
+	// compressedPayloadMagic prefixes every payload produced by CompressPayload,
+	// and is the only way compressed payloads are recognized: state stores do
+	// not keep request metadata. The leading NUL byte can not start valid JSON
+	// or text content.
+	compressedPayloadMagic = "\x00DZ\x01"
+)
+
+// ErrDecompressedSizeExceeded is returned when a payload decompresses to more
+// than the maximum size allowed.
+var ErrDecompressedSizeExceeded = errors.New("decompressed payload exceeds maximum size")
+
+// Compressor compresses and decompresses payloads for a content encoding.
+type Compressor interface {
+	Compress(data []byte) ([]byte, error)
+	Decompress(data []byte) ([]byte, error)
+}
+
+// LimitedDecompressor is implemented by Compressors which can stop
+// decompressing once the output exceeds maxSize bytes, failing with
+// ErrDecompressedSizeExceeded. The output of other Compressors is only
+// checked once decompressed.
+type LimitedDecompressor interface {
+	DecompressLimit(data []byte, maxSize int64) ([]byte, error)
+}
+
+// Decompressor is implemented by the HTTP and gRPC services. Payloads
+// compressed with CompressPayload are passed to handlers as received, unless
+// EnableDecompression is called before the service is started. Decompressed
+// payloads larger than maxSize bytes, or DefaultMaxDecompressedSize if maxSize
+// is not positive, are rejected.
+type Decompressor interface {
+	EnableDecompression(maxSize int64)
+}
+
+var (
+	compressorsLock sync.RWMutex
+	compressors     = map[string]Compressor{
+		CompressionGzip:    streamCompressor{newWriter: newGzipWriter, newReader: newGzipReader},
+		CompressionDeflate: streamCompressor{newWriter: newFlateWriter, newReader: newFlateReader},
+		CompressionZlib:    streamCompressor{newWriter: newZlibWriter, newReader: zlib.NewReader},
+	}
+)
+
+// RegisterCompressor registers a Compressor for the given content encoding,
+// replacing any existing one. The gzip, deflate and zlib encodings are registered by default.
+func RegisterCompressor(encoding string, c Compressor) {
+	compressorsLock.Lock()
+	defer compressorsLock.Unlock()
+	compressors[encoding] = c
+}
+
+// GetCompressor returns the Compressor registered for the given content encoding.
+func GetCompressor(encoding string) (Compressor, bool) {
+	compressorsLock.RLock()
+	defer compressorsLock.RUnlock()
+	c, ok := compressors[encoding]
+	return c, ok
+}
+
+// CompressPayload compresses data with the given content encoding.
+// The returned payload records the encoding and the original content type,
+// so it can be restored with DecompressPayload.
+func CompressPayload(encoding, contentType string, data []byte) ([]byte, error) {
+	if len(encoding) > 255 || len(contentType) > 255 {
+		return nil, errors.New("content encoding and content type must be at most 255 bytes")
+	}
+	c, ok := GetCompressor(encoding)
+	if !ok {
+		return nil, fmt.Errorf("unknown content encoding: %s", encoding)
+	}
+
+	compressed, err := c.Compress(data)
+	if err != nil {
+		return nil, fmt.Errorf("error compressing payload with %s: %w", encoding, err)
+	}
+
+	out := make([]byte, 0, len(compressedPayloadMagic)+2+len(encoding)+len(contentType)+len(compressed))
+	out = append(out, compressedPayloadMagic...)
+	out = append(out, byte(len(encoding)))
+	out = append(out, encoding...)
+	out = append(out, byte(len(contentType)))
+	out = append(out, contentType...)
+	out = append(out, compressed...)
+	return out, nil
+}
+
+// IsCompressedPayload returns true if data was produced by CompressPayload.
+func IsCompressedPayload(data []byte) bool {
+	return bytes.HasPrefix(data, []byte(compressedPayloadMagic))
+}
+
+// DecompressPayload returns the decompressed data and its original content type
+// if data was produced by CompressPayload. Otherwise, data and contentType are
+// returned unchanged. Payloads decompressing to more than
+// DefaultMaxDecompressedSize bytes are rejected.
+func DecompressPayload(data []byte, contentType string) ([]byte, string, error) {
+	return DecompressPayloadLimit(data, contentType, DefaultMaxDecompressedSize)
+}
+
+// DecompressPayloadLimit is DecompressPayload with a maximum size of maxSize
+// bytes for the decompressed data, or DefaultMaxDecompressedSize if maxSize is
+// not positive.
+func DecompressPayloadLimit(data []byte, contentType string, maxSize int64) ([]byte, string, error) {
+	if !IsCompressedPayload(data) {
+		return data, contentType, nil
+	}
+	if maxSize <= 0 {
+		maxSize = DefaultMaxDecompressedSize
+	}
+
+	rest := data[len(compressedPayloadMagic):]
+	encoding, rest, ok := cutLengthPrefixed(rest)
+	if !ok {
+		return nil, "", errors.New("invalid compressed payload: truncated content encoding")
+	}
+	originalContentType, rest, ok := cutLengthPrefixed(rest)
+	if !ok {
+		return nil, "", errors.New("invalid compressed payload: truncated content type")
+	}
+
+	c, ok := GetCompressor(encoding)
+	if !ok {
+		return nil, "", fmt.Errorf("unknown content encoding: %s", encoding)
+	}
+	var out []byte
+	var err error
+	if l, ok := c.(LimitedDecompressor); ok {
+		out, err = l.DecompressLimit(rest, maxSize)
+	} else if out, err = c.Decompress(rest); err == nil && int64(len(out)) > maxSize {
+		err = ErrDecompressedSizeExceeded
+	}
+	if err != nil {
+		return nil, "", fmt.Errorf("error decompressing payload with %s: %w", encoding, err)
+	}
+
+	return out, originalContentType, nil
+}
+
+func cutLengthPrefixed(data []byte) (value string, rest []byte, ok bool) {
+	if len(data) == 0 || len(data) < 1+int(data[0]) {
+		return "", nil, false
+	}
+	n := 1 + int(data[0])
+	return string(data[1:n]), data[n:], true
+}
+
+// streamCompressor implements Compressor using the stdlib stream implementations.
+type streamCompressor struct {
+	newWriter func(w io.Writer) (io.WriteCloser, error)
+	newReader func(r io.Reader) (io.ReadCloser, error)
+}
+
+func (c streamCompressor) Compress(data []byte) ([]byte, error) {
+	var buf bytes.Buffer
+	w, err := c.newWriter(&buf)
+	if err != nil {
+		return nil, err
+	}
+	if _, err = w.Write(data); err != nil {
+		return nil, err
+	}
+	if err = w.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+func (c streamCompressor) Decompress(data []byte) ([]byte, error) {
+	return c.DecompressLimit(data, DefaultMaxDecompressedSize)
+}
+
+func (c streamCompressor) DecompressLimit(data []byte, maxSize int64) ([]byte, error) {
+	r, err := c.newReader(bytes.NewReader(data))
+	if err != nil {
+		return nil, err
+	}
+	defer r.Close()
+	// read one byte more than allowed, to tell a payload of exactly maxSize
+	// bytes from a larger one
+	out, err := io.ReadAll(io.LimitReader(r, maxSize+1))
+	if err != nil {
+		return nil, err
+	}
+	if int64(len(out)) > maxSize {
+		return nil, ErrDecompressedSizeExceeded
+	}
+	return out, nil
+}
+
+func newGzipWriter(w io.Writer) (io.WriteCloser, error) {
+	return gzip.NewWriter(w), nil
+}
+
+func newGzipReader(r io.Reader) (io.ReadCloser, error) {
+	return gzip.NewReader(r)
+}
+
+func newFlateWriter(w io.Writer) (io.WriteCloser, error) {
+	return flate.NewWriter(w, flate.DefaultCompression)
+}
+
+func newFlateReader(r io.Reader) (io.ReadCloser, error) {
+	return flate.NewReader(r), nil
+}
+
+func newZlibWriter(w io.Writer) (io.WriteCloser, error) {
+	return zlib.NewWriter(w), nil
+}
@@ -0,0 +1,106 @@
+/*
+Copyright 2026 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package common
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCompressPayload(t *testing.T) {
+	data := bytes.Repeat([]byte(`{"message":"hello"}`), 100)
+
+	for _, encoding := range []string{CompressionGzip, CompressionDeflate, CompressionZlib} {
+		t.Run(encoding, func(t *testing.T) {
+			out, err := CompressPayload(encoding, "application/json", data)
+			require.NoError(t, err)
+			assert.True(t, IsCompressedPayload(out))
+			assert.Less(t, len(out), len(data))
+
+			decompressed, contentType, err := DecompressPayload(out, CompressedContentType)
+			require.NoError(t, err)
+			assert.Equal(t, "application/json", contentType)
+			assert.Equal(t, data, decompressed)
+		})
+	}
+
+	t.Run("unknown encoding", func(t *testing.T) {
+		_, err := CompressPayload("unknown", "", data)
+		require.Error(t, err)
+	})
+
+	t.Run("uncompressed payload", func(t *testing.T) {
+		out, contentType, err := DecompressPayload(data, "application/json")
+		require.NoError(t, err)
+		assert.Equal(t, "application/json", contentType)
+		assert.Equal(t, data, out)
+		assert.False(t, IsCompressedPayload(data))
+	})
+
+	t.Run("truncated payload", func(t *testing.T) {
+		out, err := CompressPayload(CompressionGzip, "application/json", data)
+		require.NoError(t, err)
+		_, _, err = DecompressPayload(out[:len(compressedPayloadMagic)+3], "")
+		require.Error(t, err)
+		_, _, err = DecompressPayload(out[:len(out)-4], "")
+		require.Error(t, err)
+	})
+
+	t.Run("maximum size", func(t *testing.T) {
+		for _, encoding := range []string{CompressionGzip, CompressionDeflate, CompressionZlib} {
+			out, err := CompressPayload(encoding, "application/json", data)
+			require.NoError(t, err)
+			decompressed, _, err := DecompressPayloadLimit(out, "", int64(len(data)))
+			require.NoError(t, err)
+			assert.Equal(t, data, decompressed)
+			_, _, err = DecompressPayloadLimit(out, "", int64(len(data)-1))
+			require.ErrorIs(t, err, ErrDecompressedSizeExceeded, encoding)
+		}
+	})
+}
+
+type reverseCompressor struct{}
+
+func (reverseCompressor) Compress(data []byte) ([]byte, error) {
+	out := bytes.Clone(data)
+	for i, j := 0, len(out)-1; i < j; i, j = i+1, j-1 {
+		out[i], out[j] = out[j], out[i]
+	}
+	return out, nil
+}
+
+func (c reverseCompressor) Decompress(data []byte) ([]byte, error) {
+	return c.Compress(data)
+}
+
+func TestRegisterCompressor(t *testing.T) {
+	RegisterCompressor("reverse", reverseCompressor{})
+	t.Cleanup(func() {
+		compressorsLock.Lock()
+		delete(compressors, "reverse")
+		compressorsLock.Unlock()
+	})
+
+	out, err := CompressPayload("reverse", "text/plain", []byte("hello"))
+	require.NoError(t, err)
+	assert.True(t, bytes.HasSuffix(out, []byte("olleh")))
+
+	data, contentType, err := DecompressPayload(out, "")
+	require.NoError(t, err)
+	assert.Equal(t, "text/plain", contentType)
+	assert.Equal(t, "hello", string(data))
+}
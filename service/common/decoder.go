@@ -0,0 +1,234 @@
+/*
+Copyright 2026 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package common
+
+import (
+	"encoding"
+	"encoding/json"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"mime"
+	"strings"
+	"sync"
+
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/reflect/protoregistry"
+)
+
+// Decoder decodes event data of a media type.
+type Decoder interface {
+	// Decode decodes data of e into target, which must be a non-nil pointer.
+	Decode(e *TopicEvent, data []byte, target any) error
+	// Value returns the generic Go representation of data of e, which is set
+	// as TopicEvent.Data.
+	Value(e *TopicEvent, data []byte) (any, error)
+}
+
+var (
+	decodersLock sync.RWMutex
+	decoders     = map[string]Decoder{
+		"application/json":                jsonDecoder{},
+		"+json":                           jsonDecoder{},
+		"application/xml":                 xmlDecoder{},
+		"text/xml":                        xmlDecoder{},
+		"+xml":                            xmlDecoder{},
+		"text/*":                          textDecoder{},
+		"application/octet-stream":        bytesDecoder{},
+		"application/protobuf":            protoDecoder{},
+		"application/x-protobuf":          protoDecoder{},
+		"application/vnd.google.protobuf": protoDecoder{},
+	}
+)
+
+// RegisterDecoder registers a Decoder for the given media type, replacing any
+// existing one. JSON, XML, text, binary and protobuf decoders are registered
+// by default. A mediaType of the form "+suffix" registers d for the
+// structured syntax suffix, as in application/cloudevents+json, and one of the
+// form "type/*" for every subtype of type.
+func RegisterDecoder(mediaType string, d Decoder) {
+	decodersLock.Lock()
+	defer decodersLock.Unlock()
+	decoders[strings.ToLower(mediaType)] = d
+}
+
+// GetDecoder returns the Decoder registered for the media type of
+// contentType, for its structured syntax suffix or for its type, in that order.
+func GetDecoder(contentType string) (Decoder, bool) {
+	mt := mediaType(contentType)
+	if mt == "" {
+		return nil, false
+	}
+	candidates := []string{mt}
+	if i := strings.LastIndexByte(mt, '+'); i >= 0 {
+		candidates = append(candidates, mt[i:])
+	}
+	if typ, _, ok := strings.Cut(mt, "/"); ok {
+		candidates = append(candidates, typ+"/*")
+	}
+
+	decodersLock.RLock()
+	defer decodersLock.RUnlock()
+	for _, c := range candidates {
+		if d, ok := decoders[c]; ok {
+			return d, true
+		}
+	}
+	return nil, false
+}
+
+// decodeValue returns the Go representation of the data of e, or the data as
+// is if its content type has no decoder or it cannot be decoded.
+func decodeValue(e *TopicEvent, data []byte) any {
+	if len(data) == 0 {
+		return data
+	}
+	d, ok := GetDecoder(e.DataContentType)
+	if !ok {
+		return data
+	}
+	v, err := d.Value(e, data)
+	if err != nil {
+		return data
+	}
+	return v
+}
+
+type jsonDecoder struct{}
+
+func (jsonDecoder) Decode(_ *TopicEvent, data []byte, target any) error {
+	return json.Unmarshal(data, target)
+}
+
+func (jsonDecoder) Value(_ *TopicEvent, data []byte) (any, error) {
+	var v any
+	if err := json.Unmarshal(data, &v); err != nil {
+		return nil, err
+	}
+	return v, nil
+}
+
+type xmlDecoder struct{}
+
+func (xmlDecoder) Decode(_ *TopicEvent, data []byte, target any) error {
+	return xml.Unmarshal(data, target)
+}
+
+// Value returns XML as is, since it has no generic Go representation.
+func (xmlDecoder) Value(_ *TopicEvent, data []byte) (any, error) {
+	return data, nil
+}
+
+// textDecoder decodes text into strings, byte slices and
+// encoding.TextUnmarshaler implementations, and other targets from JSON.
+type textDecoder struct{}
+
+func (textDecoder) Decode(e *TopicEvent, data []byte, target any) error {
+	switch t := target.(type) {
+	case *string:
+		*t = string(data)
+	case *[]byte:
+		*t = append([]byte(nil), data...)
+	case encoding.TextUnmarshaler:
+		return t.UnmarshalText(data)
+	default:
+		return jsonDecoder{}.Decode(e, data, target)
+	}
+	return nil
+}
+
+// Value assumes UTF-8 encoded text.
+func (textDecoder) Value(_ *TopicEvent, data []byte) (any, error) {
+	return string(data), nil
+}
+
+// bytesDecoder decodes binary data into byte slices and
+// encoding.BinaryUnmarshaler implementations, and other targets from JSON.
+type bytesDecoder struct{}
+
+func (bytesDecoder) Decode(e *TopicEvent, data []byte, target any) error {
+	switch t := target.(type) {
+	case *[]byte:
+		*t = append([]byte(nil), data...)
+	case encoding.BinaryUnmarshaler:
+		return t.UnmarshalBinary(data)
+	default:
+		return jsonDecoder{}.Decode(e, data, target)
+	}
+	return nil
+}
+
+func (bytesDecoder) Value(_ *TopicEvent, data []byte) (any, error) {
+	return data, nil
+}
+
+// protoDecoder decodes protobuf messages. Targets which are not proto.Message
+// implementations are decoded from the JSON mapping of the message type named
+// by the messageType or proto parameter of the content type, or by the
+// dataschema of the event, as a type URL or full name.
+type protoDecoder struct{}
+
+func (protoDecoder) Decode(e *TopicEvent, data []byte, target any) error {
+	if m, ok := target.(proto.Message); ok {
+		return proto.Unmarshal(data, m)
+	}
+	b, err := protoJSON(e, data)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(b, target)
+}
+
+func (protoDecoder) Value(e *TopicEvent, data []byte) (any, error) {
+	b, err := protoJSON(e, data)
+	if err != nil {
+		return nil, err
+	}
+	return jsonDecoder{}.Value(e, b)
+}
+
+func protoJSON(e *TopicEvent, data []byte) ([]byte, error) {
+	mt, err := protoMessageType(e)
+	if err != nil {
+		return nil, err
+	}
+	m := mt.New().Interface()
+	if err = proto.Unmarshal(data, m); err != nil {
+		return nil, err
+	}
+	return protojson.Marshal(m)
+}
+
+func protoMessageType(e *TopicEvent) (protoreflect.MessageType, error) {
+	var name string
+	if _, params, err := mime.ParseMediaType(e.DataContentType); err == nil {
+		name = params["messagetype"]
+		if name == "" {
+			name = params["proto"]
+		}
+	}
+	if name == "" {
+		name = e.DataSchema
+	}
+	if name == "" {
+		return nil, errors.New("protobuf message type unknown: no messageType content type parameter or dataschema")
+	}
+	mt, err := protoregistry.GlobalTypes.FindMessageByURL(name)
+	if err != nil {
+		return nil, fmt.Errorf("protobuf message type %s: %w", name, err)
+	}
+	return mt, nil
+}
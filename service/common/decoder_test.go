@@ -0,0 +1,148 @@
+/*
+Copyright 2026 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package common
+
+import (
+	"bytes"
+	"net/netip"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+
+	commonv1pb "github.com/dapr/dapr/pkg/proto/common/v1"
+)
+
+type order struct {
+	ID    int    `json:"id" xml:"id"`
+	Items string `json:"items" xml:"items"`
+}
+
+func TestTopicEventStruct(t *testing.T) {
+	t.Run("json", func(t *testing.T) {
+		for _, ct := range []string{"", "application/json", "application/cloudevents+json; charset=utf-8", "application/unknown"} {
+			var o order
+			e := &TopicEvent{DataContentType: ct, RawData: []byte(`{"id":1,"items":"a"}`)}
+			require.NoError(t, e.Struct(&o), ct)
+			assert.Equal(t, order{ID: 1, Items: "a"}, o, ct)
+		}
+	})
+
+	t.Run("xml", func(t *testing.T) {
+		for _, ct := range []string{"application/xml", "text/xml", "application/atom+xml"} {
+			var o order
+			e := &TopicEvent{DataContentType: ct, RawData: []byte(`<order><id>1</id><items>a</items></order>`)}
+			require.NoError(t, e.Struct(&o), ct)
+			assert.Equal(t, order{ID: 1, Items: "a"}, o, ct)
+		}
+	})
+
+	t.Run("text", func(t *testing.T) {
+		e := &TopicEvent{DataContentType: "text/csv", RawData: []byte("a,b")}
+		var s string
+		require.NoError(t, e.Struct(&s))
+		assert.Equal(t, "a,b", s)
+
+		e = &TopicEvent{DataContentType: "text/plain", RawData: []byte("10.0.0.1")}
+		var addr netip.Addr
+		require.NoError(t, e.Struct(&addr))
+		assert.Equal(t, netip.MustParseAddr("10.0.0.1"), addr)
+
+		e = &TopicEvent{DataContentType: "text/plain", RawData: []byte(`{"id":1}`)}
+		var o order
+		require.NoError(t, e.Struct(&o))
+		assert.Equal(t, 1, o.ID)
+	})
+
+	t.Run("binary", func(t *testing.T) {
+		e := &TopicEvent{DataContentType: "application/octet-stream", RawData: []byte{0, 1, 2}}
+		var b []byte
+		require.NoError(t, e.Struct(&b))
+		assert.Equal(t, []byte{0, 1, 2}, b)
+	})
+
+	t.Run("protobuf", func(t *testing.T) {
+		data, err := proto.Marshal(&commonv1pb.StateItem{Key: "k", Etag: &commonv1pb.Etag{Value: "1"}})
+		require.NoError(t, err)
+
+		var item commonv1pb.StateItem
+		e := &TopicEvent{DataContentType: "application/protobuf", RawData: data}
+		require.NoError(t, e.Struct(&item))
+		assert.Equal(t, "k", item.GetKey())
+
+		var m map[string]any
+		require.Error(t, e.Struct(&m), "message type unknown")
+
+		expected := map[string]any{"key": "k", "etag": map[string]any{"value": "1"}}
+		e.DataSchema = "type.googleapis.com/dapr.proto.common.v1.StateItem"
+		require.NoError(t, e.Struct(&m))
+		assert.Equal(t, expected, m)
+		assert.Equal(t, expected, DecodeEventData(e))
+
+		m = nil
+		e = &TopicEvent{DataContentType: "application/x-protobuf; messageType=dapr.proto.common.v1.StateItem", RawData: data}
+		require.NoError(t, e.Struct(&m))
+		assert.Equal(t, expected, m)
+	})
+}
+
+func TestDecodeData(t *testing.T) {
+	assert.Equal(t, map[string]any{"id": float64(1)}, DecodeData("application/json", []byte(`{"id":1}`)))
+	assert.Equal(t, "hello", DecodeData("text/plain; charset=utf-8", []byte("hello")))
+	assert.Equal(t, "a,b", DecodeData("text/csv", []byte("a,b")))
+	assert.Equal(t, []byte("<a/>"), DecodeData("application/xml", []byte("<a/>")))
+	assert.Equal(t, []byte(`{"id":`), DecodeData("application/json", []byte(`{"id":`)), "invalid data is kept as is")
+	assert.Equal(t, []byte("data"), DecodeData("", []byte("data")))
+	assert.Equal(t, []byte("data"), DecodeData("application/protobuf", []byte("data")))
+}
+
+// upperDecoder decodes text into upper case.
+type upperDecoder struct{}
+
+func (upperDecoder) Decode(e *TopicEvent, data []byte, target any) error {
+	*target.(*string) = string(bytes.ToUpper(data))
+	return nil
+}
+
+func (upperDecoder) Value(e *TopicEvent, data []byte) (any, error) {
+	return string(bytes.ToUpper(data)), nil
+}
+
+func TestRegisterDecoder(t *testing.T) {
+	RegisterDecoder("application/vnd.upper", upperDecoder{})
+	RegisterDecoder("+upper", upperDecoder{})
+	t.Cleanup(func() {
+		decodersLock.Lock()
+		delete(decoders, "application/vnd.upper")
+		delete(decoders, "+upper")
+		decodersLock.Unlock()
+	})
+
+	for _, ct := range []string{"application/vnd.upper", "Application/Vnd.Upper; v=1", "application/vnd.order+upper"} {
+		_, ok := GetDecoder(ct)
+		assert.True(t, ok, ct)
+
+		var s string
+		e := &TopicEvent{DataContentType: ct, RawData: []byte("hello")}
+		require.NoError(t, e.Struct(&s))
+		assert.Equal(t, "HELLO", s)
+		assert.Equal(t, "HELLO", DecodeEventData(e))
+	}
+
+	_, ok := GetDecoder("application/vnd.lower")
+	assert.False(t, ok)
+	_, ok = GetDecoder("")
+	assert.False(t, ok)
+}
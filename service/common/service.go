@@ -16,6 +16,8 @@ package common
 import (
 	"context"
 
+	"go.opentelemetry.io/otel/trace"
+
 	"github.com/dapr/go-sdk/actor"
 	"github.com/dapr/go-sdk/actor/config"
 )
@@ -76,3 +78,21 @@ type TopicEventSubscriber interface {
 func (h TopicEventHandler) Handle(ctx context.Context, e *TopicEvent) (retry bool, err error) {
 	return h(ctx, e)
 }
+
+// TopicEventMiddleware wraps a TopicEventSubscriber, like the Subscriber
+// methods of the middleware package.
+type TopicEventMiddleware func(next TopicEventSubscriber) TopicEventSubscriber
+
+// TopicEventMiddlewareUser is implemented by the HTTP and gRPC services.
+// Middleware passed to UseTopicEventMiddleware wraps every topic event handler
+// and subscriber added afterwards, the first middleware receiving events first.
+type TopicEventMiddlewareUser interface {
+	UseTopicEventMiddleware(mw ...TopicEventMiddleware)
+}
+
+// TracerProviderUser is implemented by the HTTP and gRPC services. The spans
+// of the calls of Dapr to the app are started with the tracer provider passed
+// to UseTracerProvider, or with the global one if none is set.
+type TracerProviderUser interface {
+	UseTracerProvider(tp trace.TracerProvider)
+}
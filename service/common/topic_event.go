@@ -0,0 +1,132 @@
+/*
+Copyright 2026 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package common
+
+import (
+	"mime"
+	"strings"
+
+	runtimev1pb "github.com/dapr/dapr/pkg/proto/runtime/v1"
+)
+
+// topicEventAttributes are the CloudEvent and Dapr attributes which are mapped
+// to fields of TopicEvent.
+var topicEventAttributes = map[string]struct{}{
+	"id":              {},
+	"specversion":     {},
+	"type":            {},
+	"source":          {},
+	"datacontenttype": {},
+	"data":            {},
+	"data_base64":     {},
+	"subject":         {},
+	"topic":           {},
+	"pubsubname":      {},
+	"traceid":         {},
+	"traceparent":     {},
+	"tracestate":      {},
+	"time":            {},
+	"dataschema":      {},
+}
+
+// ExtensionAttributes returns the attributes of a CloudEvent which are not
+// mapped to a field of TopicEvent.
+func ExtensionAttributes(attrs map[string]any) map[string]any {
+	var ext map[string]any
+	for k, v := range attrs {
+		if _, ok := topicEventAttributes[k]; ok {
+			continue
+		}
+		if ext == nil {
+			ext = make(map[string]any)
+		}
+		ext[k] = v
+	}
+	return ext
+}
+
+// NewTopicEvent returns the TopicEvent for an event delivered by Dapr over gRPC,
+// either to the app callback or to a streaming subscription. The data is
+// decompressed and decoded according to its content type. CloudEvent attributes
+// sent as extensions are mapped to their fields, and the remaining extensions
+// are kept in Extensions.
+//
+// If maxDecompressedSize is positive, data compressed with CompressPayload is
+// decompressed first, up to maxDecompressedSize bytes. Otherwise, it is kept
+// as received.
+func NewTopicEvent(in *runtimev1pb.TopicEventRequest, meta map[string]string, maxDecompressedSize int64) (*TopicEvent, error) {
+	rawData, contentType := in.GetData(), in.GetDataContentType()
+	if maxDecompressedSize > 0 {
+		var err error
+		if rawData, contentType, err = DecompressPayloadLimit(rawData, contentType, maxDecompressedSize); err != nil {
+			return nil, err
+		}
+	}
+
+	ext := in.GetExtensions().AsMap()
+	e := &TopicEvent{
+		ID:              in.GetId(),
+		Source:          in.GetSource(),
+		Type:            in.GetType(),
+		SpecVersion:     in.GetSpecVersion(),
+		DataContentType: contentType,
+		RawData:         rawData,
+		Topic:           in.GetTopic(),
+		PubsubName:      in.GetPubsubName(),
+		Metadata:        meta,
+		Subject:         stringAttribute(ext, "subject"),
+		TraceID:         stringAttribute(ext, "traceid"),
+		TraceParent:     stringAttribute(ext, "traceparent"),
+		TraceState:      stringAttribute(ext, "tracestate"),
+		Time:            stringAttribute(ext, "time"),
+		DataSchema:      stringAttribute(ext, "dataschema"),
+		Extensions:      ExtensionAttributes(ext),
+	}
+	e.Data = DecodeEventData(e)
+	return e, nil
+}
+
+func stringAttribute(attrs map[string]any, name string) string {
+	s, _ := attrs[name].(string)
+	return s
+}
+
+// DecodeData decodes event data into its Go representation with the decoder
+// registered for contentType. Content without a decoder, or which cannot be
+// decoded, is returned as is.
+func DecodeData(contentType string, rawData []byte) any {
+	return DecodeEventData(&TopicEvent{DataContentType: contentType, RawData: rawData})
+}
+
+// DecodeEventData is DecodeData for the RawData of e, which also makes the
+// other attributes of e, such as its dataschema, available to the decoder.
+func DecodeEventData(e *TopicEvent) any {
+	return decodeValue(e, e.RawData)
+}
+
+// IsJSONContentType reports whether contentType is application/json or a
+// structured syntax suffix type such as application/cloudevents+json.
+func IsJSONContentType(contentType string) bool {
+	mt := mediaType(contentType)
+	return mt == "application/json" ||
+		strings.HasPrefix(mt, "application/") && strings.HasSuffix(mt, "+json")
+}
+
+func mediaType(contentType string) string {
+	mt, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return ""
+	}
+	return mt
+}
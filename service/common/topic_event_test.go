@@ -0,0 +1,101 @@
+/*
+Copyright 2026 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package common
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/types/known/structpb"
+
+	runtimev1pb "github.com/dapr/dapr/pkg/proto/runtime/v1"
+)
+
+func TestNewTopicEvent(t *testing.T) {
+	t.Run("maps attributes and extensions", func(t *testing.T) {
+		ext, err := structpb.NewStruct(map[string]any{
+			"subject":     "orders/1",
+			"dataschema":  "https://example.com/order.json",
+			"traceparent": "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01",
+			"tenant":      "acme",
+		})
+		require.NoError(t, err)
+
+		e, err := NewTopicEvent(&runtimev1pb.TopicEventRequest{
+			Id:              "1",
+			Source:          "orders",
+			Type:            "order.created",
+			SpecVersion:     "1.0",
+			DataContentType: "application/cloudevents+json",
+			Data:            []byte(`{"id":1}`),
+			Topic:           "orders",
+			PubsubName:      "messages",
+			Extensions:      ext,
+		}, map[string]string{"key": "value"}, 0)
+		require.NoError(t, err)
+		assert.Equal(t, "1", e.ID)
+		assert.Equal(t, "orders", e.Source)
+		assert.Equal(t, "order.created", e.Type)
+		assert.Equal(t, map[string]any{"id": float64(1)}, e.Data)
+		assert.Equal(t, "orders/1", e.Subject)
+		assert.Equal(t, "https://example.com/order.json", e.DataSchema)
+		assert.Equal(t, "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01", e.TraceParent)
+		assert.Equal(t, map[string]any{"tenant": "acme"}, e.Extensions)
+		assert.Equal(t, map[string]string{"key": "value"}, e.Metadata)
+	})
+
+	t.Run("decompresses data", func(t *testing.T) {
+		data, err := CompressPayload(CompressionGzip, "text/plain", []byte("hello"))
+		require.NoError(t, err)
+
+		e, err := NewTopicEvent(&runtimev1pb.TopicEventRequest{
+			DataContentType: CompressedContentType,
+			Data:            data,
+		}, nil, DefaultMaxDecompressedSize)
+		require.NoError(t, err)
+		assert.Equal(t, "text/plain", e.DataContentType)
+		assert.Equal(t, "hello", e.Data)
+		assert.Nil(t, e.Extensions)
+	})
+
+	t.Run("decompression disabled", func(t *testing.T) {
+		data, err := CompressPayload(CompressionGzip, "text/plain", []byte("hello"))
+		require.NoError(t, err)
+
+		e, err := NewTopicEvent(&runtimev1pb.TopicEventRequest{
+			DataContentType: CompressedContentType,
+			Data:            data,
+		}, nil, 0)
+		require.NoError(t, err)
+		assert.Equal(t, CompressedContentType, e.DataContentType)
+		assert.Equal(t, data, e.RawData)
+	})
+
+	t.Run("decompressed data too large", func(t *testing.T) {
+		data, err := CompressPayload(CompressionGzip, "text/plain", []byte("hello"))
+		require.NoError(t, err)
+
+		_, err = NewTopicEvent(&runtimev1pb.TopicEventRequest{Data: data}, nil, 4)
+		require.ErrorIs(t, err, ErrDecompressedSizeExceeded)
+	})
+
+	t.Run("corrupt compressed data", func(t *testing.T) {
+		data, err := CompressPayload(CompressionGzip, "text/plain", []byte("hello"))
+		require.NoError(t, err)
+
+		_, err = NewTopicEvent(&runtimev1pb.TopicEventRequest{Data: data[:len(data)-4]}, nil, DefaultMaxDecompressedSize)
+		require.Error(t, err)
+	})
+}
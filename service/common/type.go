@@ -13,10 +13,6 @@ limitations under the License.
 
 package common
 
-import (
-	"encoding/json"
-)
-
 // TopicEvent is the content of the inbound topic message.
 type TopicEvent struct {
 	// ID identifies the event.
@@ -51,12 +47,26 @@ type TopicEvent struct {
 	TraceID string `json:"traceid"`
 	// TraceParent is name of the parent trace identifier for the incoming event
 	TraceParent string `json:"traceparent"`
+	// TraceState is the vendor-specific trace state for the incoming event
+	TraceState string `json:"tracestate,omitempty"`
+	// Time is the time the event occurred, as set by the producer
+	Time string `json:"time,omitempty"`
+	// DataSchema identifies the schema that data adheres to
+	DataSchema string `json:"dataschema,omitempty"`
+	// Extensions are the CloudEvent extension attributes of the event
+	// which are not mapped to any of the fields above.
+	Extensions map[string]any `json:"-"`
 }
 
+// Struct decodes RawData into target with the decoder registered for
+// DataContentType, see RegisterDecoder. Data without a content type, or of a
+// content type without a decoder, is decoded as JSON.
 func (e *TopicEvent) Struct(target interface{}) error {
-	// TODO: Enhance to inspect DataContentType for the best
-	// deserialization method.
-	return json.Unmarshal(e.RawData, target)
+	d, ok := GetDecoder(e.DataContentType)
+	if !ok {
+		d = jsonDecoder{}
+	}
+	return d.Decode(e, e.RawData, target)
 }
 
 // InvocationEvent represents the input and output of binding invocation.
@@ -109,6 +119,19 @@ type Subscription struct {
 	DisableTopicValidation bool `json:"disableTopicValidation"`
 	// DeadLetterTopic is the name of the deadletter topic.
 	DeadLetterTopic string `json:"deadLetterTopic"`
+	// BulkSubscribe, if set, has Dapr deliver the events of the topic in bulk.
+	// Handlers still receive them one at a time.
+	BulkSubscribe *BulkSubscribeOptions `json:"bulkSubscribe,omitempty"`
+}
+
+// BulkSubscribeOptions are the bulk delivery settings of a subscription.
+// Zero values use the defaults of Dapr.
+type BulkSubscribeOptions struct {
+	// MaxMessagesCount is the maximum number of events delivered at once.
+	MaxMessagesCount int32 `json:"maxMessagesCount,omitempty"`
+	// MaxAwaitDurationMs is how long Dapr waits for events to fill a
+	// delivery, in milliseconds.
+	MaxAwaitDurationMs int32 `json:"maxAwaitDurationMs,omitempty"`
 }
 
 type SubscriptionResponseStatus string
@@ -18,10 +18,12 @@ import (
 	"errors"
 	"fmt"
 
+	"go.opentelemetry.io/otel/attribute"
 	"google.golang.org/protobuf/types/known/emptypb"
 
 	pb "github.com/dapr/dapr/pkg/proto/runtime/v1"
 	"github.com/dapr/go-sdk/service/common"
+	"github.com/dapr/go-sdk/service/internal"
 )
 
 // AddBindingInvocationHandler appends provided binding invocation handler with its name to the service.
@@ -59,7 +61,10 @@ func (s *Server) OnBindingEvent(ctx context.Context, in *pb.BindingEventRequest)
 			Data:     in.GetData(),
 			Metadata: in.GetMetadata(),
 		}
+		ctx, span := internal.StartSpan(internal.IncomingTraceContext(ctx), s.tracerProvider, "binding "+in.GetName(),
+			attribute.String("dapr.component", in.GetName()))
 		data, err := fn(ctx, e)
+		internal.EndSpan(span, err)
 		if err != nil {
 			return nil, fmt.Errorf("error executing %s binding: %w", in.GetName(), err)
 		}
@@ -18,11 +18,13 @@ import (
 	"errors"
 	"fmt"
 
+	"go.opentelemetry.io/otel/attribute"
 	"google.golang.org/grpc/metadata"
 	"google.golang.org/protobuf/types/known/anypb"
 
 	cpb "github.com/dapr/dapr/pkg/proto/common/v1"
 	cc "github.com/dapr/go-sdk/service/common"
+	"github.com/dapr/go-sdk/service/internal"
 )
 
 // AddServiceInvocationHandler appends provided service invocation handler with its method to the service.
@@ -65,6 +67,12 @@ func (s *Server) OnInvoke(ctx context.Context, in *cpb.InvokeRequest) (*cpb.Invo
 		if in.GetData() != nil {
 			e.Data = in.GetData().GetValue()
 			e.DataTypeURL = in.GetData().GetTypeUrl()
+			if s.maxDecompressedSize > 0 {
+				var err error
+				if e.Data, e.ContentType, err = cc.DecompressPayloadLimit(e.Data, e.ContentType, s.maxDecompressedSize); err != nil {
+					return nil, fmt.Errorf("invalid invocation content: %w", err)
+				}
+			}
 		}
 
 		if in.GetHttpExtension() != nil {
@@ -72,7 +80,10 @@ func (s *Server) OnInvoke(ctx context.Context, in *cpb.InvokeRequest) (*cpb.Invo
 			e.QueryString = in.GetHttpExtension().GetQuerystring()
 		}
 
+		ctx, span := internal.StartSpan(internal.IncomingTraceContext(ctx), s.tracerProvider, "invoke "+in.GetMethod(),
+			attribute.String("dapr.method", in.GetMethod()))
 		ct, er := fn(ctx, e)
+		internal.EndSpan(span, er)
 		if er != nil {
 			return nil, er
 		}
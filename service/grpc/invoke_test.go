@@ -134,6 +134,29 @@ func TestInvoke(t *testing.T) {
 		assert.Equal(t, data, string(out.GetData().GetValue()))
 	})
 
+	t.Run("invoke request with compressed data", func(t *testing.T) {
+		data := "hello there"
+		compressed, err := cc.CompressPayload(cc.CompressionGzip, "text/plain", []byte(data))
+		require.NoError(t, err)
+		in := &common.InvokeRequest{Method: methodName}
+		in.Data = &anypb.Any{Value: compressed}
+		in.ContentType = cc.CompressedContentType
+		out, err := server.OnInvoke(ctx, in)
+		require.NoError(t, err)
+		assert.Equal(t, cc.CompressedContentType, out.GetContentType())
+		assert.Equal(t, compressed, out.GetData().GetValue())
+
+		server.EnableDecompression(0)
+		out, err = server.OnInvoke(ctx, in)
+		require.NoError(t, err)
+		assert.Equal(t, "text/plain", out.GetContentType())
+		assert.Equal(t, data, string(out.GetData().GetValue()))
+
+		server.EnableDecompression(4)
+		_, err = server.OnInvoke(ctx, in)
+		require.ErrorIs(t, err, cc.ErrDecompressedSizeExceeded)
+	})
+
 	t.Run("invoke request with error", func(t *testing.T) {
 		data := "hello there"
 		dataContentType := "text/plain"
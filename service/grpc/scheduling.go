@@ -6,8 +6,11 @@ import (
 	"fmt"
 	"strings"
 
+	"go.opentelemetry.io/otel/attribute"
+
 	runtimepb "github.com/dapr/dapr/pkg/proto/runtime/v1"
 	"github.com/dapr/go-sdk/service/common"
+	"github.com/dapr/go-sdk/service/internal"
 )
 
 // AddJobEventHandler registers a job handler
@@ -41,7 +44,11 @@ func (s *Server) OnJobEventAlpha1(ctx context.Context, in *runtimepb.JobEventReq
 			JobType: jobType,
 			Data:    in.GetData().GetValue(),
 		}
-		if err := fn(ctx, e); err != nil {
+		ctx, span := internal.StartSpan(internal.IncomingTraceContext(ctx), s.tracerProvider, "job "+jobType,
+			attribute.String("dapr.job.name", jobType))
+		err := fn(ctx, e)
+		internal.EndSpan(span, err)
+		if err != nil {
 			return nil, fmt.Errorf("error executing %s binding: %w", in.GetName(), err)
 		}
 		return &runtimepb.JobEventResponse{}, nil
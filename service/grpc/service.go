@@ -20,6 +20,7 @@ import (
 	"os"
 	"sync/atomic"
 
+	"go.opentelemetry.io/otel/trace"
 	"google.golang.org/grpc"
 
 	pb "github.com/dapr/dapr/pkg/proto/runtime/v1"
@@ -88,6 +89,32 @@ type Server struct {
 	authToken          string
 	grpcServer         *grpc.Server
 	started            uint32
+	// maxDecompressedSize enables the decompression of payloads when positive.
+	maxDecompressedSize int64
+	topicMiddleware     []common.TopicEventMiddleware
+	// tracerProvider starts the spans of calls, the global one if nil.
+	tracerProvider trace.TracerProvider
+}
+
+// EnableDecompression decompresses the compressed payloads of invocations and
+// topic events before they are passed to handlers, up to maxSize bytes.
+func (s *Server) EnableDecompression(maxSize int64) {
+	if maxSize <= 0 {
+		maxSize = common.DefaultMaxDecompressedSize
+	}
+	s.maxDecompressedSize = maxSize
+}
+
+// UseTracerProvider starts the spans of the calls of Dapr to the app with tp
+// rather than with the global tracer provider.
+func (s *Server) UseTracerProvider(tp trace.TracerProvider) {
+	s.tracerProvider = tp
+}
+
+// UseTopicEventMiddleware wraps the topic event handlers and subscribers added
+// afterwards with mw, the first middleware receiving events first.
+func (s *Server) UseTopicEventMiddleware(mw ...common.TopicEventMiddleware) {
+	s.topicMiddleware = append(s.topicMiddleware, mw...)
 }
 
 // Deprecated: Use RegisterActorImplFactoryContext instead.
@@ -15,20 +15,18 @@ package grpc
 
 import (
 	"context"
-	"encoding/json"
 	"errors"
 	"fmt"
-	"mime"
+	"maps"
 	"strings"
 
-	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/metadata"
-	"google.golang.org/grpc/status"
 	"google.golang.org/protobuf/types/known/emptypb"
 
 	runtimev1pb "github.com/dapr/dapr/pkg/proto/runtime/v1"
 	"github.com/dapr/go-sdk/service/common"
 	"github.com/dapr/go-sdk/service/internal"
+	"github.com/dapr/go-sdk/service/manifest"
 )
 
 // AddTopicEventHandler appends provided event handler with topic name to the service.
@@ -46,7 +44,23 @@ func (s *Server) AddTopicEventSubscriber(sub *common.Subscription, subscriber co
 		return errors.New("subscription required")
 	}
 
-	return s.topicRegistrar.AddSubscription(sub, subscriber)
+	if subscriber == nil {
+		return errors.New("topic handler required")
+	}
+
+	return s.topicRegistrar.AddSubscription(sub, internal.WrapSubscriber(subscriber, s.topicMiddleware))
+}
+
+// TopicSubscriptions returns the declarative subscriptions of the registered
+// topic handlers, as used by the manifest package.
+func (s *Server) TopicSubscriptions() []manifest.SubscriptionSpec {
+	return s.topicRegistrar.Specs()
+}
+
+// TopicEventRoute returns the route Dapr selects for e given the routing rules
+// of the registered topic handlers, as used by the routing package.
+func (s *Server) TopicEventRoute(e *common.TopicEvent) (string, error) {
+	return s.topicRegistrar.Route(e)
 }
 
 // ListTopicSubscriptions is called by Dapr to get the list of topics in a pubsub component the app wants to subscribe to.
@@ -61,6 +75,13 @@ func (s *Server) ListTopicSubscriptions(ctx context.Context, in *emptypb.Empty)
 			Routes:          convertRoutes(s.Routes),
 			DeadLetterTopic: s.DeadLetterTopic,
 		}
+		if b := s.BulkSubscribe; b != nil {
+			sub.BulkSubscribe = &runtimev1pb.BulkSubscribeConfig{
+				Enabled:            b.Enabled,
+				MaxMessagesCount:   b.MaxMessagesCount,
+				MaxAwaitDurationMs: b.MaxAwaitDurationMs,
+			}
+		}
 		subs = append(subs, sub)
 	}
 
@@ -89,6 +110,10 @@ func convertRoutes(routes *internal.TopicRoutes) *runtimev1pb.TopicRoutes {
 // OnTopicEvent fired whenever a message has been published to a topic that has been subscribed.
 // Dapr sends published messages in a CloudEvents v1.0 envelope.
 func (s *Server) OnTopicEvent(ctx context.Context, in *runtimev1pb.TopicEventRequest) (*runtimev1pb.TopicEventResponse, error) {
+	return s.onTopicEvent(ctx, in, getCustomMetadataFromContext(ctx))
+}
+
+func (s *Server) onTopicEvent(ctx context.Context, in *runtimev1pb.TopicEventRequest, meta map[string]string) (*runtimev1pb.TopicEventResponse, error) {
 	if in == nil || in.GetTopic() == "" || in.GetPubsubName() == "" {
 		// this is really Dapr issue more than the event request format.
 		// since Dapr will not get updated until long after this event expires, just drop it
@@ -106,41 +131,10 @@ func (s *Server) OnTopicEvent(ctx context.Context, in *runtimev1pb.TopicEventReq
 	}
 
 	if ok {
-		data := interface{}(in.GetData())
-		if len(in.GetData()) > 0 {
-			mediaType, _, err := mime.ParseMediaType(in.GetDataContentType())
-			if err == nil {
-				var v interface{}
-				switch mediaType {
-				case "application/json":
-					if err := json.Unmarshal(in.GetData(), &v); err == nil {
-						data = v
-					}
-				case "text/plain":
-					// Assume UTF-8 encoded string.
-					data = string(in.GetData())
-				default:
-					if strings.HasPrefix(mediaType, "application/") &&
-						strings.HasSuffix(mediaType, "+json") {
-						if err := json.Unmarshal(in.GetData(), &v); err == nil {
-							data = v
-						}
-					}
-				}
-			}
-		}
-
-		e := &common.TopicEvent{
-			ID:              in.GetId(),
-			Source:          in.GetSource(),
-			Type:            in.GetType(),
-			SpecVersion:     in.GetSpecVersion(),
-			DataContentType: in.GetDataContentType(),
-			Data:            data,
-			RawData:         in.GetData(),
-			Topic:           in.GetTopic(),
-			PubsubName:      in.GetPubsubName(),
-			Metadata:        getCustomMetadataFromContext(ctx),
+		e, err := common.NewTopicEvent(in, meta, s.maxDecompressedSize)
+		if err != nil {
+			// the payload will never be readable, so there is no point in retrying
+			return &runtimev1pb.TopicEventResponse{Status: runtimev1pb.TopicEventResponse_DROP}, nil
 		}
 		h := sub.DefaultHandler
 		if in.GetPath() != "" {
@@ -154,7 +148,9 @@ func (s *Server) OnTopicEvent(ctx context.Context, in *runtimev1pb.TopicEventReq
 				in.GetPath(), in.GetPubsubName(), in.GetTopic(),
 			)
 		}
+		ctx, span := internal.StartTopicEventSpan(internal.IncomingTraceContext(ctx), s.tracerProvider, e)
 		retry, err := h.Handle(ctx, e)
+		internal.EndSpan(span, err)
 		if err == nil {
 			return &runtimev1pb.TopicEventResponse{Status: runtimev1pb.TopicEventResponse_SUCCESS}, nil
 		}
@@ -182,8 +178,42 @@ func getCustomMetadataFromContext(ctx context.Context) map[string]string {
 	return md
 }
 
+// OnBulkTopicEvent is called by Dapr with the events of subscriptions with
+// bulk delivery enabled. The events are passed to the handler one at a time.
 func (s *Server) OnBulkTopicEvent(ctx context.Context, in *runtimev1pb.TopicEventBulkRequest) (*runtimev1pb.TopicEventBulkResponse, error) {
-	return nil, status.Error(codes.Unimplemented, "bulk pubsub callback is not supported")
+	statuses := make([]*runtimev1pb.TopicEventBulkResponseEntry, 0, len(in.GetEntries()))
+	for _, entry := range in.GetEntries() {
+		req := &runtimev1pb.TopicEventRequest{
+			Id:              entry.GetEntryId(),
+			DataContentType: entry.GetContentType(),
+			Data:            entry.GetBytes(),
+			Topic:           in.GetTopic(),
+			PubsubName:      in.GetPubsubName(),
+			Path:            in.GetPath(),
+		}
+		if ce := entry.GetCloudEvent(); ce != nil {
+			req.Id = ce.GetId()
+			req.Source = ce.GetSource()
+			req.Type = ce.GetType()
+			req.SpecVersion = ce.GetSpecVersion()
+			req.DataContentType = ce.GetDataContentType()
+			req.Data = ce.GetData()
+			req.Extensions = ce.GetExtensions()
+		}
+		meta := maps.Clone(in.GetMetadata())
+		if meta == nil {
+			meta = make(map[string]string, len(entry.GetMetadata()))
+		}
+		maps.Copy(meta, entry.GetMetadata())
+
+		// errors are reported to Dapr by the status of the entry
+		resp, _ := s.onTopicEvent(ctx, req, meta)
+		statuses = append(statuses, &runtimev1pb.TopicEventBulkResponseEntry{
+			EntryId: entry.GetEntryId(),
+			Status:  resp.GetStatus(),
+		})
+	}
+	return &runtimev1pb.TopicEventBulkResponse{Statuses: statuses}, nil
 }
 
 func (s *Server) OnBulkTopicEventAlpha1(ctx context.Context, in *runtimev1pb.TopicEventBulkRequest) (*runtimev1pb.TopicEventBulkResponse, error) {
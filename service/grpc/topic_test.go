@@ -23,6 +23,7 @@ import (
 
 	"github.com/stretchr/testify/assert"
 	"google.golang.org/protobuf/types/known/emptypb"
+	"google.golang.org/protobuf/types/known/structpb"
 
 	"github.com/dapr/dapr/pkg/proto/runtime/v1"
 	"github.com/dapr/go-sdk/service/common"
@@ -166,6 +167,105 @@ func TestTopic(t *testing.T) {
 	stopTestServer(t, server)
 }
 
+func TestTopicEventMiddleware(t *testing.T) {
+	server := getTestServer()
+	var calls []string
+	record := func(name string) common.TopicEventMiddleware {
+		return func(next common.TopicEventSubscriber) common.TopicEventSubscriber {
+			return common.TopicEventHandler(func(ctx context.Context, e *common.TopicEvent) (bool, error) {
+				calls = append(calls, name)
+				return next.Handle(ctx, e)
+			})
+		}
+	}
+	handler := func(ctx context.Context, e *common.TopicEvent) (bool, error) {
+		calls = append(calls, "handler")
+		return false, nil
+	}
+
+	require.NoError(t, server.AddTopicEventHandler(&common.Subscription{PubsubName: "messages", Topic: "before"}, handler))
+	server.UseTopicEventMiddleware(record("first"), record("second"))
+	require.NoError(t, server.AddTopicEventHandler(&common.Subscription{PubsubName: "messages", Topic: "after"}, handler))
+
+	for _, topic := range []string{"before", "after"} {
+		_, err := server.OnTopicEvent(t.Context(), &runtime.TopicEventRequest{
+			Id:              "a123",
+			Source:          "test",
+			Type:            "test",
+			SpecVersion:     "v1.0",
+			DataContentType: "text/plain",
+			Data:            []byte("test"),
+			Topic:           topic,
+			PubsubName:      "messages",
+		})
+		require.NoError(t, err)
+	}
+	assert.Equal(t, []string{"handler", "first", "second", "handler"}, calls)
+}
+
+func TestBulkTopic(t *testing.T) {
+	server := getTestServer()
+	sub := &common.Subscription{
+		PubsubName:    "messages",
+		Topic:         "test",
+		BulkSubscribe: &common.BulkSubscribeOptions{MaxMessagesCount: 10, MaxAwaitDurationMs: 500},
+	}
+	var events []*common.TopicEvent
+	handler := func(ctx context.Context, e *common.TopicEvent) (bool, error) {
+		events = append(events, e)
+		if e.ID == "retry" {
+			return true, errors.New("retry")
+		}
+		return false, nil
+	}
+	require.NoError(t, server.AddTopicEventHandler(sub, handler))
+
+	subs, err := server.ListTopicSubscriptions(t.Context(), &emptypb.Empty{})
+	require.NoError(t, err)
+	require.Len(t, subs.GetSubscriptions(), 1)
+	bulk := subs.GetSubscriptions()[0].GetBulkSubscribe()
+	assert.True(t, bulk.GetEnabled())
+	assert.Equal(t, int32(10), bulk.GetMaxMessagesCount())
+	assert.Equal(t, int32(500), bulk.GetMaxAwaitDurationMs())
+
+	resp, err := server.OnBulkTopicEvent(t.Context(), &runtime.TopicEventBulkRequest{
+		PubsubName: "messages",
+		Topic:      "test",
+		Metadata:   map[string]string{"a": "1", "b": "1"},
+		Entries: []*runtime.TopicEventBulkRequestEntry{
+			{
+				EntryId:     "1",
+				Event:       &runtime.TopicEventBulkRequestEntry_Bytes{Bytes: []byte("raw")},
+				ContentType: "text/plain",
+				Metadata:    map[string]string{"b": "2"},
+			},
+			{
+				EntryId: "2",
+				Event: &runtime.TopicEventBulkRequestEntry_CloudEvent{CloudEvent: &runtime.TopicEventCERequest{
+					Id:              "retry",
+					Source:          "test",
+					Type:            "test",
+					SpecVersion:     "1.0",
+					DataContentType: "text/plain",
+					Data:            []byte("cloud event"),
+				}},
+			},
+		},
+	})
+	require.NoError(t, err)
+	require.Len(t, resp.GetStatuses(), 2)
+	assert.Equal(t, "1", resp.GetStatuses()[0].GetEntryId())
+	assert.Equal(t, runtime.TopicEventResponse_SUCCESS, resp.GetStatuses()[0].GetStatus())
+	assert.Equal(t, "2", resp.GetStatuses()[1].GetEntryId())
+	assert.Equal(t, runtime.TopicEventResponse_RETRY, resp.GetStatuses()[1].GetStatus())
+
+	require.Len(t, events, 2)
+	assert.Equal(t, []byte("raw"), events[0].RawData)
+	assert.Equal(t, map[string]string{"a": "1", "b": "2"}, events[0].Metadata)
+	assert.Equal(t, "test", events[1].Source)
+	assert.Equal(t, "cloud event", events[1].Data)
+}
+
 func TestTopicWithValidationDisabled(t *testing.T) {
 	ctx := t.Context()
 
@@ -343,3 +443,126 @@ func TestEventDataHandling(t *testing.T) {
 		})
 	}
 }
+
+func TestCompressedEventDataHandling(t *testing.T) {
+	ctx := t.Context()
+	s := getTestServer()
+
+	sub := &common.Subscription{
+		PubsubName: "messages",
+		Topic:      "test",
+	}
+	var topicEvent *common.TopicEvent
+	err := s.AddTopicEventHandler(sub, func(ctx context.Context, e *common.TopicEvent) (retry bool, err error) {
+		topicEvent = e
+		return false, nil
+	})
+	require.NoError(t, err)
+	data, err := common.CompressPayload(common.CompressionGzip, "application/json", []byte(`{"message":"hello"}`))
+	require.NoError(t, err)
+
+	t.Run("compressed payload is passed through by default", func(t *testing.T) {
+		resp, err := s.OnTopicEvent(ctx, &runtime.TopicEventRequest{
+			Id:              "a123",
+			DataContentType: common.CompressedContentType,
+			Data:            data,
+			Topic:           sub.Topic,
+			PubsubName:      sub.PubsubName,
+		})
+		require.NoError(t, err)
+		assert.Equal(t, runtime.TopicEventResponse_SUCCESS, resp.GetStatus())
+		assert.Equal(t, common.CompressedContentType, topicEvent.DataContentType)
+		assert.Equal(t, data, topicEvent.RawData)
+	})
+
+	s.EnableDecompression(0)
+
+	t.Run("compressed payload is decompressed", func(t *testing.T) {
+
+		resp, err := s.OnTopicEvent(ctx, &runtime.TopicEventRequest{
+			Id:              "a123",
+			DataContentType: common.CompressedContentType,
+			Data:            data,
+			Topic:           sub.Topic,
+			PubsubName:      sub.PubsubName,
+		})
+		require.NoError(t, err)
+		assert.Equal(t, runtime.TopicEventResponse_SUCCESS, resp.GetStatus())
+		assert.Equal(t, "application/json", topicEvent.DataContentType)
+		assert.Equal(t, map[string]interface{}{"message": "hello"}, topicEvent.Data)
+		assert.JSONEq(t, `{"message":"hello"}`, string(topicEvent.RawData))
+	})
+
+	t.Run("corrupt compressed payload is dropped", func(t *testing.T) {
+		resp, err := s.OnTopicEvent(ctx, &runtime.TopicEventRequest{
+			Id:              "a123",
+			DataContentType: common.CompressedContentType,
+			Data:            data[:len(data)-4],
+			Topic:           sub.Topic,
+			PubsubName:      sub.PubsubName,
+		})
+		require.NoError(t, err)
+		assert.Equal(t, runtime.TopicEventResponse_DROP, resp.GetStatus())
+	})
+
+	t.Run("payload exceeding the maximum size is dropped", func(t *testing.T) {
+		s.EnableDecompression(8)
+		resp, err := s.OnTopicEvent(ctx, &runtime.TopicEventRequest{
+			Id:              "a123",
+			DataContentType: common.CompressedContentType,
+			Data:            data,
+			Topic:           sub.Topic,
+			PubsubName:      sub.PubsubName,
+		})
+		require.NoError(t, err)
+		assert.Equal(t, runtime.TopicEventResponse_DROP, resp.GetStatus())
+	})
+}
+
+func TestEventAttributesHandling(t *testing.T) {
+	ctx := metadata.NewIncomingContext(t.Context(), metadata.Pairs("Metadata.key", "value"))
+	s := getTestServer()
+
+	sub := &common.Subscription{
+		PubsubName: "messages",
+		Topic:      "test",
+	}
+	var topicEvent *common.TopicEvent
+	err := s.AddTopicEventHandler(sub, func(ctx context.Context, e *common.TopicEvent) (retry bool, err error) {
+		topicEvent = e
+		return false, nil
+	})
+	require.NoError(t, err)
+
+	ext, err := structpb.NewStruct(map[string]any{
+		"subject":     "orders/1",
+		"time":        "2026-01-02T15:04:05Z",
+		"traceid":     "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01",
+		"traceparent": "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01",
+		"tracestate":  "vendor=value",
+		"partition":   float64(3),
+	})
+	require.NoError(t, err)
+
+	resp, err := s.OnTopicEvent(ctx, &runtime.TopicEventRequest{
+		Id:              "a123",
+		Source:          "test",
+		Type:            "test",
+		SpecVersion:     "1.0",
+		DataContentType: "text/plain",
+		Data:            []byte("hello"),
+		Topic:           sub.Topic,
+		PubsubName:      sub.PubsubName,
+		Extensions:      ext,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, runtime.TopicEventResponse_SUCCESS, resp.GetStatus())
+	assert.Equal(t, "hello", topicEvent.Data)
+	assert.Equal(t, "orders/1", topicEvent.Subject)
+	assert.Equal(t, "2026-01-02T15:04:05Z", topicEvent.Time)
+	assert.Equal(t, "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01", topicEvent.TraceParent)
+	assert.Equal(t, "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01", topicEvent.TraceID)
+	assert.Equal(t, "vendor=value", topicEvent.TraceState)
+	assert.Equal(t, map[string]any{"partition": float64(3)}, topicEvent.Extensions)
+	assert.Equal(t, map[string]string{"key": "value"}, topicEvent.Metadata)
+}
@@ -0,0 +1,116 @@
+/*
+Copyright 2026 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package grpc
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+	"go.opentelemetry.io/otel/trace/noop"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/protobuf/types/known/structpb"
+
+	"github.com/dapr/dapr/pkg/proto/common/v1"
+	runtime "github.com/dapr/dapr/pkg/proto/runtime/v1"
+	cc "github.com/dapr/go-sdk/service/common"
+)
+
+func TestTracing(t *testing.T) {
+	spans := tracetest.NewSpanRecorder()
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(spans)))
+	t.Cleanup(func() { otel.SetTracerProvider(noop.NewTracerProvider()) })
+	last := func() sdktrace.ReadOnlySpan {
+		ended := spans.Ended()
+		require.NotEmpty(t, ended)
+		return ended[len(ended)-1]
+	}
+
+	server := getTestServer()
+	ctx := metadata.NewIncomingContext(t.Context(),
+		metadata.Pairs("traceparent", "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01"))
+
+	t.Run("invocation", func(t *testing.T) {
+		var handled trace.SpanContext
+		require.NoError(t, server.AddServiceInvocationHandler("orders", func(ctx context.Context, in *cc.InvocationEvent) (*cc.Content, error) {
+			handled = trace.SpanContextFromContext(ctx)
+			return nil, nil
+		}))
+		_, err := server.OnInvoke(ctx, &common.InvokeRequest{Method: "orders"})
+		require.NoError(t, err)
+
+		span := last()
+		assert.Equal(t, "invoke orders", span.Name())
+		assert.Equal(t, "00f067aa0ba902b7", span.Parent().SpanID().String())
+		assert.Equal(t, span.SpanContext(), handled)
+	})
+
+	t.Run("topic event", func(t *testing.T) {
+		require.NoError(t, server.AddTopicEventHandler(&cc.Subscription{PubsubName: "messages", Topic: "orders"}, eventHandler))
+		_, err := server.OnTopicEvent(ctx, &runtime.TopicEventRequest{
+			Id:              "1",
+			SpecVersion:     "1.0",
+			DataContentType: "text/plain",
+			Data:            []byte("order"),
+			Topic:           "orders",
+			PubsubName:      "messages",
+			Extensions: &structpb.Struct{Fields: map[string]*structpb.Value{
+				"traceparent": structpb.NewStringValue("00-0af7651916cd43dd8448eb211c80319c-b7ad6b7169203331-01"),
+			}},
+		})
+		require.NoError(t, err)
+
+		span := last()
+		assert.Equal(t, "process orders", span.Name())
+		assert.Equal(t, trace.SpanKindConsumer, span.SpanKind())
+		require.Len(t, span.Links(), 1)
+		assert.Equal(t, "b7ad6b7169203331", span.Links()[0].SpanContext.SpanID().String())
+	})
+
+	t.Run("binding error", func(t *testing.T) {
+		require.NoError(t, server.AddBindingInvocationHandler("queue", func(ctx context.Context, in *cc.BindingEvent) ([]byte, error) {
+			return nil, errors.New("queue unavailable")
+		}))
+		_, err := server.OnBindingEvent(ctx, &runtime.BindingEventRequest{Name: "queue"})
+		require.Error(t, err)
+
+		span := last()
+		assert.Equal(t, "binding queue", span.Name())
+		assert.Equal(t, codes.Error, span.Status().Code)
+		assert.Contains(t, span.Attributes(), attribute.String("dapr.component", "queue"))
+	})
+}
+
+func TestUseTracerProvider(t *testing.T) {
+	spans := tracetest.NewSpanRecorder()
+	server := getTestServer()
+	server.UseTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(spans)))
+	require.NoError(t, server.AddServiceInvocationHandler("orders", func(ctx context.Context, in *cc.InvocationEvent) (*cc.Content, error) {
+		return nil, nil
+	}))
+
+	_, err := server.OnInvoke(t.Context(), &common.InvokeRequest{Method: "orders"})
+	require.NoError(t, err)
+	if assert.Len(t, spans.Ended(), 1) {
+		assert.Equal(t, "invoke orders", spans.Ended()[0].Name())
+	}
+}
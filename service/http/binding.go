@@ -19,7 +19,10 @@ import (
 	"net/http"
 	"strings"
 
+	"go.opentelemetry.io/otel/attribute"
+
 	"github.com/dapr/go-sdk/service/common"
+	"github.com/dapr/go-sdk/service/internal"
 )
 
 // AddBindingInvocationHandler appends provided binding invocation handler with its route to the service.
@@ -65,7 +68,10 @@ func (s *Server) AddBindingInvocationHandler(route string, fn common.BindingInvo
 				Data:     content,
 				Metadata: meta,
 			}
-			out, err := fn(r.Context(), in)
+			ctx, span := internal.StartSpan(traceContext(r), s.tracerProvider, "binding "+route[1:],
+				attribute.String("dapr.component", route[1:]))
+			out, err := fn(ctx, in)
+			internal.EndSpan(span, err)
 			if err != nil {
 				http.Error(w, err.Error(), http.StatusInternalServerError)
 				return
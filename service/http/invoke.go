@@ -19,9 +19,11 @@ import (
 	"net/http"
 	"strings"
 
+	"go.opentelemetry.io/otel/attribute"
 	"google.golang.org/grpc/metadata"
 
 	"github.com/dapr/go-sdk/service/common"
+	"github.com/dapr/go-sdk/service/internal"
 )
 
 // AddServiceInvocationHandler appends provided service invocation handler with its route to the service.
@@ -61,9 +63,16 @@ func (s *Server) AddServiceInvocationHandler(route string, fn common.ServiceInvo
 					http.Error(w, err.Error(), http.StatusBadRequest)
 					return
 				}
+				if s.maxDecompressedSize > 0 {
+					e.Data, e.ContentType, err = common.DecompressPayloadLimit(e.Data, e.ContentType, s.maxDecompressedSize)
+					if err != nil {
+						http.Error(w, err.Error(), http.StatusBadRequest)
+						return
+					}
+				}
 			}
 
-			ctx := r.Context()
+			ctx := traceContext(r)
 			md, ok := metadata.FromIncomingContext(ctx)
 			if !ok {
 				md = metadata.MD{}
@@ -74,7 +83,11 @@ func (s *Server) AddServiceInvocationHandler(route string, fn common.ServiceInvo
 			ctx = metadata.NewIncomingContext(ctx, md)
 
 			// execute handler
+			ctx, span := internal.StartSpan(ctx, s.tracerProvider, "invoke "+route[1:],
+				attribute.String("dapr.method", route[1:]),
+				attribute.String("http.request.method", r.Method))
 			o, err := fn(ctx, e)
+			internal.EndSpan(span, err)
 			if err != nil {
 				http.Error(w, err.Error(), http.StatusInternalServerError)
 				return
@@ -106,6 +106,68 @@ func TestInvocationHandlerWithData(t *testing.T) {
 	assert.Equal(t, data, string(b))
 }
 
+func TestInvocationHandlerWithCompressedData(t *testing.T) {
+	data := `{"name": "test"}`
+	s := newServer("", nil)
+	err := s.AddServiceInvocationHandler("/hello", func(ctx context.Context, in *common.InvocationEvent) (out *common.Content, err error) {
+		return &common.Content{
+			Data:        in.Data,
+			ContentType: in.ContentType,
+		}, nil
+	})
+	require.NoErrorf(t, err, "adding event handler success")
+
+	compressed, err := common.CompressPayload(common.CompressionGzip, "application/json", []byte(data))
+	require.NoError(t, err)
+
+	t.Run("compressed data is passed through by default", func(t *testing.T) {
+		req, err := http.NewRequest(http.MethodPost, "/hello", strings.NewReader(string(compressed)))
+		require.NoErrorf(t, err, "creating request success")
+		req.Header.Set("Content-Type", common.CompressedContentType)
+
+		resp := httptest.NewRecorder()
+		s.mux.ServeHTTP(resp, req)
+		assert.Equal(t, http.StatusOK, resp.Code)
+		assert.Equal(t, common.CompressedContentType, resp.Header().Get("Content-Type"))
+		assert.Equal(t, compressed, resp.Body.Bytes())
+	})
+
+	s.EnableDecompression(0)
+
+	t.Run("compressed data is decompressed", func(t *testing.T) {
+		req, err := http.NewRequest(http.MethodPost, "/hello", strings.NewReader(string(compressed)))
+		require.NoErrorf(t, err, "creating request success")
+		req.Header.Set("Content-Type", common.CompressedContentType)
+
+		resp := httptest.NewRecorder()
+		s.mux.ServeHTTP(resp, req)
+		assert.Equal(t, http.StatusOK, resp.Code)
+		assert.Equal(t, "application/json", resp.Header().Get("Content-Type"))
+		assert.Equal(t, data, resp.Body.String())
+	})
+
+	t.Run("corrupt compressed data is rejected", func(t *testing.T) {
+		req, err := http.NewRequest(http.MethodPost, "/hello", strings.NewReader(string(compressed[:len(compressed)-4])))
+		require.NoErrorf(t, err, "creating request success")
+		req.Header.Set("Content-Type", common.CompressedContentType)
+
+		resp := httptest.NewRecorder()
+		s.mux.ServeHTTP(resp, req)
+		assert.Equal(t, http.StatusBadRequest, resp.Code)
+	})
+
+	t.Run("data exceeding the maximum size is rejected", func(t *testing.T) {
+		s.EnableDecompression(8)
+		req, err := http.NewRequest(http.MethodPost, "/hello", strings.NewReader(string(compressed)))
+		require.NoErrorf(t, err, "creating request success")
+		req.Header.Set("Content-Type", common.CompressedContentType)
+
+		resp := httptest.NewRecorder()
+		s.mux.ServeHTTP(resp, req)
+		assert.Equal(t, http.StatusBadRequest, resp.Code)
+	})
+}
+
 func TestInvocationHandlerWithoutInputData(t *testing.T) {
 	s := newServer("", nil)
 	err := s.AddServiceInvocationHandler("/hello", func(ctx context.Context, in *common.InvocationEvent) (out *common.Content, err error) {
@@ -14,19 +14,24 @@ limitations under the License.
 package http
 
 import (
+	"cmp"
+	"context"
 	"encoding/base64"
 	"encoding/json"
 	"errors"
 	"io"
+	"maps"
 	"net/http"
 	"strings"
 
 	"github.com/go-chi/chi/v5"
+	"go.opentelemetry.io/otel/attribute"
 
 	actorErr "github.com/dapr/go-sdk/actor/error"
 	"github.com/dapr/go-sdk/actor/runtime"
 	"github.com/dapr/go-sdk/service/common"
 	"github.com/dapr/go-sdk/service/internal"
+	"github.com/dapr/go-sdk/service/manifest"
 )
 
 const (
@@ -73,6 +78,12 @@ type topicEventJSON struct {
 	TraceID string `json:"traceid"`
 	// TraceParent is name of the parent trace identifier for the incoming event
 	TraceParent string `json:"traceparent"`
+	// TraceState is the vendor-specific trace state for the incoming event
+	TraceState string `json:"tracestate"`
+	// Time is the time the event occurred, as set by the producer
+	Time string `json:"time"`
+	// DataSchema identifies the schema that data adheres to
+	DataSchema string `json:"dataschema"`
 }
 
 func (in topicEventJSON) getData() (data any, rawData []byte) {
@@ -89,6 +100,10 @@ func (in topicEventJSON) getData() (data any, rawData []byte) {
 			data = v
 			// Handling of JSON base64 encoded or escaped in a string.
 			if str, ok := v.(string); ok {
+				// Text and XML are sent as JSON strings, whose content is the raw data.
+				if in.DataContentType != "" && !common.IsJSONContentType(in.DataContentType) {
+					rawData = []byte(str)
+				}
 				// This is the path that will most likely succeed.
 				var (
 					vString any
@@ -109,18 +124,23 @@ func (in topicEventJSON) getData() (data any, rawData []byte) {
 	} else if in.DataBase64 != "" {
 		rawData, err = base64.StdEncoding.DecodeString(in.DataBase64)
 		if err == nil {
-			data = rawData
-			if in.DataContentType == "application/json" {
-				if err = json.Unmarshal(rawData, &v); err == nil {
-					data = v
-				}
-			}
+			data = in.decode(rawData)
 		}
 	}
 
 	return data, rawData
 }
 
+// decode returns the Go representation of rawData according to the content
+// type and schema of the event.
+func (in topicEventJSON) decode(rawData []byte) any {
+	return common.DecodeEventData(&common.TopicEvent{
+		DataContentType: in.DataContentType,
+		DataSchema:      in.DataSchema,
+		RawData:         rawData,
+	})
+}
+
 func (s *Server) registerBaseHandler() {
 	// register subscribe handler
 	f := func(w http.ResponseWriter, r *http.Request) {
@@ -173,7 +193,9 @@ func (s *Server) registerBaseHandler() {
 		actorID := chi.URLParam(r, "actorId")
 		methodName := chi.URLParam(r, "methodName")
 		reqData, _ := io.ReadAll(r.Body)
-		rspData, err := runtime.GetActorRuntimeInstanceContext().InvokeActorMethod(r.Context(), actorType, actorID, methodName, reqData)
+		ctx, span := s.startActorSpan(r, actorType, actorID, methodName, attribute.String("dapr.actor.method", methodName))
+		rspData, err := runtime.GetActorRuntimeInstanceContext().InvokeActorMethod(ctx, actorType, actorID, methodName, reqData)
+		endActorSpan(span, err)
 		if err == actorErr.ErrActorTypeNotFound {
 			w.WriteHeader(http.StatusNotFound)
 			return
@@ -193,7 +215,9 @@ func (s *Server) registerBaseHandler() {
 	fDelete := func(w http.ResponseWriter, r *http.Request) {
 		actorType := chi.URLParam(r, "actorType")
 		actorID := chi.URLParam(r, "actorId")
-		err := runtime.GetActorRuntimeInstanceContext().Deactivate(r.Context(), actorType, actorID)
+		ctx, span := s.startActorSpan(r, actorType, actorID, "deactivate")
+		err := runtime.GetActorRuntimeInstanceContext().Deactivate(ctx, actorType, actorID)
+		endActorSpan(span, err)
 		if err == actorErr.ErrActorTypeNotFound || err == actorErr.ErrActorIDNotFound {
 			w.WriteHeader(http.StatusNotFound)
 			return
@@ -212,7 +236,9 @@ func (s *Server) registerBaseHandler() {
 		actorID := chi.URLParam(r, "actorId")
 		reminderName := chi.URLParam(r, "reminderName")
 		reqData, _ := io.ReadAll(r.Body)
-		err := runtime.GetActorRuntimeInstanceContext().InvokeReminder(r.Context(), actorType, actorID, reminderName, reqData)
+		ctx, span := s.startActorSpan(r, actorType, actorID, "reminder", attribute.String("dapr.actor.reminder", reminderName))
+		err := runtime.GetActorRuntimeInstanceContext().InvokeReminder(ctx, actorType, actorID, reminderName, reqData)
+		endActorSpan(span, err)
 		if err == actorErr.ErrActorTypeNotFound {
 			w.WriteHeader(http.StatusNotFound)
 			return
@@ -231,7 +257,9 @@ func (s *Server) registerBaseHandler() {
 		actorID := chi.URLParam(r, "actorId")
 		timerName := chi.URLParam(r, "timerName")
 		reqData, _ := io.ReadAll(r.Body)
-		err := runtime.GetActorRuntimeInstanceContext().InvokeTimer(r.Context(), actorType, actorID, timerName, reqData)
+		ctx, span := s.startActorSpan(r, actorType, actorID, "timer", attribute.String("dapr.actor.timer", timerName))
+		err := runtime.GetActorRuntimeInstanceContext().InvokeTimer(ctx, actorType, actorID, timerName, reqData)
+		endActorSpan(span, err)
 		if err == actorErr.ErrActorTypeNotFound {
 			w.WriteHeader(http.StatusNotFound)
 			return
@@ -264,6 +292,10 @@ func (s *Server) AddTopicEventSubscriber(sub *common.Subscription, subscriber co
 	if sub.Route == "" {
 		return errors.New("handler route name")
 	}
+	if subscriber == nil {
+		return errors.New("topic handler required")
+	}
+	subscriber = internal.WrapSubscriber(subscriber, s.topicMiddleware)
 	if err := s.topicRegistrar.AddSubscription(sub, subscriber); err != nil {
 		return err
 	}
@@ -287,59 +319,185 @@ func (s *Server) AddTopicEventSubscriber(sub *common.Subscription, subscriber co
 				return
 			}
 
-			// deserialize the event
-			var in topicEventJSON
-			if err = json.Unmarshal(body, &in); err != nil {
-				http.Error(w, err.Error(), PubSubHandlerDropStatusCode)
+			if isBulkTopicEvent(body) {
+				s.handleBulkTopicEvent(w, r, sub, subscriber, body)
 				return
 			}
 
-			if in.PubsubName == "" {
-				in.Topic = sub.PubsubName
-			}
-			if in.Topic == "" {
-				in.Topic = sub.Topic
-			}
-
-			data, rawData := in.getData()
-			te := common.TopicEvent{
-				ID:              in.ID,
-				SpecVersion:     in.SpecVersion,
-				Type:            in.Type,
-				Source:          in.Source,
-				DataContentType: in.DataContentType,
-				Data:            data,
-				RawData:         rawData,
-				DataBase64:      in.DataBase64,
-				Subject:         in.Subject,
-				PubsubName:      in.PubsubName,
-				Topic:           in.Topic,
-				Metadata:        getCustomMetdataFromHeaders(r),
-				TraceID:         in.TraceID,
-				TraceParent:     in.TraceParent,
+			te, err := s.newTopicEvent(body, sub, getCustomMetdataFromHeaders(r))
+			if err != nil {
+				http.Error(w, err.Error(), PubSubHandlerDropStatusCode)
+				return
 			}
 
 			w.Header().Add("Content-Type", "application/json")
 			w.WriteHeader(http.StatusOK)
 
 			// execute user handler
-			retry, err := subscriber.Handle(r.Context(), &te)
-			if err == nil {
-				writeStatus(w, common.SubscriptionResponseStatusSuccess)
-				return
-			}
-
-			if retry {
-				writeStatus(w, common.SubscriptionResponseStatusRetry)
-				return
-			}
-
-			writeStatus(w, common.SubscriptionResponseStatusDrop)
+			writeStatus(w, s.handleTopicEvent(traceContext(r), subscriber, te))
 		})))
 
 	return nil
 }
 
+// newTopicEvent deserializes the CloudEvent in body.
+func (s *Server) newTopicEvent(body []byte, sub *common.Subscription, meta map[string]string) (*common.TopicEvent, error) {
+	var in topicEventJSON
+	if err := json.Unmarshal(body, &in); err != nil {
+		return nil, err
+	}
+	var attrs map[string]any
+	if err := json.Unmarshal(body, &attrs); err != nil {
+		return nil, err
+	}
+
+	if in.PubsubName == "" {
+		in.Topic = sub.PubsubName
+	}
+	if in.Topic == "" {
+		in.Topic = sub.Topic
+	}
+
+	data, rawData := in.getData()
+	if s.maxDecompressedSize > 0 && common.IsCompressedPayload(rawData) {
+		var err error
+		if rawData, in.DataContentType, err = common.DecompressPayloadLimit(rawData, in.DataContentType, s.maxDecompressedSize); err != nil {
+			return nil, err
+		}
+		data = in.decode(rawData)
+	}
+	return &common.TopicEvent{
+		ID:              in.ID,
+		SpecVersion:     in.SpecVersion,
+		Type:            in.Type,
+		Source:          in.Source,
+		DataContentType: in.DataContentType,
+		Data:            data,
+		RawData:         rawData,
+		DataBase64:      in.DataBase64,
+		Subject:         in.Subject,
+		PubsubName:      in.PubsubName,
+		Topic:           in.Topic,
+		Metadata:        meta,
+		TraceID:         in.TraceID,
+		TraceParent:     in.TraceParent,
+		TraceState:      in.TraceState,
+		Time:            in.Time,
+		DataSchema:      in.DataSchema,
+		Extensions:      common.ExtensionAttributes(attrs),
+	}, nil
+}
+
+// handleTopicEvent passes te to subscriber and returns the status to respond with.
+func (s *Server) handleTopicEvent(ctx context.Context, subscriber common.TopicEventSubscriber, te *common.TopicEvent) common.SubscriptionResponseStatus {
+	ctx, span := internal.StartTopicEventSpan(ctx, s.tracerProvider, te)
+	retry, err := subscriber.Handle(ctx, te)
+	internal.EndSpan(span, err)
+	switch {
+	case err == nil:
+		return common.SubscriptionResponseStatusSuccess
+	case retry:
+		return common.SubscriptionResponseStatusRetry
+	default:
+		return common.SubscriptionResponseStatusDrop
+	}
+}
+
+// bulkTopicEventJSON is the envelope of the events of a bulk delivery.
+type bulkTopicEventJSON struct {
+	ID         string                    `json:"id"`
+	Topic      string                    `json:"topic"`
+	PubsubName string                    `json:"pubsubname"`
+	Metadata   map[string]string         `json:"metadata"`
+	Entries    []bulkTopicEventEntryJSON `json:"entries"`
+}
+
+// bulkTopicEventEntryJSON is an event of a bulk delivery.
+type bulkTopicEventEntryJSON struct {
+	EntryID string `json:"entryId"`
+	// Event is a CloudEvent, or the base64 encoded data of raw payload subscriptions.
+	Event       json.RawMessage   `json:"event"`
+	ContentType string            `json:"contentType"`
+	Metadata    map[string]string `json:"metadata"`
+}
+
+// bulkSubscriptionResponseJSON holds the status of every event of a bulk delivery.
+type bulkSubscriptionResponseJSON struct {
+	Statuses []bulkSubscriptionStatusJSON `json:"statuses"`
+}
+
+type bulkSubscriptionStatusJSON struct {
+	EntryID string                            `json:"entryId"`
+	Status  common.SubscriptionResponseStatus `json:"status"`
+}
+
+// isBulkTopicEvent reports whether body is the envelope of a bulk delivery
+// rather than a CloudEvent.
+func isBulkTopicEvent(body []byte) bool {
+	var in struct {
+		SpecVersion string          `json:"specversion"`
+		Entries     json.RawMessage `json:"entries"`
+	}
+	return json.Unmarshal(body, &in) == nil && in.SpecVersion == "" && len(in.Entries) > 0
+}
+
+// handleBulkTopicEvent passes the events of a bulk delivery to subscriber one
+// at a time, and responds with the status of each.
+func (s *Server) handleBulkTopicEvent(w http.ResponseWriter, r *http.Request, sub *common.Subscription, subscriber common.TopicEventSubscriber, body []byte) {
+	var in bulkTopicEventJSON
+	if err := json.Unmarshal(body, &in); err != nil {
+		http.Error(w, err.Error(), PubSubHandlerDropStatusCode)
+		return
+	}
+
+	resp := bulkSubscriptionResponseJSON{Statuses: make([]bulkSubscriptionStatusJSON, 0, len(in.Entries))}
+	for _, entry := range in.Entries {
+		meta := make(map[string]string, len(in.Metadata)+len(entry.Metadata))
+		maps.Copy(meta, in.Metadata)
+		maps.Copy(meta, entry.Metadata)
+
+		status := common.SubscriptionResponseStatusDrop
+		if te, err := s.newBulkTopicEvent(&in, &entry, sub, meta); err == nil {
+			status = s.handleTopicEvent(traceContext(r), subscriber, te)
+		}
+		resp.Statuses = append(resp.Statuses, bulkSubscriptionStatusJSON{EntryID: entry.EntryID, Status: status})
+	}
+
+	w.Header().Add("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	if err := json.NewEncoder(w).Encode(resp); err != nil {
+		http.Error(w, err.Error(), PubSubHandlerRetryStatusCode)
+	}
+}
+
+// newBulkTopicEvent deserializes an event of a bulk delivery.
+func (s *Server) newBulkTopicEvent(in *bulkTopicEventJSON, entry *bulkTopicEventEntryJSON, sub *common.Subscription, meta map[string]string) (*common.TopicEvent, error) {
+	var encoded string
+	if err := json.Unmarshal(entry.Event, &encoded); err != nil {
+		return s.newTopicEvent(entry.Event, sub, meta)
+	}
+
+	rawData, err := base64.StdEncoding.DecodeString(encoded)
+	if err != nil {
+		return nil, err
+	}
+	contentType := entry.ContentType
+	if s.maxDecompressedSize > 0 {
+		if rawData, contentType, err = common.DecompressPayloadLimit(rawData, contentType, s.maxDecompressedSize); err != nil {
+			return nil, err
+		}
+	}
+	return &common.TopicEvent{
+		ID:              entry.EntryID,
+		DataContentType: contentType,
+		Data:            common.DecodeData(contentType, rawData),
+		RawData:         rawData,
+		PubsubName:      cmp.Or(in.PubsubName, sub.PubsubName),
+		Topic:           cmp.Or(in.Topic, sub.Topic),
+		Metadata:        meta,
+	}, nil
+}
+
 func getCustomMetdataFromHeaders(r *http.Request) map[string]string {
 	md := make(map[string]string)
 	for k, v := range r.Header {
@@ -356,3 +514,15 @@ func writeStatus(w http.ResponseWriter, s common.SubscriptionResponseStatus) {
 		http.Error(w, err.Error(), PubSubHandlerRetryStatusCode)
 	}
 }
+
+// TopicSubscriptions returns the declarative subscriptions of the registered
+// topic handlers, as used by the manifest package.
+func (s *Server) TopicSubscriptions() []manifest.SubscriptionSpec {
+	return s.topicRegistrar.Specs()
+}
+
+// TopicEventRoute returns the route Dapr selects for e given the routing rules
+// of the registered topic handlers, as used by the routing package.
+func (s *Server) TopicEventRoute(e *common.TopicEvent) (string, error) {
+	return s.topicRegistrar.Route(e)
+}
@@ -15,6 +15,7 @@ package http
 
 import (
 	"context"
+	"encoding/base64"
 	"encoding/json"
 	"errors"
 	"fmt"
@@ -271,6 +272,39 @@ func TestEventDataHandling(t *testing.T) {
 	}
 }
 
+func TestEventDataStruct(t *testing.T) {
+	type order struct {
+		ID int `xml:"id"`
+	}
+
+	s := newServer("", nil)
+	sub := &common.Subscription{PubsubName: "messages", Topic: "test", Route: "/test"}
+
+	recv := make(chan *common.TopicEvent, 1)
+	err := s.AddTopicEventHandler(sub, func(ctx context.Context, e *common.TopicEvent) (retry bool, err error) {
+		recv <- e
+		return false, nil
+	})
+	require.NoErrorf(t, err, "error adding event handler")
+
+	t.Run("XML in data", func(t *testing.T) {
+		makeEventRequest(t, s, "/test", `{"specversion":"1.0","id":"1","datacontenttype":"application/xml","data":"<order><id>1</id></order>"}`, http.StatusOK)
+		e := <-recv
+		var o order
+		require.NoError(t, e.Struct(&o))
+		assert.Equal(t, 1, o.ID)
+	})
+
+	t.Run("text in data_base64", func(t *testing.T) {
+		makeEventRequest(t, s, "/test", `{"specversion":"1.0","id":"1","datacontenttype":"text/plain","data_base64":"aGVsbG8="}`, http.StatusOK)
+		e := <-recv
+		assert.Equal(t, "hello", e.Data)
+		var str string
+		require.NoError(t, e.Struct(&str))
+		assert.Equal(t, "hello", str)
+	})
+}
+
 func TestEventMetadataHandling(t *testing.T) {
 	tests := map[string]struct {
 		metadata         map[string]string
@@ -544,3 +578,218 @@ func TestRawPayloadDecode(t *testing.T) {
 	s.registerBaseHandler()
 	makeEventRequest(t, s, "/raw", rawData, http.StatusOK)
 }
+
+func TestCompressedEventDataHandling(t *testing.T) {
+	s := newServer("", nil)
+	sub := &common.Subscription{
+		PubsubName: "messages",
+		Topic:      "test",
+		Route:      "/test",
+	}
+
+	recv := make(chan *common.TopicEvent, 1)
+	err := s.AddTopicEventHandler(sub, func(ctx context.Context, e *common.TopicEvent) (retry bool, err error) {
+		recv <- e
+		return false, nil
+	})
+	require.NoError(t, err)
+
+	data, err := common.CompressPayload(common.CompressionGzip, "application/json", []byte(`{"message":"hello"}`))
+	require.NoError(t, err)
+	event := func(data []byte) string {
+		return fmt.Sprintf(`{
+			"specversion" : "1.0",
+			"type" : "com.example.test",
+			"source" : "test",
+			"id" : "A234-1234-1234",
+			"datacontenttype" : %q,
+			"data_base64" : %q
+		}`, common.CompressedContentType, base64.StdEncoding.EncodeToString(data))
+	}
+
+	t.Run("compressed payload is passed through by default", func(t *testing.T) {
+		makeEventRequest(t, s, "/test", event(data), http.StatusOK)
+
+		e := <-recv
+		assert.Equal(t, common.CompressedContentType, e.DataContentType)
+		assert.Equal(t, data, e.RawData)
+	})
+
+	s.EnableDecompression(0)
+
+	t.Run("compressed payload is decompressed", func(t *testing.T) {
+		makeEventRequest(t, s, "/test", event(data), http.StatusOK)
+
+		e := <-recv
+		assert.Equal(t, "application/json", e.DataContentType)
+		assert.Equal(t, map[string]interface{}{"message": "hello"}, e.Data)
+		assert.JSONEq(t, `{"message":"hello"}`, string(e.RawData))
+	})
+
+	t.Run("corrupt compressed payload is dropped", func(t *testing.T) {
+		makeEventRequest(t, s, "/test", event(data[:len(data)-4]), PubSubHandlerDropStatusCode)
+	})
+
+	t.Run("payload exceeding the maximum size is dropped", func(t *testing.T) {
+		s.EnableDecompression(8)
+		makeEventRequest(t, s, "/test", event(data), PubSubHandlerDropStatusCode)
+	})
+}
+
+func TestTopicEventMiddleware(t *testing.T) {
+	s := newServer("", nil)
+	var calls []string
+	record := func(name string) common.TopicEventMiddleware {
+		return func(next common.TopicEventSubscriber) common.TopicEventSubscriber {
+			return common.TopicEventHandler(func(ctx context.Context, e *common.TopicEvent) (bool, error) {
+				calls = append(calls, name)
+				return next.Handle(ctx, e)
+			})
+		}
+	}
+	handler := func(ctx context.Context, e *common.TopicEvent) (bool, error) {
+		calls = append(calls, "handler")
+		return false, nil
+	}
+
+	require.NoError(t, s.AddTopicEventHandler(&common.Subscription{PubsubName: "messages", Topic: "before", Route: "/before"}, handler))
+	s.UseTopicEventMiddleware(record("first"), record("second"))
+	require.NoError(t, s.AddTopicEventHandler(&common.Subscription{PubsubName: "messages", Topic: "after", Route: "/after"}, handler))
+
+	for _, route := range []string{"/before", "/after"} {
+		makeEventRequest(t, s, route, `{
+			"specversion" : "1.0",
+			"type" : "com.example.test",
+			"source" : "test",
+			"id" : "A234-1234-1234",
+			"datacontenttype" : "text/plain",
+			"data" : "test"
+		}`, http.StatusOK)
+	}
+	assert.Equal(t, []string{"handler", "first", "second", "handler"}, calls)
+}
+
+func TestBulkEventHandling(t *testing.T) {
+	s := newServer("", nil)
+	sub := &common.Subscription{
+		PubsubName:    "messages",
+		Topic:         "test",
+		Route:         "/test",
+		BulkSubscribe: &common.BulkSubscribeOptions{MaxMessagesCount: 10},
+	}
+	var events []*common.TopicEvent
+	handler := func(ctx context.Context, e *common.TopicEvent) (bool, error) {
+		events = append(events, e)
+		if e.ID == "retry" {
+			return true, errors.New("retry")
+		}
+		return false, nil
+	}
+	require.NoError(t, s.AddTopicEventHandler(sub, handler))
+	s.registerBaseHandler()
+
+	t.Run("subscription lists bulk settings", func(t *testing.T) {
+		req, err := http.NewRequest(http.MethodGet, "/dapr/subscribe", nil)
+		require.NoError(t, err)
+		rr := httptest.NewRecorder()
+		s.mux.ServeHTTP(rr, req)
+		var subs []internal.TopicSubscription
+		require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &subs))
+		require.Len(t, subs, 1)
+		assert.Equal(t, &internal.TopicBulkSubscribe{Enabled: true, MaxMessagesCount: 10}, subs[0].BulkSubscribe)
+	})
+
+	t.Run("entries are handled one at a time", func(t *testing.T) {
+		body := fmt.Sprintf(`{
+			"id": "bulk",
+			"topic": "test",
+			"pubsubname": "messages",
+			"metadata": {"a": "1", "b": "1"},
+			"entries": [
+				{
+					"entryId": "1",
+					"event": %q,
+					"contentType": "text/plain",
+					"metadata": {"b": "2"}
+				},
+				{
+					"entryId": "2",
+					"event": {
+						"specversion": "1.0",
+						"type": "com.example.test",
+						"source": "test",
+						"id": "retry",
+						"datacontenttype": "text/plain",
+						"data": "cloud event"
+					},
+					"contentType": "application/cloudevents+json"
+				},
+				{
+					"entryId": "3",
+					"event": "not base64",
+					"contentType": "text/plain"
+				}
+			]
+		}`, base64.StdEncoding.EncodeToString([]byte("raw")))
+		req, err := http.NewRequest(http.MethodPost, "/test", strings.NewReader(body))
+		require.NoError(t, err)
+		req.Header.Set("Content-Type", "application/json")
+		rr := httptest.NewRecorder()
+		s.mux.ServeHTTP(rr, req)
+		require.Equal(t, http.StatusOK, rr.Code)
+
+		var resp bulkSubscriptionResponseJSON
+		require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &resp))
+		assert.Equal(t, []bulkSubscriptionStatusJSON{
+			{EntryID: "1", Status: common.SubscriptionResponseStatusSuccess},
+			{EntryID: "2", Status: common.SubscriptionResponseStatusRetry},
+			{EntryID: "3", Status: common.SubscriptionResponseStatusDrop},
+		}, resp.Statuses)
+
+		require.Len(t, events, 2)
+		assert.Equal(t, "raw", events[0].Data)
+		assert.Equal(t, map[string]string{"a": "1", "b": "2"}, events[0].Metadata)
+		assert.Equal(t, "test", events[1].Source)
+		assert.Equal(t, "cloud event", events[1].Data)
+	})
+}
+
+func TestEventAttributesHandling(t *testing.T) {
+	s := newServer("", nil)
+	sub := &common.Subscription{
+		PubsubName: "messages",
+		Topic:      "test",
+		Route:      "/test",
+	}
+
+	recv := make(chan *common.TopicEvent, 1)
+	err := s.AddTopicEventHandler(sub, func(ctx context.Context, e *common.TopicEvent) (retry bool, err error) {
+		recv <- e
+		return false, nil
+	})
+	require.NoError(t, err)
+
+	event := `{
+		"specversion" : "1.0",
+		"type" : "com.example.test",
+		"source" : "test",
+		"id" : "A234-1234-1234",
+		"subject" : "orders/1",
+		"time" : "2026-01-02T15:04:05Z",
+		"dataschema" : "https://example.com/order.json",
+		"traceparent" : "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01",
+		"tracestate" : "vendor=value",
+		"partition" : 3,
+		"datacontenttype" : "application/json",
+		"data" : {"message":"hello"}
+	}`
+	makeEventRequest(t, s, "/test", event, http.StatusOK)
+
+	e := <-recv
+	assert.Equal(t, "orders/1", e.Subject)
+	assert.Equal(t, "2026-01-02T15:04:05Z", e.Time)
+	assert.Equal(t, "https://example.com/order.json", e.DataSchema)
+	assert.Equal(t, "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01", e.TraceParent)
+	assert.Equal(t, "vendor=value", e.TraceState)
+	assert.Equal(t, map[string]any{"partition": float64(3)}, e.Extensions)
+}
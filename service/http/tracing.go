@@ -0,0 +1,49 @@
+/*
+Copyright 2026 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package http
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
+
+	actorErr "github.com/dapr/go-sdk/actor/error"
+	"github.com/dapr/go-sdk/service/internal"
+)
+
+// traceContext returns the context of r with the trace context of its headers.
+func traceContext(r *http.Request) context.Context {
+	return internal.ExtractTraceContext(r.Context(), propagation.HeaderCarrier(r.Header))
+}
+
+// startActorSpan starts the span of a call of Dapr to an actor, named after
+// the actor type and the kind of call.
+func (s *Server) startActorSpan(r *http.Request, actorType, actorID, name string, attrs ...attribute.KeyValue) (context.Context, trace.Span) {
+	attrs = append(attrs,
+		attribute.String("dapr.actor.type", actorType),
+		attribute.String("dapr.actor.id", actorID))
+	return internal.StartSpan(traceContext(r), s.tracerProvider, "actor "+actorType+"/"+name, attrs...)
+}
+
+func endActorSpan(span trace.Span, err actorErr.ActorErr) {
+	if err != actorErr.Success {
+		internal.EndSpan(span, fmt.Errorf("actor error %d", err))
+		return
+	}
+	span.End()
+}
@@ -0,0 +1,110 @@
+/*
+Copyright 2026 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package http
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+	"go.opentelemetry.io/otel/trace/noop"
+
+	"github.com/dapr/go-sdk/service/common"
+)
+
+func TestTracing(t *testing.T) {
+	spans := tracetest.NewSpanRecorder()
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(spans)))
+	t.Cleanup(func() { otel.SetTracerProvider(noop.NewTracerProvider()) })
+	last := func() sdktrace.ReadOnlySpan {
+		ended := spans.Ended()
+		require.NotEmpty(t, ended)
+		return ended[len(ended)-1]
+	}
+
+	const traceparent = "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01"
+	s := newServer("", nil)
+	s.registerBaseHandler()
+
+	t.Run("invocation", func(t *testing.T) {
+		var handled trace.SpanContext
+		require.NoError(t, s.AddServiceInvocationHandler("/orders", func(ctx context.Context, in *common.InvocationEvent) (*common.Content, error) {
+			handled = trace.SpanContextFromContext(ctx)
+			return nil, nil
+		}))
+		req, err := http.NewRequest(http.MethodGet, "/orders", nil)
+		require.NoError(t, err)
+		req.Header.Set("traceparent", traceparent)
+		testRequest(t, s, req, http.StatusOK)
+
+		span := last()
+		assert.Equal(t, "invoke orders", span.Name())
+		assert.Equal(t, trace.SpanKindServer, span.SpanKind())
+		assert.Equal(t, "00f067aa0ba902b7", span.Parent().SpanID().String())
+		assert.Equal(t, span.SpanContext(), handled)
+		assert.Contains(t, span.Attributes(), attribute.String("http.request.method", http.MethodGet))
+	})
+
+	t.Run("topic event", func(t *testing.T) {
+		require.NoError(t, s.AddTopicEventHandler(&common.Subscription{PubsubName: "messages", Topic: "orders", Route: "/events"},
+			func(ctx context.Context, e *common.TopicEvent) (bool, error) { return false, nil }))
+		req, err := http.NewRequest(http.MethodPost, "/events", strings.NewReader(`{
+			"id": "1", "specversion": "1.0", "type": "test", "source": "test",
+			"pubsubname": "messages", "topic": "orders", "data": "order",
+			"traceparent": "00-0af7651916cd43dd8448eb211c80319c-b7ad6b7169203331-01"
+		}`))
+		require.NoError(t, err)
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("traceparent", traceparent)
+		testRequest(t, s, req, http.StatusOK)
+
+		span := last()
+		assert.Equal(t, "process orders", span.Name())
+		assert.Equal(t, "4bf92f3577b34da6a3ce929d0e0e4736", span.SpanContext().TraceID().String())
+		require.Len(t, span.Links(), 1)
+		assert.Equal(t, "0af7651916cd43dd8448eb211c80319c", span.Links()[0].SpanContext.TraceID().String())
+	})
+
+	t.Run("actor", func(t *testing.T) {
+		makeRequest(t, s, "/actors/tracedActorType/1/method/Invoke", "", http.MethodPut, http.StatusNotFound)
+
+		span := last()
+		assert.Equal(t, "actor tracedActorType/Invoke", span.Name())
+		assert.Equal(t, codes.Error, span.Status().Code)
+		assert.Contains(t, span.Attributes(), attribute.String("dapr.actor.id", "1"))
+	})
+}
+
+func TestUseTracerProvider(t *testing.T) {
+	spans := tracetest.NewSpanRecorder()
+	s := newServer("", nil)
+	s.UseTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(spans)))
+	require.NoError(t, s.AddServiceInvocationHandler("/orders", func(ctx context.Context, in *common.InvocationEvent) (*common.Content, error) {
+		return nil, nil
+	}))
+
+	makeRequest(t, s, "/orders", "", http.MethodGet, http.StatusOK)
+	if assert.Len(t, spans.Ended(), 1) {
+		assert.Equal(t, "invoke orders", spans.Ended()[0].Name())
+	}
+}
@@ -2,8 +2,11 @@ package internal
 
 import (
 	"errors"
+	"fmt"
 
 	"github.com/dapr/go-sdk/service/common"
+	"github.com/dapr/go-sdk/service/manifest"
+	"github.com/dapr/go-sdk/service/routing"
 )
 
 // TopicRegistrar is a map of <pubsubname>-<topic> to `TopicRegistration`
@@ -18,6 +21,14 @@ type TopicRegistration struct {
 	RouteHandlers  map[string]common.TopicEventSubscriber
 }
 
+// WrapSubscriber wraps subscriber with mw, the first middleware receiving events first.
+func WrapSubscriber(subscriber common.TopicEventSubscriber, mw []common.TopicEventMiddleware) common.TopicEventSubscriber {
+	for i := len(mw) - 1; i >= 0; i-- {
+		subscriber = mw[i](subscriber)
+	}
+	return subscriber
+}
+
 func (m TopicRegistrar) AddSubscription(sub *common.Subscription, fn common.TopicEventSubscriber) error {
 	if sub.Topic == "" {
 		return errors.New("topic name required")
@@ -47,6 +58,9 @@ func (m TopicRegistrar) AddSubscription(sub *common.Subscription, fn common.Topi
 		m[key] = ts
 	}
 
+	if err := ts.Subscription.SetBulkSubscribe(sub.BulkSubscribe); err != nil {
+		return err
+	}
 	if sub.Match != "" {
 		if err := ts.Subscription.AddRoutingRule(sub.Route, sub.Match, sub.Priority); err != nil {
 			return err
@@ -61,3 +75,28 @@ func (m TopicRegistrar) AddSubscription(sub *common.Subscription, fn common.Topi
 
 	return nil
 }
+
+// Specs returns the declarative subscriptions of the registered topics.
+func (m TopicRegistrar) Specs() []manifest.SubscriptionSpec {
+	specs := make([]manifest.SubscriptionSpec, 0, len(m))
+	for _, ts := range m {
+		specs = append(specs, ts.Subscription.Spec())
+	}
+	return specs
+}
+
+// Route returns the route of the subscription of e which Dapr selects for e.
+func (m TopicRegistrar) Route(e *common.TopicEvent) (string, error) {
+	ts, ok := m[e.PubsubName+"-"+e.Topic]
+	if !ok {
+		ts, ok = m[e.PubsubName]
+	}
+	if !ok {
+		return "", fmt.Errorf("no subscription for topic %s on pubsub %s", e.Topic, e.PubsubName)
+	}
+	envelope, err := routing.Envelope(e)
+	if err != nil {
+		return "", err
+	}
+	return ts.Subscription.SelectRoute(envelope)
+}
@@ -47,6 +47,14 @@ func TestTopicRegistrarValidation(t *testing.T) {
 				Match:      `event.type == "test"`,
 			}, fn, "path is required for routing rules",
 		},
+		"match must be a bool": {
+			common.Subscription{ //nolint:exhaustivestruct
+				PubsubName: "test",
+				Topic:      "test",
+				Route:      "/test",
+				Match:      `size(event.type)`,
+			}, fn, `subscription for topic test on pubsub test: invalid match expression "size(event.type)": evaluates to int, want bool`,
+		},
 		"success default route": {
 			common.Subscription{ //nolint:exhaustivestruct
 				PubsubName: "test",
@@ -3,7 +3,12 @@ package internal
 import (
 	"errors"
 	"fmt"
+	"maps"
 	"sort"
+
+	"github.com/dapr/go-sdk/service/common"
+	"github.com/dapr/go-sdk/service/manifest"
+	"github.com/dapr/go-sdk/service/routing"
 )
 
 // TopicSubscription internally represents single topic subscription.
@@ -20,6 +25,15 @@ type TopicSubscription struct {
 	Metadata map[string]string `json:"metadata,omitempty"`
 	// DeadLetterTopic is the name of the deadletter topic.
 	DeadLetterTopic string `json:"deadLetterTopic"`
+	// BulkSubscribe are the bulk delivery settings of the subscription.
+	BulkSubscribe *TopicBulkSubscribe `json:"bulkSubscribe,omitempty"`
+}
+
+// TopicBulkSubscribe are the bulk delivery settings of a subscription.
+type TopicBulkSubscribe struct {
+	Enabled            bool  `json:"enabled"`
+	MaxMessagesCount   int32 `json:"maxMessagesCount,omitempty"`
+	MaxAwaitDurationMs int32 `json:"maxAwaitDurationMs,omitempty"`
 }
 
 // TopicRoutes encapsulates the default route and multiple routing rules.
@@ -41,6 +55,8 @@ type TopicRule struct {
 	Path string `json:"path"`
 	// priority is the optional priority order (low to high) for this rule.
 	priority int `json:"-"`
+	// match is the compiled Match expression.
+	match *routing.Match
 }
 
 // NewTopicSubscription creates a new `TopicSubscription`.
@@ -63,6 +79,25 @@ func (s *TopicSubscription) SetMetadata(metadata map[string]string) error {
 	return nil
 }
 
+// SetBulkSubscribe enables bulk delivery with the given options.
+// An error is returned if bulk delivery is already enabled with other options.
+func (s *TopicSubscription) SetBulkSubscribe(opts *common.BulkSubscribeOptions) error {
+	if opts == nil {
+		return nil
+	}
+	bulk := &TopicBulkSubscribe{
+		Enabled:            true,
+		MaxMessagesCount:   opts.MaxMessagesCount,
+		MaxAwaitDurationMs: opts.MaxAwaitDurationMs,
+	}
+	if s.BulkSubscribe != nil && *s.BulkSubscribe != *bulk {
+		return fmt.Errorf("subscription for topic %s on pubsub %s already has other bulk subscribe options", s.Topic, s.PubsubName)
+	}
+	s.BulkSubscribe = bulk
+
+	return nil
+}
+
 // SetDefaultRoute sets the default route if not already set.
 // An error is returned if it is already set.
 func (s *TopicSubscription) SetDefaultRoute(path string) error {
@@ -82,11 +117,16 @@ func (s *TopicSubscription) SetDefaultRoute(path string) error {
 }
 
 // AddRoutingRule adds a routing rule.
-// An error is returned if a there id a duplicate priority > 1.
+// An error is returned if a there id a duplicate priority > 1, or if the
+// match expression is not a valid CEL expression.
 func (s *TopicSubscription) AddRoutingRule(path, match string, priority int) error {
 	if path == "" {
 		return errors.New("path is required for routing rules")
 	}
+	m, err := routing.Compile(match)
+	if err != nil {
+		return fmt.Errorf("subscription for topic %s on pubsub %s: %w", s.Topic, s.PubsubName, err)
+	}
 	if s.Routes == nil {
 		s.Routes = &TopicRoutes{ //nolint:exhaustivestruct
 			Default:    s.Route,
@@ -103,6 +143,7 @@ func (s *TopicSubscription) AddRoutingRule(path, match string, priority int) err
 		Match:    match,
 		Path:     path,
 		priority: priority,
+		match:    m,
 	})
 	sort.SliceStable(s.Routes.Rules, func(i, j int) bool {
 		return s.Routes.Rules[i].priority < s.Routes.Rules[j].priority
@@ -113,3 +154,46 @@ func (s *TopicSubscription) AddRoutingRule(path, match string, priority int) err
 
 	return nil
 }
+
+// SelectRoute returns the path of the first routing rule matching envelope,
+// or the default route if none does.
+func (s *TopicSubscription) SelectRoute(envelope map[string]any) (string, error) {
+	if s.Routes == nil {
+		return s.Route, nil
+	}
+	for _, r := range s.Routes.Rules {
+		matched, err := r.match.Eval(envelope)
+		if err != nil {
+			return "", err
+		}
+		if matched {
+			return r.Path, nil
+		}
+	}
+	return s.Routes.Default, nil
+}
+
+// Spec returns the declarative subscription of s.
+func (s *TopicSubscription) Spec() manifest.SubscriptionSpec {
+	spec := manifest.SubscriptionSpec{
+		PubsubName:      s.PubsubName,
+		Topic:           s.Topic,
+		Routes:          manifest.Routes{Default: s.Route},
+		Metadata:        maps.Clone(s.Metadata),
+		DeadLetterTopic: s.DeadLetterTopic,
+	}
+	if b := s.BulkSubscribe; b != nil {
+		spec.BulkSubscribe = &manifest.BulkSubscribe{
+			Enabled:            b.Enabled,
+			MaxMessagesCount:   b.MaxMessagesCount,
+			MaxAwaitDurationMs: b.MaxAwaitDurationMs,
+		}
+	}
+	if s.Routes != nil {
+		spec.Routes.Default = s.Routes.Default
+		for _, r := range s.Routes.Rules {
+			spec.Routes.Rules = append(spec.Routes.Rules, manifest.Rule{Match: r.Match, Path: r.Path})
+		}
+	}
+	return spec
+}
@@ -7,7 +7,9 @@ import (
 
 	"github.com/stretchr/testify/assert"
 
+	"github.com/dapr/go-sdk/service/common"
 	"github.com/dapr/go-sdk/service/internal"
+	"github.com/dapr/go-sdk/service/manifest"
 )
 
 func TestTopicSubscripiton(t *testing.T) {
@@ -71,4 +73,36 @@ func TestTopicSubscripiton(t *testing.T) {
 			assert.Equal(t, `event.type == "100"`, sub.Routes.Rules[2].Match)
 		}
 	})
+	t.Run("spec", func(t *testing.T) {
+		sub := internal.NewTopicSubscription("test", "mytopic", "dead")
+		require.NoError(t, sub.SetDefaultRoute("/default"))
+		assert.Equal(t, manifest.Routes{Default: "/default"}, sub.Spec().Routes)
+
+		require.NoError(t, sub.AddRoutingRule("/50", `event.type == "50"`, 50))
+		require.NoError(t, sub.AddRoutingRule("/1", `event.type == "1"`, 1))
+		assert.Equal(t, manifest.SubscriptionSpec{
+			PubsubName: "test",
+			Topic:      "mytopic",
+			Routes: manifest.Routes{
+				Rules: []manifest.Rule{
+					{Match: `event.type == "1"`, Path: "/1"},
+					{Match: `event.type == "50"`, Path: "/50"},
+				},
+				Default: "/default",
+			},
+			DeadLetterTopic: "dead",
+		}, sub.Spec())
+	})
+	t.Run("bulk subscribe", func(t *testing.T) {
+		sub := internal.NewTopicSubscription("test", "mytopic", "")
+		require.NoError(t, sub.SetBulkSubscribe(nil))
+		assert.Nil(t, sub.Spec().BulkSubscribe)
+
+		require.NoError(t, sub.SetBulkSubscribe(&common.BulkSubscribeOptions{MaxMessagesCount: 50}))
+		require.NoError(t, sub.SetBulkSubscribe(nil))
+		require.NoError(t, sub.SetBulkSubscribe(&common.BulkSubscribeOptions{MaxMessagesCount: 50}))
+		require.EqualError(t, sub.SetBulkSubscribe(&common.BulkSubscribeOptions{MaxMessagesCount: 10}),
+			"subscription for topic mytopic on pubsub test already has other bulk subscribe options")
+		assert.Equal(t, &manifest.BulkSubscribe{Enabled: true, MaxMessagesCount: 50}, sub.Spec().BulkSubscribe)
+	})
 }
@@ -0,0 +1,99 @@
+/*
+Copyright 2026 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package internal
+
+import (
+	"context"
+	"strings"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
+	"google.golang.org/grpc/metadata"
+
+	"github.com/dapr/go-sdk/internal/tracing"
+	"github.com/dapr/go-sdk/service/common"
+	"github.com/dapr/go-sdk/version"
+)
+
+const instrumentationName = "github.com/dapr/go-sdk/service"
+
+// Dapr propagates the W3C trace context to apps.
+var propagator = propagation.TraceContext{}
+
+// ExtractTraceContext returns ctx with the remote span context carried by
+// carrier, the headers of a request of Dapr.
+func ExtractTraceContext(ctx context.Context, carrier propagation.TextMapCarrier) context.Context {
+	return propagator.Extract(ctx, carrier)
+}
+
+// IncomingTraceContext returns ctx with the remote span context of its
+// incoming gRPC metadata.
+func IncomingTraceContext(ctx context.Context) context.Context {
+	md, _ := metadata.FromIncomingContext(ctx)
+	return propagator.Extract(ctx, tracing.MetadataCarrier(md))
+}
+
+// StartSpan starts a server span of tp, or of the global tracer provider if tp
+// is nil, handling a call of Dapr to the app.
+func StartSpan(ctx context.Context, tp trace.TracerProvider, name string, attrs ...attribute.KeyValue) (context.Context, trace.Span) {
+	return tracer(tp).Start(ctx, name, trace.WithSpanKind(trace.SpanKindServer), trace.WithAttributes(attrs...))
+}
+
+// StartTopicEventSpan starts the consumer span of e with tp, or with the global
+// tracer provider if tp is nil, linked to the span of its producer as set in
+// the traceparent of the event.
+func StartTopicEventSpan(ctx context.Context, tp trace.TracerProvider, e *common.TopicEvent) (context.Context, trace.Span) {
+	opts := []trace.SpanStartOption{
+		trace.WithSpanKind(trace.SpanKindConsumer),
+		trace.WithAttributes(
+			attribute.String("messaging.system", "dapr"),
+			attribute.String("messaging.operation.type", "process"),
+			attribute.String("messaging.destination.name", e.Topic),
+			attribute.String("messaging.message.id", e.ID),
+			attribute.String("dapr.component", e.PubsubName),
+		),
+	}
+	if e.TraceParent != "" {
+		producer := propagator.Extract(context.Background(), propagation.MapCarrier{
+			"traceparent": e.TraceParent,
+			"tracestate":  e.TraceState,
+		})
+		if sc := trace.SpanContextFromContext(producer); sc.IsValid() {
+			opts = append(opts, trace.WithLinks(trace.Link{SpanContext: sc}))
+		}
+	}
+	return tracer(tp).Start(ctx, "process "+e.Topic, opts...)
+}
+
+// EndSpan ends span, with an error status if err is set.
+func EndSpan(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End()
+}
+
+// tracer returns the tracer of tp, or of the global tracer provider if tp is
+// nil, which may be set after the service is created.
+func tracer(tp trace.TracerProvider) trace.Tracer {
+	if tp == nil {
+		tp = otel.GetTracerProvider()
+	}
+	return tp.Tracer(instrumentationName,
+		trace.WithInstrumentationVersion(strings.TrimSpace(version.SDKVersion)))
+}
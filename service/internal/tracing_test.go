@@ -0,0 +1,70 @@
+package internal_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+	"go.opentelemetry.io/otel/trace/noop"
+	"google.golang.org/grpc/metadata"
+
+	"github.com/dapr/go-sdk/service/common"
+	"github.com/dapr/go-sdk/service/internal"
+)
+
+const (
+	deliveryTraceparent = "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01"
+	producerTraceparent = "00-0af7651916cd43dd8448eb211c80319c-b7ad6b7169203331-01"
+)
+
+func TestTracing(t *testing.T) {
+	spans := tracetest.NewSpanRecorder()
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(spans)))
+	t.Cleanup(func() { otel.SetTracerProvider(noop.NewTracerProvider()) })
+
+	ctx := metadata.NewIncomingContext(t.Context(), metadata.Pairs("traceparent", deliveryTraceparent))
+	ctx = internal.IncomingTraceContext(ctx)
+	assert.Equal(t, "4bf92f3577b34da6a3ce929d0e0e4736", trace.SpanContextFromContext(ctx).TraceID().String())
+
+	t.Run("topic event", func(t *testing.T) {
+		_, span := internal.StartTopicEventSpan(ctx, nil, &common.TopicEvent{
+			ID:          "1",
+			PubsubName:  "messages",
+			Topic:       "orders",
+			TraceParent: producerTraceparent,
+		})
+		internal.EndSpan(span, nil)
+
+		ended := spans.Ended()[len(spans.Ended())-1]
+		assert.Equal(t, "process orders", ended.Name())
+		assert.Equal(t, trace.SpanKindConsumer, ended.SpanKind())
+		assert.Equal(t, "00f067aa0ba902b7", ended.Parent().SpanID().String())
+		require.Len(t, ended.Links(), 1)
+		assert.Equal(t, "b7ad6b7169203331", ended.Links()[0].SpanContext.SpanID().String())
+		assert.Contains(t, ended.Attributes(), attribute.String("dapr.component", "messages"))
+		assert.Contains(t, ended.Attributes(), attribute.String("messaging.message.id", "1"))
+	})
+
+	t.Run("topic event without trace context", func(t *testing.T) {
+		_, span := internal.StartTopicEventSpan(t.Context(), nil, &common.TopicEvent{Topic: "orders", TraceParent: "invalid"})
+		internal.EndSpan(span, nil)
+		assert.Empty(t, spans.Ended()[len(spans.Ended())-1].Links())
+	})
+
+	t.Run("error", func(t *testing.T) {
+		_, span := internal.StartSpan(ctx, nil, "invoke orders", attribute.String("dapr.method", "orders"))
+		internal.EndSpan(span, errors.New("failed"))
+
+		ended := spans.Ended()[len(spans.Ended())-1]
+		assert.Equal(t, trace.SpanKindServer, ended.SpanKind())
+		assert.Equal(t, codes.Error, ended.Status().Code)
+		assert.Equal(t, "failed", ended.Status().Description)
+	})
+}
@@ -0,0 +1,312 @@
+/*
+Copyright 2026 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package manifest converts the topic subscriptions registered with a service
+// to and from declarative Dapr v2alpha1 Subscription resources, so that
+// deployed manifests can be generated from, and checked against, the code.
+package manifest
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"maps"
+	"slices"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/dapr/go-sdk/service/common"
+)
+
+const (
+	// APIVersion is the API version of generated Subscription resources.
+	APIVersion = "dapr.io/v2alpha1"
+	// Kind is the kind of Subscription resources.
+	Kind = "Subscription"
+
+	maxNameLength = 253
+)
+
+// Subscription is a declarative Dapr Subscription resource.
+type Subscription struct {
+	APIVersion string           `yaml:"apiVersion"`
+	Kind       string           `yaml:"kind"`
+	Metadata   ObjectMeta       `yaml:"metadata"`
+	Spec       SubscriptionSpec `yaml:"spec"`
+	// Scopes are the app IDs the subscription applies to, or all apps if empty.
+	Scopes []string `yaml:"scopes,omitempty"`
+}
+
+// ObjectMeta is the metadata of a resource.
+type ObjectMeta struct {
+	Name      string `yaml:"name"`
+	Namespace string `yaml:"namespace,omitempty"`
+}
+
+// SubscriptionSpec is the subscription of a topic.
+type SubscriptionSpec struct {
+	PubsubName      string            `yaml:"pubsubname"`
+	Topic           string            `yaml:"topic"`
+	Routes          Routes            `yaml:"routes"`
+	Metadata        map[string]string `yaml:"metadata,omitempty"`
+	DeadLetterTopic string            `yaml:"deadLetterTopic,omitempty"`
+	BulkSubscribe   *BulkSubscribe    `yaml:"bulkSubscribe,omitempty"`
+}
+
+// Routes are the routing rules of a subscription, evaluated in order, and the
+// default route of events matching none of them.
+type Routes struct {
+	Rules   []Rule `yaml:"rules,omitempty"`
+	Default string `yaml:"default,omitempty"`
+}
+
+// Rule routes events matching a CEL expression to a path.
+type Rule struct {
+	Match string `yaml:"match"`
+	Path  string `yaml:"path"`
+}
+
+// BulkSubscribe are the bulk delivery settings of a subscription.
+type BulkSubscribe struct {
+	Enabled            bool  `yaml:"enabled"`
+	MaxMessagesCount   int32 `yaml:"maxMessagesCount,omitempty"`
+	MaxAwaitDurationMs int32 `yaml:"maxAwaitDurationMs,omitempty"`
+}
+
+// Registrar is implemented by the HTTP and gRPC services, which list the
+// subscriptions of their topic handlers.
+type Registrar interface {
+	TopicSubscriptions() []SubscriptionSpec
+}
+
+// Options are the options of generated resources.
+type Options struct {
+	Namespace string
+	Scopes    []string
+	// Name returns the name of the resource of a subscription. Defaults to
+	// the pubsub name and topic, made a valid resource name.
+	Name func(spec SubscriptionSpec) string
+}
+
+// Generate returns the Subscription resources of the topic handlers
+// registered with s, sorted by pubsub name and topic.
+func Generate(s common.Service, opts Options) ([]Subscription, error) {
+	r, ok := s.(Registrar)
+	if !ok {
+		return nil, fmt.Errorf("service %T does not list its topic subscriptions", s)
+	}
+	if opts.Name == nil {
+		opts.Name = defaultName
+	}
+
+	specs := sortedSpecs(r)
+	subs := make([]Subscription, 0, len(specs))
+	for _, spec := range specs {
+		subs = append(subs, Subscription{
+			APIVersion: APIVersion,
+			Kind:       Kind,
+			Metadata:   ObjectMeta{Name: opts.Name(spec), Namespace: opts.Namespace},
+			Spec:       spec,
+			Scopes:     slices.Clone(opts.Scopes),
+		})
+	}
+	return subs, nil
+}
+
+// Export writes the Subscription resources of the topic handlers registered
+// with s to w, as a multi-document YAML stream.
+func Export(w io.Writer, s common.Service, opts Options) error {
+	subs, err := Generate(s, opts)
+	if err != nil {
+		return err
+	}
+	enc := yaml.NewEncoder(w)
+	enc.SetIndent(2)
+	for i := range subs {
+		if err = enc.Encode(&subs[i]); err != nil {
+			return fmt.Errorf("error encoding subscription %s: %w", subs[i].Metadata.Name, err)
+		}
+	}
+	return enc.Close()
+}
+
+// Load reads the Subscription resources of a multi-document YAML stream, and
+// validates them. Documents of other kinds, such as components, are skipped.
+func Load(r io.Reader) ([]Subscription, error) {
+	var subs []Subscription
+	dec := yaml.NewDecoder(r)
+	for i := 1; ; i++ {
+		var doc yaml.Node
+		if err := dec.Decode(&doc); errors.Is(err, io.EOF) {
+			return subs, nil
+		} else if err != nil {
+			return nil, fmt.Errorf("error parsing document %d: %w", i, err)
+		}
+
+		var typ struct {
+			Kind string `yaml:"kind"`
+		}
+		if err := doc.Decode(&typ); err != nil {
+			return nil, fmt.Errorf("error parsing document %d: %w", i, err)
+		}
+		if typ.Kind != Kind {
+			continue
+		}
+
+		var sub Subscription
+		if err := doc.Decode(&sub); err != nil {
+			return nil, fmt.Errorf("error parsing document %d: %w", i, err)
+		}
+		if err := sub.Validate(); err != nil {
+			return nil, fmt.Errorf("invalid subscription in document %d: %w", i, err)
+		}
+		subs = append(subs, sub)
+	}
+}
+
+// Validate reports the errors of a Subscription resource.
+func (s *Subscription) Validate() error {
+	var errs []error
+	if s.APIVersion != APIVersion {
+		errs = append(errs, fmt.Errorf("unsupported apiVersion %q, want %s", s.APIVersion, APIVersion))
+	}
+	if s.Kind != Kind {
+		errs = append(errs, fmt.Errorf("unsupported kind %q, want %s", s.Kind, Kind))
+	}
+	if s.Metadata.Name == "" {
+		errs = append(errs, errors.New("metadata.name required"))
+	}
+	if s.Spec.PubsubName == "" {
+		errs = append(errs, errors.New("spec.pubsubname required"))
+	}
+	if s.Spec.Topic == "" {
+		errs = append(errs, errors.New("spec.topic required"))
+	}
+	for i, rule := range s.Spec.Routes.Rules {
+		if rule.Match == "" {
+			errs = append(errs, fmt.Errorf("spec.routes.rules[%d].match required", i))
+		}
+		if rule.Path == "" {
+			errs = append(errs, fmt.Errorf("spec.routes.rules[%d].path required", i))
+		}
+	}
+	if b := s.Spec.BulkSubscribe; b != nil && (b.MaxMessagesCount < 0 || b.MaxAwaitDurationMs < 0) {
+		errs = append(errs, errors.New("spec.bulkSubscribe limits must not be negative"))
+	}
+	return errors.Join(errs...)
+}
+
+// Verify reports the differences between manifests and the topic handlers
+// registered with s: subscriptions without handlers, handlers without
+// subscriptions, and subscriptions whose routes, metadata, dead-letter topic
+// or bulk settings differ from those of the handlers. Manifests which are not
+// scoped to appID are ignored, unless appID is empty.
+func Verify(manifests []Subscription, s common.Service, appID string) error {
+	r, ok := s.(Registrar)
+	if !ok {
+		return fmt.Errorf("service %T does not list its topic subscriptions", s)
+	}
+
+	declared := make(map[string]Subscription, len(manifests))
+	var errs []error
+	for _, m := range manifests {
+		if appID != "" && len(m.Scopes) > 0 && !slices.Contains(m.Scopes, appID) {
+			continue
+		}
+		key := specKey(m.Spec)
+		if prev, ok := declared[key]; ok {
+			errs = append(errs, fmt.Errorf("subscriptions %s and %s both subscribe to %s", prev.Metadata.Name, m.Metadata.Name, key))
+			continue
+		}
+		declared[key] = m
+	}
+
+	for _, spec := range sortedSpecs(r) {
+		key := specKey(spec)
+		m, ok := declared[key]
+		if !ok {
+			errs = append(errs, fmt.Errorf("%s: handlers are registered but no subscription is declared", key))
+			continue
+		}
+		delete(declared, key)
+		for _, diff := range diffSpecs(m.Spec, spec) {
+			errs = append(errs, fmt.Errorf("%s: subscription %s %s", key, m.Metadata.Name, diff))
+		}
+	}
+	for _, key := range slices.Sorted(maps.Keys(declared)) {
+		errs = append(errs, fmt.Errorf("%s: subscription %s is declared but no handler is registered", key, declared[key].Metadata.Name))
+	}
+	return errors.Join(errs...)
+}
+
+// diffSpecs describes how declared differs from the registered spec.
+func diffSpecs(declared, registered SubscriptionSpec) []string {
+	var diffs []string
+	if declared.Routes.Default != registered.Routes.Default {
+		diffs = append(diffs, fmt.Sprintf("has default route %q, handlers have %q", declared.Routes.Default, registered.Routes.Default))
+	}
+	if !slices.Equal(declared.Routes.Rules, registered.Routes.Rules) {
+		diffs = append(diffs, fmt.Sprintf("has routing rules %v, handlers have %v", declared.Routes.Rules, registered.Routes.Rules))
+	}
+	if declared.DeadLetterTopic != registered.DeadLetterTopic {
+		diffs = append(diffs, fmt.Sprintf("has dead-letter topic %q, handlers have %q", declared.DeadLetterTopic, registered.DeadLetterTopic))
+	}
+	if !maps.Equal(declared.Metadata, registered.Metadata) {
+		diffs = append(diffs, fmt.Sprintf("has metadata %v, handlers have %v", declared.Metadata, registered.Metadata))
+	}
+	if d, r := bulkSubscribe(declared), bulkSubscribe(registered); d != r {
+		diffs = append(diffs, fmt.Sprintf("has bulk subscribe %+v, handlers have %+v", d, r))
+	}
+	return diffs
+}
+
+// bulkSubscribe returns the bulk delivery settings of spec, which are all zero
+// when bulk delivery is disabled.
+func bulkSubscribe(spec SubscriptionSpec) BulkSubscribe {
+	if spec.BulkSubscribe == nil || !spec.BulkSubscribe.Enabled {
+		return BulkSubscribe{}
+	}
+	return *spec.BulkSubscribe
+}
+
+func sortedSpecs(r Registrar) []SubscriptionSpec {
+	specs := r.TopicSubscriptions()
+	slices.SortFunc(specs, func(a, b SubscriptionSpec) int {
+		return strings.Compare(specKey(a), specKey(b))
+	})
+	return specs
+}
+
+func specKey(spec SubscriptionSpec) string {
+	return spec.PubsubName + "/" + spec.Topic
+}
+
+// defaultName returns the pubsub name and topic of spec as a DNS subdomain
+// name, as required for resource names.
+func defaultName(spec SubscriptionSpec) string {
+	name := strings.Map(func(r rune) rune {
+		switch {
+		case r >= 'a' && r <= 'z', r >= '0' && r <= '9', r == '-', r == '.':
+			return r
+		case r >= 'A' && r <= 'Z':
+			return r + 'a' - 'A'
+		default:
+			return '-'
+		}
+	}, spec.PubsubName+"-"+spec.Topic)
+	if len(name) > maxNameLength {
+		name = name[:maxNameLength]
+	}
+	return strings.Trim(name, "-.")
+}
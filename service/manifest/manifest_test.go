@@ -0,0 +1,198 @@
+/*
+Copyright 2026 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package manifest_test
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/dapr/go-sdk/service/common"
+	daprd "github.com/dapr/go-sdk/service/grpc"
+	daprhttp "github.com/dapr/go-sdk/service/http"
+	"github.com/dapr/go-sdk/service/manifest"
+)
+
+func handler(ctx context.Context, e *common.TopicEvent) (bool, error) {
+	return false, nil
+}
+
+func newService(t *testing.T) common.Service {
+	t.Helper()
+	s := daprhttp.NewService(":0")
+	subs := []*common.Subscription{
+		{PubsubName: "messages", Topic: "orders", Route: "/orders", DeadLetterTopic: "orders-dead", Metadata: map[string]string{"rawPayload": "true"}},
+		{PubsubName: "messages", Topic: "orders", Route: "/orders/large", Match: `event.data.total > 100`, Priority: 2},
+		{PubsubName: "messages", Topic: "orders", Route: "/orders/eu", Match: `event.data.region == "eu"`, Priority: 1},
+		{PubsubName: "Kafka_PubSub", Topic: "audit.Events", Route: "/audit", BulkSubscribe: &common.BulkSubscribeOptions{MaxMessagesCount: 100}},
+	}
+	for _, sub := range subs {
+		require.NoError(t, s.AddTopicEventHandler(sub, handler))
+	}
+	return s
+}
+
+const expected = `apiVersion: dapr.io/v2alpha1
+kind: Subscription
+metadata:
+  name: kafka-pubsub-audit.events
+  namespace: prod
+spec:
+  pubsubname: Kafka_PubSub
+  topic: audit.Events
+  routes:
+    default: /audit
+  bulkSubscribe:
+    enabled: true
+    maxMessagesCount: 100
+scopes:
+  - checkout
+---
+apiVersion: dapr.io/v2alpha1
+kind: Subscription
+metadata:
+  name: messages-orders
+  namespace: prod
+spec:
+  pubsubname: messages
+  topic: orders
+  routes:
+    rules:
+      - match: event.data.region == "eu"
+        path: /orders/eu
+      - match: event.data.total > 100
+        path: /orders/large
+    default: /orders
+  metadata:
+    rawPayload: "true"
+  deadLetterTopic: orders-dead
+scopes:
+  - checkout
+`
+
+func TestExport(t *testing.T) {
+	s := newService(t)
+	var buf bytes.Buffer
+	require.NoError(t, manifest.Export(&buf, s, manifest.Options{Namespace: "prod", Scopes: []string{"checkout"}}))
+	assert.Equal(t, expected, buf.String())
+
+	subs, err := manifest.Load(&buf)
+	require.NoError(t, err)
+	require.Len(t, subs, 2)
+	require.NoError(t, manifest.Verify(subs, s, "checkout"))
+
+	t.Run("grpc", func(t *testing.T) {
+		s := daprd.NewServiceWithListener(nil)
+		require.NoError(t, s.AddTopicEventHandler(&common.Subscription{PubsubName: "messages", Topic: "orders"}, handler))
+		subs, err := manifest.Generate(s, manifest.Options{Name: func(spec manifest.SubscriptionSpec) string { return "custom" }})
+		require.NoError(t, err)
+		require.Len(t, subs, 1)
+		assert.Equal(t, "custom", subs[0].Metadata.Name)
+		assert.Empty(t, subs[0].Spec.Routes)
+	})
+}
+
+func TestLoad(t *testing.T) {
+	t.Run("skips other kinds", func(t *testing.T) {
+		subs, err := manifest.Load(strings.NewReader(`apiVersion: dapr.io/v1alpha1
+kind: Component
+metadata:
+  name: messages
+spec:
+  type: pubsub.redis
+---
+apiVersion: dapr.io/v2alpha1
+kind: Subscription
+metadata:
+  name: orders
+spec:
+  pubsubname: messages
+  topic: orders
+  routes:
+    default: /orders
+  bulkSubscribe:
+    enabled: true
+    maxMessagesCount: 100
+`))
+		require.NoError(t, err)
+		require.Len(t, subs, 1)
+		assert.Equal(t, "orders", subs[0].Metadata.Name)
+		assert.Equal(t, int32(100), subs[0].Spec.BulkSubscribe.MaxMessagesCount)
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		_, err := manifest.Load(strings.NewReader(`apiVersion: dapr.io/v1alpha1
+kind: Subscription
+metadata:
+  name: orders
+spec:
+  topic: orders
+  route: /orders
+  routes:
+    rules:
+      - match: event.type == "order"
+`))
+		require.ErrorContains(t, err, "document 1")
+		require.ErrorContains(t, err, `unsupported apiVersion "dapr.io/v1alpha1"`)
+		require.ErrorContains(t, err, "spec.pubsubname required")
+		require.ErrorContains(t, err, "spec.routes.rules[0].path required")
+
+		_, err = manifest.Load(strings.NewReader("kind: [Subscription"))
+		require.ErrorContains(t, err, "error parsing document 1")
+	})
+}
+
+func TestVerify(t *testing.T) {
+	s := newService(t)
+	subs, err := manifest.Generate(s, manifest.Options{})
+	require.NoError(t, err)
+	require.NoError(t, manifest.Verify(subs, s, ""))
+
+	t.Run("differences", func(t *testing.T) {
+		orders := subs[1]
+		orders.Spec.Routes.Rules = orders.Spec.Routes.Rules[1:]
+		orders.Spec.DeadLetterTopic = ""
+		orders.Spec.Metadata = nil
+		orders.Spec.BulkSubscribe = &manifest.BulkSubscribe{Enabled: true}
+		unknown := manifest.Subscription{
+			Metadata: manifest.ObjectMeta{Name: "payments"},
+			Spec:     manifest.SubscriptionSpec{PubsubName: "messages", Topic: "payments"},
+		}
+
+		err := manifest.Verify([]manifest.Subscription{orders, unknown}, s, "")
+		require.Error(t, err)
+		msg := err.Error()
+		assert.Contains(t, msg, "Kafka_PubSub/audit.Events: handlers are registered but no subscription is declared")
+		assert.Contains(t, msg, "messages/orders: subscription messages-orders has routing rules")
+		assert.Contains(t, msg, `has dead-letter topic "", handlers have "orders-dead"`)
+		assert.Contains(t, msg, "has metadata map[], handlers have map[rawPayload:true]")
+		assert.Contains(t, msg, "has bulk subscribe {Enabled:true MaxMessagesCount:0 MaxAwaitDurationMs:0}, handlers have {Enabled:false MaxMessagesCount:0 MaxAwaitDurationMs:0}")
+		assert.Contains(t, msg, "messages/payments: subscription payments is declared but no handler is registered")
+	})
+
+	t.Run("scopes", func(t *testing.T) {
+		scoped := append([]manifest.Subscription(nil), subs...)
+		scoped[0].Scopes = []string{"other"}
+		require.ErrorContains(t, manifest.Verify(scoped, s, "checkout"), "Kafka_PubSub/audit.Events: handlers are registered")
+		require.NoError(t, manifest.Verify(scoped, s, "other"))
+	})
+
+	t.Run("duplicates", func(t *testing.T) {
+		require.ErrorContains(t, manifest.Verify(append(subs, subs[0]), s, ""), "both subscribe to Kafka_PubSub/audit.Events")
+	})
+}
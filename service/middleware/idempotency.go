@@ -0,0 +1,236 @@
+/*
+Copyright 2026 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package middleware
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/dapr/go-sdk/client"
+	"github.com/dapr/go-sdk/service/common"
+)
+
+const (
+	// DefaultIdempotencyTTL is the default time processed events are remembered for.
+	DefaultIdempotencyTTL = 24 * time.Hour
+
+	metadataTTLInSeconds = "ttlInSeconds"
+)
+
+// StateClient is the part of the Dapr client used to record processed events.
+type StateClient interface {
+	GetState(ctx context.Context, storeName, key string, meta map[string]string) (*client.StateItem, error)
+	SaveState(ctx context.Context, storeName, key string, data []byte, meta map[string]string, so ...client.StateOption) error
+	ExecuteStateTransaction(ctx context.Context, storeName string, meta map[string]string, ops []*client.StateOperation) error
+}
+
+// IdempotencyOptions are the options of an Idempotency middleware.
+type IdempotencyOptions struct {
+	// StoreName is the state store processed events are recorded in.
+	StoreName string
+	// Key returns the de-duplication key of an event. Events with an empty key
+	// are always handled. Defaults to the pubsub name, topic and event ID.
+	Key func(e *common.TopicEvent) string
+	// TTL is how long processed events are remembered, rounded up to whole
+	// seconds. Defaults to DefaultIdempotencyTTL.
+	TTL time.Duration
+	// Logger receives the errors Handler can not return. Defaults to a logger
+	// writing to stdout.
+	Logger *log.Logger
+}
+
+// Idempotency skips topic events which were already handled successfully, as
+// recorded in a state store. Events are marked as processed once the handler
+// succeeds, so deliveries of an event that overlap may both be handled.
+//
+// Handlers can add state operations to the Transaction of their context, which
+// are then executed in a single transaction with the processed mark. The state
+// store must then support transactions.
+type Idempotency struct {
+	client StateClient
+	opts   IdempotencyOptions
+}
+
+// NewIdempotency returns an Idempotency middleware recording processed events with c.
+func NewIdempotency(c StateClient, opts IdempotencyOptions) (*Idempotency, error) {
+	if c == nil {
+		return nil, errors.New("client required")
+	}
+	if opts.StoreName == "" {
+		return nil, errors.New("store name required")
+	}
+	if opts.Key == nil {
+		opts.Key = defaultIdempotencyKey
+	}
+	if opts.TTL <= 0 {
+		opts.TTL = DefaultIdempotencyTTL
+	}
+	// state stores expire items in whole seconds, where 0 would never expire them
+	opts.TTL = (opts.TTL + time.Second - 1).Truncate(time.Second)
+	if opts.Logger == nil {
+		opts.Logger = logger
+	}
+	return &Idempotency{client: c, opts: opts}, nil
+}
+
+func defaultIdempotencyKey(e *common.TopicEvent) string {
+	if e.ID == "" {
+		return ""
+	}
+	return e.PubsubName + "/" + e.Topic + "/" + e.ID
+}
+
+// Subscriber wraps next, to be registered with common.Service.AddTopicEventSubscriber.
+func (i *Idempotency) Subscriber(next common.TopicEventSubscriber) common.TopicEventSubscriber {
+	return common.TopicEventHandler(func(ctx context.Context, e *common.TopicEvent) (bool, error) {
+		key := i.opts.Key(e)
+		if key == "" {
+			return next.Handle(ctx, e)
+		}
+		if processed, err := i.processed(ctx, key); err != nil {
+			return true, err
+		} else if processed {
+			return false, nil
+		}
+
+		tx := &Transaction{}
+		if retry, err := next.Handle(withTransaction(ctx, tx), e); err != nil {
+			return retry, err
+		}
+		if err := i.markProcessed(ctx, key, tx); err != nil {
+			return true, err
+		}
+		return false, nil
+	})
+}
+
+// Handler wraps next, to be used with client.SubscribeWithContextHandler.
+// Only events handled with SubscriptionResponseStatusSuccess are marked as processed.
+func (i *Idempotency) Handler(next client.SubscriptionContextHandleFunction) client.SubscriptionContextHandleFunction {
+	return func(ctx context.Context, e *common.TopicEvent) common.SubscriptionResponseStatus {
+		key := i.opts.Key(e)
+		if key == "" {
+			return next(ctx, e)
+		}
+		if processed, err := i.processed(ctx, key); err != nil {
+			i.opts.Logger.Printf("error checking whether event %s was processed: %v", e.ID, err)
+			return common.SubscriptionResponseStatusRetry
+		} else if processed {
+			return common.SubscriptionResponseStatusSuccess
+		}
+
+		tx := &Transaction{}
+		if status := next(withTransaction(ctx, tx), e); status != common.SubscriptionResponseStatusSuccess {
+			return status
+		}
+		if err := i.markProcessed(ctx, key, tx); err != nil {
+			i.opts.Logger.Printf("error marking event %s as processed: %v", e.ID, err)
+			return common.SubscriptionResponseStatusRetry
+		}
+		return common.SubscriptionResponseStatusSuccess
+	}
+}
+
+func (i *Idempotency) processed(ctx context.Context, key string) (bool, error) {
+	item, err := i.client.GetState(ctx, i.opts.StoreName, key, nil)
+	if err != nil {
+		return false, fmt.Errorf("error getting processed mark %s: %w", key, err)
+	}
+	return item != nil && len(item.Value) > 0, nil
+}
+
+// markProcessed saves the processed mark of key, together with the state
+// operations of tx if there are any.
+func (i *Idempotency) markProcessed(ctx context.Context, key string, tx *Transaction) error {
+	value := []byte(time.Now().UTC().Format(time.RFC3339))
+	meta := map[string]string{metadataTTLInSeconds: strconv.Itoa(int(i.opts.TTL.Seconds()))}
+
+	ops := tx.operations()
+	if len(ops) == 0 {
+		if err := i.client.SaveState(ctx, i.opts.StoreName, key, value, meta); err != nil {
+			return fmt.Errorf("error saving processed mark %s: %w", key, err)
+		}
+		return nil
+	}
+
+	ops = append(ops, &client.StateOperation{
+		Type: client.StateOperationTypeUpsert,
+		Item: &client.SetStateItem{Key: key, Value: value, Metadata: meta},
+	})
+	if err := i.client.ExecuteStateTransaction(ctx, i.opts.StoreName, nil, ops); err != nil {
+		return fmt.Errorf("error saving processed mark %s with handler state: %w", key, err)
+	}
+	return nil
+}
+
+type transactionKey struct{}
+
+// Transaction collects the state operations of a handler wrapped by
+// Idempotency, which are executed together with the processed mark once the
+// handler succeeds.
+type Transaction struct {
+	lock sync.Mutex
+	ops  []*client.StateOperation
+}
+
+func withTransaction(ctx context.Context, tx *Transaction) context.Context {
+	return context.WithValue(ctx, transactionKey{}, tx)
+}
+
+// TransactionFromContext returns the Transaction of a handler wrapped by
+// Idempotency, or nil.
+func TransactionFromContext(ctx context.Context) *Transaction {
+	tx, _ := ctx.Value(transactionKey{}).(*Transaction)
+	return tx
+}
+
+// Save adds an upsert of key to the transaction.
+func (t *Transaction) Save(key string, value []byte, meta map[string]string) {
+	t.Add(&client.StateOperation{
+		Type: client.StateOperationTypeUpsert,
+		Item: &client.SetStateItem{Key: key, Value: value, Metadata: meta},
+	})
+}
+
+// Delete adds a delete of key to the transaction.
+func (t *Transaction) Delete(key string) {
+	t.Add(&client.StateOperation{
+		Type: client.StateOperationTypeDelete,
+		Item: &client.SetStateItem{Key: key},
+	})
+}
+
+// Add adds ops to the transaction.
+func (t *Transaction) Add(ops ...*client.StateOperation) {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	t.ops = append(t.ops, ops...)
+}
+
+func (t *Transaction) operations() []*client.StateOperation {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	return t.ops
+}
+
+func (t *Transaction) reset() {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	t.ops = nil
+}
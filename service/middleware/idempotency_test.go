@@ -0,0 +1,220 @@
+/*
+Copyright 2026 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package middleware
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"log"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/dapr/go-sdk/client"
+	"github.com/dapr/go-sdk/service/common"
+)
+
+// memStateClient is an in-memory state store recording the metadata of writes.
+type memStateClient struct {
+	lock         sync.Mutex
+	values       map[string][]byte
+	meta         map[string]map[string]string
+	transactions int
+	err          error
+}
+
+func newMemStateClient() *memStateClient {
+	return &memStateClient{values: map[string][]byte{}, meta: map[string]map[string]string{}}
+}
+
+func (m *memStateClient) GetState(ctx context.Context, storeName, key string, meta map[string]string) (*client.StateItem, error) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	return &client.StateItem{Key: key, Value: m.values[key]}, nil
+}
+
+func (m *memStateClient) SaveState(ctx context.Context, storeName, key string, data []byte, meta map[string]string, so ...client.StateOption) error {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	if m.err != nil {
+		return m.err
+	}
+	m.values[key] = data
+	m.meta[key] = meta
+	return nil
+}
+
+func (m *memStateClient) ExecuteStateTransaction(ctx context.Context, storeName string, meta map[string]string, ops []*client.StateOperation) error {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	if m.err != nil {
+		return m.err
+	}
+	m.transactions++
+	for _, op := range ops {
+		switch op.Type {
+		case client.StateOperationTypeUpsert:
+			m.values[op.Item.Key] = op.Item.Value
+			m.meta[op.Item.Key] = op.Item.Metadata
+		case client.StateOperationTypeDelete:
+			delete(m.values, op.Item.Key)
+		}
+	}
+	return nil
+}
+
+func (m *memStateClient) get(key string) []byte {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	return m.values[key]
+}
+
+func TestNewIdempotency(t *testing.T) {
+	_, err := NewIdempotency(nil, IdempotencyOptions{StoreName: "store"})
+	require.Error(t, err)
+	_, err = NewIdempotency(newMemStateClient(), IdempotencyOptions{})
+	require.Error(t, err)
+}
+
+func TestIdempotencySubscriber(t *testing.T) {
+	ctx := t.Context()
+	event := &common.TopicEvent{ID: "e1", PubsubName: "pubsub", Topic: "orders"}
+
+	t.Run("skips processed events", func(t *testing.T) {
+		state := newMemStateClient()
+		i, err := NewIdempotency(state, IdempotencyOptions{StoreName: "store", TTL: time.Hour})
+		require.NoError(t, err)
+
+		var calls int
+		fail := true
+		sub := i.Subscriber(common.TopicEventHandler(func(ctx context.Context, e *common.TopicEvent) (bool, error) {
+			calls++
+			if fail {
+				return true, errors.New("failed")
+			}
+			return false, nil
+		}))
+
+		retry, err := sub.Handle(ctx, event)
+		require.Error(t, err)
+		assert.True(t, retry)
+		assert.Empty(t, state.get("pubsub/orders/e1"), "failed events are not marked")
+
+		fail = false
+		for range 2 {
+			retry, err = sub.Handle(ctx, event)
+			require.NoError(t, err)
+			assert.False(t, retry)
+		}
+		assert.Equal(t, 2, calls)
+		assert.NotEmpty(t, state.get("pubsub/orders/e1"))
+		assert.Equal(t, map[string]string{"ttlInSeconds": "3600"}, state.meta["pubsub/orders/e1"])
+		assert.Zero(t, state.transactions)
+
+		// Events without a key are always handled.
+		_, err = sub.Handle(ctx, &common.TopicEvent{Topic: "orders"})
+		require.NoError(t, err)
+		assert.Equal(t, 3, calls)
+	})
+
+	t.Run("stores the mark with the handler state", func(t *testing.T) {
+		state := newMemStateClient()
+		state.values["stale"] = []byte("x")
+		i, err := NewIdempotency(state, IdempotencyOptions{
+			StoreName: "store",
+			Key:       func(e *common.TopicEvent) string { return "order-" + e.ID },
+		})
+		require.NoError(t, err)
+
+		sub := i.Subscriber(common.TopicEventHandler(func(ctx context.Context, e *common.TopicEvent) (bool, error) {
+			tx := TransactionFromContext(ctx)
+			require.NotNil(t, tx)
+			tx.Save("total", []byte("42"), nil)
+			tx.Delete("stale")
+			return false, nil
+		}))
+		_, err = sub.Handle(ctx, event)
+		require.NoError(t, err)
+		assert.Equal(t, 1, state.transactions)
+		assert.Equal(t, []byte("42"), state.get("total"))
+		assert.Empty(t, state.get("stale"))
+		assert.NotEmpty(t, state.get("order-e1"))
+		assert.Equal(t, map[string]string{"ttlInSeconds": "86400"}, state.meta["order-e1"])
+	})
+
+	t.Run("TTL is rounded up to whole seconds", func(t *testing.T) {
+		state := newMemStateClient()
+		i, err := NewIdempotency(state, IdempotencyOptions{StoreName: "store", TTL: 1500 * time.Millisecond})
+		require.NoError(t, err)
+		_, err = i.Subscriber(common.TopicEventHandler(func(ctx context.Context, e *common.TopicEvent) (bool, error) {
+			return false, nil
+		})).Handle(ctx, event)
+		require.NoError(t, err)
+		assert.Equal(t, map[string]string{"ttlInSeconds": "2"}, state.meta["pubsub/orders/e1"])
+
+		i, err = NewIdempotency(newMemStateClient(), IdempotencyOptions{StoreName: "store", TTL: time.Millisecond})
+		require.NoError(t, err)
+		assert.Equal(t, time.Second, i.opts.TTL)
+	})
+
+	t.Run("retries when the mark cannot be saved", func(t *testing.T) {
+		state := newMemStateClient()
+		state.err = errors.New("store unavailable")
+		i, err := NewIdempotency(state, IdempotencyOptions{StoreName: "store"})
+		require.NoError(t, err)
+
+		sub := i.Subscriber(common.TopicEventHandler(func(ctx context.Context, e *common.TopicEvent) (bool, error) {
+			TransactionFromContext(ctx).Save("total", []byte("42"), nil)
+			return false, nil
+		}))
+		retry, err := sub.Handle(ctx, event)
+		require.Error(t, err)
+		assert.True(t, retry)
+		assert.Empty(t, state.get("total"))
+	})
+}
+
+func TestIdempotencyHandler(t *testing.T) {
+	ctx := t.Context()
+	state := newMemStateClient()
+	var logs bytes.Buffer
+	i, err := NewIdempotency(state, IdempotencyOptions{StoreName: "store", Logger: log.New(&logs, "", 0)})
+	require.NoError(t, err)
+
+	status := common.SubscriptionResponseStatusRetry
+	var calls int
+	handler := i.Handler(func(ctx context.Context, e *common.TopicEvent) common.SubscriptionResponseStatus {
+		calls++
+		return status
+	})
+
+	event := &common.TopicEvent{ID: "e1", PubsubName: "pubsub", Topic: "orders"}
+	assert.Equal(t, common.SubscriptionResponseStatusRetry, handler(ctx, event))
+	status = common.SubscriptionResponseStatusDrop
+	assert.Equal(t, common.SubscriptionResponseStatusDrop, handler(ctx, event))
+	assert.Empty(t, state.get("pubsub/orders/e1"))
+
+	status = common.SubscriptionResponseStatusSuccess
+	assert.Equal(t, common.SubscriptionResponseStatusSuccess, handler(ctx, event))
+	assert.Equal(t, common.SubscriptionResponseStatusSuccess, handler(ctx, event))
+	assert.Equal(t, 3, calls)
+
+	state.err = errors.New("store unavailable")
+	assert.Equal(t, common.SubscriptionResponseStatusRetry, handler(ctx, &common.TopicEvent{ID: "e2"}))
+	assert.Contains(t, logs.String(), "error marking event e2 as processed: ")
+}
@@ -0,0 +1,39 @@
+/*
+Copyright 2026 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package middleware provides reusable wrappers for topic event handlers of
+// Dapr services and streaming subscriptions.
+package middleware
+
+import (
+	"log"
+	"os"
+
+	"github.com/dapr/go-sdk/service/common"
+	"github.com/dapr/go-sdk/service/internal"
+)
+
+// logger is used by middlewares configured without a logger.
+var logger = log.New(os.Stdout, "", 0)
+
+// TopicMiddleware wraps a topic event subscriber, as registered with
+// common.Service.AddTopicEventSubscriber of both the HTTP and gRPC services.
+// Middleware can also be applied to every handler of a service with
+// common.TopicEventMiddlewareUser.
+type TopicMiddleware = common.TopicEventMiddleware
+
+// Chain wraps subscriber with middlewares. The first middleware is the
+// outermost one, and sees every event first.
+func Chain(subscriber common.TopicEventSubscriber, middlewares ...TopicMiddleware) common.TopicEventSubscriber {
+	return internal.WrapSubscriber(subscriber, middlewares)
+}
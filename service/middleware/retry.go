@@ -0,0 +1,207 @@
+/*
+Copyright 2026 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package middleware
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/cenkalti/backoff/v4"
+
+	"github.com/dapr/go-sdk/client"
+	"github.com/dapr/go-sdk/service/common"
+)
+
+const (
+	defaultRetryInterval    = 100 * time.Millisecond
+	defaultMaxRetryInterval = 5 * time.Second
+)
+
+// DeliveryCountKeys are the event metadata keys holding the delivery count of
+// an event by default, as set by some pubsub components. Keys are matched
+// case-insensitively.
+var DeliveryCountKeys = []string{"deliverycount", "x-delivery-count"}
+
+// EventPublisher is the part of the Dapr client used to forward events to a
+// dead-letter topic.
+type EventPublisher interface {
+	PublishEvent(ctx context.Context, pubsubName, topicName string, data interface{}, opts ...client.PublishEventOption) error
+}
+
+// DeadLetterOptions configure forwarding of failed events to a dead-letter topic.
+type DeadLetterOptions struct {
+	Publisher EventPublisher
+	// PubsubName defaults to the pubsub the event was received from.
+	PubsubName string
+	Topic      string
+}
+
+// DeadLetterEvent is published to the dead-letter topic for a failed event.
+type DeadLetterEvent struct {
+	// Reason is the error returned by the handler.
+	Reason string `json:"reason"`
+	// Attempts is the number of in-process attempts of the last delivery.
+	Attempts int `json:"attempts"`
+	// DeliveryCount is the delivery count of the event, if known.
+	DeliveryCount int                `json:"deliveryCount,omitempty"`
+	FailedAt      time.Time          `json:"failedAt"`
+	Event         *common.TopicEvent `json:"event"`
+}
+
+// RetryOptions are the options of a Retry middleware.
+type RetryOptions struct {
+	// Attempts is the number of times the handler is called for each delivery
+	// of an event, as long as it returns an error with retry set. Defaults to 1.
+	Attempts int
+	// InitialInterval is the delay before the second attempt. It doubles with
+	// every attempt, with jitter, up to MaxInterval. Defaults to 100ms and 5s.
+	InitialInterval time.Duration
+	MaxInterval     time.Duration
+	// MaxDeliveries is the delivery count from which an event that still
+	// fails is considered a poison message, and is no longer retried by Dapr.
+	// Zero disables the check.
+	MaxDeliveries int
+	// DeliveryCount returns the delivery count of an event, if known. Defaults
+	// to reading the metadata keys in DeliveryCountKeys.
+	DeliveryCount func(e *common.TopicEvent) (int, bool)
+	// DeadLetter, if set, receives events which are not retried by Dapr.
+	// Otherwise they are dropped.
+	DeadLetter *DeadLetterOptions
+}
+
+// Retry calls a handler again in-process when it fails with retry set, before
+// leaving retries to Dapr. Events failing without retry set, or exceeding
+// MaxDeliveries, are forwarded to the dead-letter topic and acknowledged, or
+// dropped if there is none.
+type Retry struct {
+	opts RetryOptions
+}
+
+// NewRetry returns a Retry middleware.
+func NewRetry(opts RetryOptions) (*Retry, error) {
+	if opts.Attempts <= 0 {
+		opts.Attempts = 1
+	}
+	if opts.InitialInterval <= 0 {
+		opts.InitialInterval = defaultRetryInterval
+	}
+	if opts.MaxInterval <= 0 {
+		opts.MaxInterval = defaultMaxRetryInterval
+	}
+	if opts.DeliveryCount == nil {
+		opts.DeliveryCount = metadataDeliveryCount
+	}
+	if err := opts.DeadLetter.validate(); err != nil {
+		return nil, err
+	}
+	return &Retry{opts: opts}, nil
+}
+
+// metadataDeliveryCount reads the delivery count from the first of the
+// DeliveryCountKeys in the event metadata.
+func metadataDeliveryCount(e *common.TopicEvent) (int, bool) {
+	for k, v := range e.Metadata {
+		for _, key := range DeliveryCountKeys {
+			if strings.EqualFold(k, key) {
+				n, err := strconv.Atoi(v)
+				return n, err == nil
+			}
+		}
+	}
+	return 0, false
+}
+
+// Subscriber wraps next, to be registered with common.Service.AddTopicEventSubscriber.
+func (r *Retry) Subscriber(next common.TopicEventSubscriber) common.TopicEventSubscriber {
+	return common.TopicEventHandler(func(ctx context.Context, e *common.TopicEvent) (bool, error) {
+		bo := backoff.NewExponentialBackOff()
+		bo.InitialInterval = r.opts.InitialInterval
+		bo.MaxInterval = r.opts.MaxInterval
+		bo.MaxElapsedTime = 0
+
+		var attempts int
+		var retry bool
+		err := backoff.Retry(func() error {
+			attempts++
+			// State staged by a failed attempt must not be committed.
+			if tx := TransactionFromContext(ctx); tx != nil {
+				tx.reset()
+			}
+			var err error
+			if retry, err = next.Handle(ctx, e); err != nil && !retry {
+				return backoff.Permanent(err)
+			}
+			return err
+		}, backoff.WithContext(backoff.WithMaxRetries(bo, uint64(r.opts.Attempts-1)), ctx))
+		if err == nil {
+			return false, nil
+		}
+		if ctx.Err() != nil {
+			return true, err
+		}
+
+		count, known := r.opts.DeliveryCount(e)
+		poison := r.opts.MaxDeliveries > 0 && known && count >= r.opts.MaxDeliveries
+		if retry && !poison {
+			return true, err
+		}
+		if poison {
+			err = fmt.Errorf("giving up after %d deliveries: %w", count, err)
+		}
+		if r.opts.DeadLetter == nil {
+			return false, err
+		}
+		if dlErr := r.opts.DeadLetter.forward(ctx, e, err, attempts, count); dlErr != nil {
+			return true, errors.Join(err, dlErr)
+		}
+		return false, nil
+	})
+}
+
+// validate checks the options, if dead-lettering is enabled.
+func (dl *DeadLetterOptions) validate() error {
+	if dl == nil {
+		return nil
+	}
+	if dl.Publisher == nil {
+		return errors.New("dead-letter publisher required")
+	}
+	if dl.Topic == "" {
+		return errors.New("dead-letter topic required")
+	}
+	return nil
+}
+
+// forward publishes a DeadLetterEvent for e to the dead-letter topic.
+func (dl *DeadLetterOptions) forward(ctx context.Context, e *common.TopicEvent, reason error, attempts, count int) error {
+	pubsubName := dl.PubsubName
+	if pubsubName == "" {
+		pubsubName = e.PubsubName
+	}
+	err := dl.Publisher.PublishEvent(ctx, pubsubName, dl.Topic, &DeadLetterEvent{
+		Reason:        reason.Error(),
+		Attempts:      attempts,
+		DeliveryCount: count,
+		FailedAt:      time.Now().UTC(),
+		Event:         e,
+	})
+	if err != nil {
+		return fmt.Errorf("error forwarding event %s to dead-letter topic %s: %w", e.ID, dl.Topic, err)
+	}
+	return nil
+}
@@ -0,0 +1,229 @@
+/*
+Copyright 2026 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package middleware
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/dapr/go-sdk/client"
+	"github.com/dapr/go-sdk/service/common"
+	daprhttp "github.com/dapr/go-sdk/service/http"
+)
+
+type publishedEvent struct {
+	pubsubName string
+	topic      string
+	data       interface{}
+}
+
+// fakePublisher records published events.
+type fakePublisher struct {
+	lock   sync.Mutex
+	events []publishedEvent
+	err    error
+}
+
+func (f *fakePublisher) PublishEvent(ctx context.Context, pubsubName, topicName string, data interface{}, opts ...client.PublishEventOption) error {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	if f.err != nil {
+		return f.err
+	}
+	f.events = append(f.events, publishedEvent{pubsubName: pubsubName, topic: topicName, data: data})
+	return nil
+}
+
+// failingHandler fails the first failures calls with retry.
+func failingHandler(failures int, retry bool) (common.TopicEventHandler, *int) {
+	var calls int
+	return func(ctx context.Context, e *common.TopicEvent) (bool, error) {
+		calls++
+		if calls <= failures {
+			return retry, errors.New("handler failed")
+		}
+		return false, nil
+	}, &calls
+}
+
+func TestNewRetry(t *testing.T) {
+	_, err := NewRetry(RetryOptions{DeadLetter: &DeadLetterOptions{Topic: "dead"}})
+	require.Error(t, err)
+	_, err = NewRetry(RetryOptions{DeadLetter: &DeadLetterOptions{Publisher: &fakePublisher{}}})
+	require.Error(t, err)
+}
+
+func TestRetrySubscriber(t *testing.T) {
+	ctx := t.Context()
+	event := &common.TopicEvent{ID: "e1", PubsubName: "pubsub", Topic: "orders"}
+
+	t.Run("retries in process", func(t *testing.T) {
+		r, err := NewRetry(RetryOptions{Attempts: 3, InitialInterval: time.Millisecond})
+		require.NoError(t, err)
+
+		h, calls := failingHandler(2, true)
+		retry, err := r.Subscriber(h).Handle(ctx, event)
+		require.NoError(t, err)
+		assert.False(t, retry)
+		assert.Equal(t, 3, *calls)
+
+		h, calls = failingHandler(3, true)
+		retry, err = r.Subscriber(h).Handle(ctx, event)
+		require.Error(t, err)
+		assert.True(t, retry, "retries are left to Dapr")
+		assert.Equal(t, 3, *calls)
+	})
+
+	t.Run("does not retry permanent failures", func(t *testing.T) {
+		r, err := NewRetry(RetryOptions{Attempts: 3, InitialInterval: time.Millisecond})
+		require.NoError(t, err)
+
+		h, calls := failingHandler(1, false)
+		retry, err := r.Subscriber(h).Handle(ctx, event)
+		require.Error(t, err)
+		assert.False(t, retry)
+		assert.Equal(t, 1, *calls)
+	})
+
+	t.Run("forwards poison messages to the dead-letter topic", func(t *testing.T) {
+		pub := &fakePublisher{}
+		r, err := NewRetry(RetryOptions{
+			MaxDeliveries: 3,
+			DeadLetter:    &DeadLetterOptions{Publisher: pub, Topic: "orders-dead"},
+		})
+		require.NoError(t, err)
+		h, _ := failingHandler(10, true)
+		sub := r.Subscriber(h)
+
+		retry, err := sub.Handle(ctx, &common.TopicEvent{ID: "e1", PubsubName: "pubsub", Metadata: map[string]string{"DeliveryCount": "2"}})
+		require.Error(t, err)
+		assert.True(t, retry)
+		assert.Empty(t, pub.events)
+
+		poison := &common.TopicEvent{ID: "e1", PubsubName: "pubsub", Metadata: map[string]string{"DeliveryCount": "3"}}
+		retry, err = sub.Handle(ctx, poison)
+		require.NoError(t, err)
+		assert.False(t, retry)
+		require.Len(t, pub.events, 1)
+		assert.Equal(t, "pubsub", pub.events[0].pubsubName)
+		assert.Equal(t, "orders-dead", pub.events[0].topic)
+		dle, ok := pub.events[0].data.(*DeadLetterEvent)
+		require.True(t, ok)
+		assert.Contains(t, dle.Reason, "handler failed")
+		assert.Equal(t, 1, dle.Attempts)
+		assert.Equal(t, 3, dle.DeliveryCount)
+		assert.Same(t, poison, dle.Event)
+
+		pub.err = errors.New("pubsub unavailable")
+		retry, err = sub.Handle(ctx, poison)
+		require.Error(t, err)
+		assert.True(t, retry, "events are redelivered when they cannot be dead-lettered")
+	})
+
+	t.Run("drops poison messages without dead-letter topic", func(t *testing.T) {
+		r, err := NewRetry(RetryOptions{
+			MaxDeliveries: 2,
+			DeliveryCount: func(e *common.TopicEvent) (int, bool) { return 5, true },
+		})
+		require.NoError(t, err)
+		h, _ := failingHandler(1, true)
+		retry, err := r.Subscriber(h).Handle(ctx, event)
+		require.Error(t, err)
+		assert.False(t, retry)
+	})
+
+	t.Run("discards state of failed attempts", func(t *testing.T) {
+		state := newMemStateClient()
+		idem, err := NewIdempotency(state, IdempotencyOptions{StoreName: "store"})
+		require.NoError(t, err)
+		r, err := NewRetry(RetryOptions{Attempts: 2, InitialInterval: time.Millisecond})
+		require.NoError(t, err)
+
+		var calls int
+		sub := Chain(common.TopicEventHandler(func(ctx context.Context, e *common.TopicEvent) (bool, error) {
+			calls++
+			TransactionFromContext(ctx).Save("attempt", []byte{byte(calls)}, nil)
+			if calls == 1 {
+				TransactionFromContext(ctx).Save("first", []byte("x"), nil)
+				return true, errors.New("handler failed")
+			}
+			return false, nil
+		}), idem.Subscriber, r.Subscriber)
+
+		_, err = sub.Handle(ctx, event)
+		require.NoError(t, err)
+		assert.Equal(t, []byte{2}, state.get("attempt"))
+		assert.Empty(t, state.get("first"))
+	})
+}
+
+func TestChain(t *testing.T) {
+	var order []string
+	mw := func(name string) TopicMiddleware {
+		return func(next common.TopicEventSubscriber) common.TopicEventSubscriber {
+			return common.TopicEventHandler(func(ctx context.Context, e *common.TopicEvent) (bool, error) {
+				order = append(order, name)
+				return next.Handle(ctx, e)
+			})
+		}
+	}
+	sub := Chain(common.TopicEventHandler(func(ctx context.Context, e *common.TopicEvent) (bool, error) {
+		order = append(order, "handler")
+		return false, nil
+	}), mw("a"), mw("b"))
+
+	_, err := sub.Handle(t.Context(), &common.TopicEvent{})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"a", "b", "handler"}, order)
+}
+
+func TestRetryWithHTTPService(t *testing.T) {
+	pub := &fakePublisher{}
+	r, err := NewRetry(RetryOptions{
+		MaxDeliveries: 3,
+		DeadLetter:    &DeadLetterOptions{Publisher: pub, Topic: "dead"},
+	})
+	require.NoError(t, err)
+
+	mux := chi.NewRouter()
+	s := daprhttp.NewServiceWithMux("", mux)
+	h, _ := failingHandler(10, true)
+	err = s.AddTopicEventSubscriber(&common.Subscription{PubsubName: "messages", Topic: "orders", Route: "/orders"}, r.Subscriber(h))
+	require.NoError(t, err)
+
+	deliver := func(count string) string {
+		req := httptest.NewRequest(http.MethodPost, "/orders",
+			strings.NewReader(`{"specversion":"1.0","id":"e1","source":"test","type":"t","pubsubname":"messages","topic":"orders","data":"x"}`))
+		req.Header.Set("Content-Type", "application/cloudevents+json")
+		req.Header.Set("Metadata.DeliveryCount", count)
+		rr := httptest.NewRecorder()
+		mux.ServeHTTP(rr, req)
+		return rr.Body.String()
+	}
+
+	assert.Contains(t, deliver("1"), "RETRY")
+	assert.Contains(t, deliver("3"), "SUCCESS")
+	require.Len(t, pub.events, 1)
+	assert.Equal(t, "messages", pub.events[0].pubsubName)
+}
@@ -0,0 +1,100 @@
+/*
+Copyright 2026 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package middleware
+
+import (
+	"context"
+	"errors"
+	"log"
+
+	"github.com/dapr/go-sdk/client"
+	"github.com/dapr/go-sdk/schema"
+	"github.com/dapr/go-sdk/service/common"
+)
+
+// ValidationOptions are the options of a Validation middleware.
+type ValidationOptions struct {
+	// Registry holds the schemas events are validated against.
+	Registry *schema.Registry
+	// DeadLetter, if set, receives events which do not match their schema.
+	// Otherwise they are dropped.
+	DeadLetter *DeadLetterOptions
+	// Logger receives the errors Handler can not return. Defaults to a logger
+	// writing to stdout.
+	Logger *log.Logger
+}
+
+// Validation validates the data of topic events against their schema before
+// calling the handler. Invalid events are never retried, as redelivering them
+// cannot succeed: they are forwarded to the dead-letter topic and acknowledged,
+// or dropped if there is none. Events without a schema are passed through.
+type Validation struct {
+	opts ValidationOptions
+}
+
+// NewValidation returns a Validation middleware.
+func NewValidation(opts ValidationOptions) (*Validation, error) {
+	if opts.Registry == nil {
+		return nil, errors.New("schema registry required")
+	}
+	if err := opts.DeadLetter.validate(); err != nil {
+		return nil, err
+	}
+	if opts.Logger == nil {
+		opts.Logger = logger
+	}
+	return &Validation{opts: opts}, nil
+}
+
+// Subscriber wraps next, to be registered with common.Service.AddTopicEventSubscriber.
+// It can also be passed to the UseTopicEventMiddleware method of a service to
+// validate the events of every topic handler added afterwards.
+func (v *Validation) Subscriber(next common.TopicEventSubscriber) common.TopicEventSubscriber {
+	return common.TopicEventHandler(func(ctx context.Context, e *common.TopicEvent) (bool, error) {
+		err := v.validate(ctx, e)
+		if err == nil {
+			return next.Handle(ctx, e)
+		}
+		if v.opts.DeadLetter == nil {
+			return false, err
+		}
+		if dlErr := v.opts.DeadLetter.forward(ctx, e, err, 0, 0); dlErr != nil {
+			return true, errors.Join(err, dlErr)
+		}
+		return false, nil
+	})
+}
+
+// Handler wraps next, to be used with client.SubscribeWithContextHandler.
+func (v *Validation) Handler(next client.SubscriptionContextHandleFunction) client.SubscriptionContextHandleFunction {
+	return func(ctx context.Context, e *common.TopicEvent) common.SubscriptionResponseStatus {
+		err := v.validate(ctx, e)
+		if err == nil {
+			return next(ctx, e)
+		}
+		if v.opts.DeadLetter == nil {
+			v.opts.Logger.Printf("dropping event %s: %v", e.ID, err)
+			return common.SubscriptionResponseStatusDrop
+		}
+		if dlErr := v.opts.DeadLetter.forward(ctx, e, err, 0, 0); dlErr != nil {
+			v.opts.Logger.Printf("error handling invalid event %s: %v", e.ID, dlErr)
+			return common.SubscriptionResponseStatusRetry
+		}
+		return common.SubscriptionResponseStatusSuccess
+	}
+}
+
+func (v *Validation) validate(ctx context.Context, e *common.TopicEvent) error {
+	return v.opts.Registry.Validate(ctx, e.PubsubName, e.Topic, e.DataSchema, e.RawData)
+}
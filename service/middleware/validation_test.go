@@ -0,0 +1,168 @@
+/*
+Copyright 2026 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package middleware
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/dapr/go-sdk/schema"
+	"github.com/dapr/go-sdk/service/common"
+	daprhttp "github.com/dapr/go-sdk/service/http"
+)
+
+func orderRegistry(t *testing.T) *schema.Registry {
+	t.Helper()
+	v, err := schema.JSONSchema([]byte(`{"type":"object","required":["id"]}`))
+	require.NoError(t, err)
+	r := &schema.Registry{}
+	r.RegisterTopic("pubsub", "orders", v)
+	return r
+}
+
+func TestNewValidation(t *testing.T) {
+	_, err := NewValidation(ValidationOptions{})
+	require.Error(t, err)
+	_, err = NewValidation(ValidationOptions{Registry: &schema.Registry{}, DeadLetter: &DeadLetterOptions{Topic: "dead"}})
+	require.Error(t, err)
+}
+
+func TestValidationSubscriber(t *testing.T) {
+	ctx := t.Context()
+	valid := &common.TopicEvent{ID: "e1", PubsubName: "pubsub", Topic: "orders", RawData: []byte(`{"id":1}`)}
+	invalid := &common.TopicEvent{ID: "e2", PubsubName: "pubsub", Topic: "orders", RawData: []byte(`{}`)}
+
+	var calls int
+	handler := common.TopicEventHandler(func(ctx context.Context, e *common.TopicEvent) (bool, error) {
+		calls++
+		return false, nil
+	})
+
+	t.Run("drops invalid events", func(t *testing.T) {
+		v, err := NewValidation(ValidationOptions{Registry: orderRegistry(t)})
+		require.NoError(t, err)
+		sub := v.Subscriber(handler)
+
+		calls = 0
+		retry, err := sub.Handle(ctx, valid)
+		require.NoError(t, err)
+		assert.False(t, retry)
+		assert.Equal(t, 1, calls)
+
+		retry, err = sub.Handle(ctx, invalid)
+		var verr *schema.ValidationError
+		require.ErrorAs(t, err, &verr)
+		assert.False(t, retry)
+		assert.Equal(t, 1, calls)
+	})
+
+	t.Run("forwards invalid events to the dead-letter topic", func(t *testing.T) {
+		pub := &fakePublisher{}
+		v, err := NewValidation(ValidationOptions{
+			Registry:   orderRegistry(t),
+			DeadLetter: &DeadLetterOptions{Publisher: pub, Topic: "orders-invalid"},
+		})
+		require.NoError(t, err)
+		sub := v.Subscriber(handler)
+
+		calls = 0
+		retry, err := sub.Handle(ctx, invalid)
+		require.NoError(t, err)
+		assert.False(t, retry)
+		assert.Zero(t, calls)
+		require.Len(t, pub.events, 1)
+		assert.Equal(t, "orders-invalid", pub.events[0].topic)
+		dle, ok := pub.events[0].data.(*DeadLetterEvent)
+		require.True(t, ok)
+		assert.Contains(t, dle.Reason, "does not match schema")
+		assert.Same(t, invalid, dle.Event)
+
+		pub.err = errors.New("pubsub unavailable")
+		retry, err = sub.Handle(ctx, invalid)
+		require.Error(t, err)
+		assert.True(t, retry)
+	})
+}
+
+func TestValidationHandler(t *testing.T) {
+	ctx := t.Context()
+	handler := func(ctx context.Context, e *common.TopicEvent) common.SubscriptionResponseStatus {
+		return common.SubscriptionResponseStatusSuccess
+	}
+	invalid := &common.TopicEvent{ID: "e2", PubsubName: "pubsub", Topic: "orders", RawData: []byte(`[]`)}
+
+	var logs bytes.Buffer
+	l := log.New(&logs, "", 0)
+
+	v, err := NewValidation(ValidationOptions{Registry: orderRegistry(t), Logger: l})
+	require.NoError(t, err)
+	assert.Equal(t, common.SubscriptionResponseStatusDrop, v.Handler(handler)(ctx, invalid))
+	assert.Contains(t, logs.String(), "dropping event e2: ")
+
+	pub := &fakePublisher{}
+	v, err = NewValidation(ValidationOptions{Registry: orderRegistry(t), DeadLetter: &DeadLetterOptions{Publisher: pub, Topic: "dead"}, Logger: l})
+	require.NoError(t, err)
+	assert.Equal(t, common.SubscriptionResponseStatusSuccess, v.Handler(handler)(ctx, invalid))
+	assert.Len(t, pub.events, 1)
+	pub.err = errors.New("pubsub unavailable")
+	assert.Equal(t, common.SubscriptionResponseStatusRetry, v.Handler(handler)(ctx, invalid))
+	assert.Contains(t, logs.String(), "error handling invalid event e2: ")
+}
+
+func TestValidationWithHTTPService(t *testing.T) {
+	v, err := NewValidation(ValidationOptions{Registry: orderRegistry(t)})
+	require.NoError(t, err)
+
+	sub := &common.Subscription{PubsubName: "pubsub", Topic: "orders", Route: "/orders"}
+	handler := func(ctx context.Context, e *common.TopicEvent) (bool, error) {
+		return false, nil
+	}
+
+	for name, register := range map[string]func(s common.Service) error{
+		"wrapped subscriber": func(s common.Service) error {
+			return s.AddTopicEventSubscriber(sub, v.Subscriber(common.TopicEventHandler(handler)))
+		},
+		"service middleware": func(s common.Service) error {
+			s.(common.TopicEventMiddlewareUser).UseTopicEventMiddleware(v.Subscriber)
+			return s.AddTopicEventHandler(sub, handler)
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			mux := chi.NewRouter()
+			require.NoError(t, register(daprhttp.NewServiceWithMux("", mux)))
+
+			deliver := func(data string) string {
+				req := httptest.NewRequest(http.MethodPost, "/orders",
+					strings.NewReader(`{"specversion":"1.0","id":"e1","source":"test","type":"t","pubsubname":"pubsub","topic":"orders","datacontenttype":"application/json","data":`+data+`}`))
+				req.Header.Set("Content-Type", "application/cloudevents+json")
+				rr := httptest.NewRecorder()
+				mux.ServeHTTP(rr, req)
+				return rr.Body.String()
+			}
+
+			assert.Contains(t, deliver(`{"id":1}`), "SUCCESS")
+			assert.Contains(t, deliver(`{"total":1}`), "DROP")
+		})
+	}
+}
@@ -0,0 +1,139 @@
+/*
+Copyright 2026 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package routing compiles and evaluates the CEL match expressions of topic
+// routing rules the way Dapr does, so that expressions are checked when
+// handlers are added and routing can be tested without a sidecar.
+package routing
+
+import (
+	"encoding/json"
+	"fmt"
+	"sync"
+
+	"github.com/google/cel-go/cel"
+
+	"github.com/dapr/go-sdk/service/common"
+)
+
+var (
+	envOnce sync.Once
+	env     *cel.Env
+	envErr  error
+)
+
+// celEnv returns the environment of match expressions, which, as in Dapr,
+// declares the CloudEvent envelope as the event variable.
+func celEnv() (*cel.Env, error) {
+	envOnce.Do(func() {
+		env, envErr = cel.NewEnv(cel.Variable("event", cel.MapType(cel.StringType, cel.DynType)))
+	})
+	return env, envErr
+}
+
+// Match is a compiled match expression.
+type Match struct {
+	expr    string
+	program cel.Program
+}
+
+// Compile parses and type-checks a match expression, which must evaluate to
+// a bool.
+func Compile(expr string) (*Match, error) {
+	e, err := celEnv()
+	if err != nil {
+		return nil, fmt.Errorf("error creating CEL environment: %w", err)
+	}
+	ast, iss := e.Compile(expr)
+	if iss.Err() != nil {
+		return nil, fmt.Errorf("invalid match expression %q: %w", expr, iss.Err())
+	}
+	if t := ast.OutputType(); !t.IsExactType(cel.BoolType) && !t.IsExactType(cel.DynType) {
+		return nil, fmt.Errorf("invalid match expression %q: evaluates to %s, want bool", expr, t)
+	}
+	program, err := e.Program(ast)
+	if err != nil {
+		return nil, fmt.Errorf("invalid match expression %q: %w", expr, err)
+	}
+	return &Match{expr: expr, program: program}, nil
+}
+
+// String returns the expression of m.
+func (m *Match) String() string {
+	return m.expr
+}
+
+// Eval reports whether envelope, a CloudEvent as returned by Envelope,
+// matches the expression. Evaluation fails, as in Dapr, when the expression
+// refers to attributes the event does not have.
+func (m *Match) Eval(envelope map[string]any) (bool, error) {
+	out, _, err := m.program.Eval(map[string]any{"event": envelope})
+	if err != nil {
+		return false, fmt.Errorf("error evaluating match expression %q: %w", m.expr, err)
+	}
+	matched, ok := out.Value().(bool)
+	if !ok {
+		return false, fmt.Errorf("match expression %q evaluated to %v, want bool", m.expr, out.Value())
+	}
+	return matched, nil
+}
+
+// Envelope returns e as the CloudEvent envelope match expressions are
+// evaluated against, with its data and extensions as decoded from JSON.
+func Envelope(e *common.TopicEvent) (map[string]any, error) {
+	b, err := json.Marshal(e)
+	if err != nil {
+		return nil, fmt.Errorf("error encoding topic event: %w", err)
+	}
+	var envelope map[string]any
+	if err = json.Unmarshal(b, &envelope); err != nil {
+		return nil, fmt.Errorf("error decoding topic event: %w", err)
+	}
+	// Metadata are not CloudEvent attributes, but the metadata of the request.
+	delete(envelope, "metadata")
+
+	if len(e.Extensions) > 0 {
+		if b, err = json.Marshal(e.Extensions); err != nil {
+			return nil, fmt.Errorf("error encoding topic event extensions: %w", err)
+		}
+		var extensions map[string]any
+		if err = json.Unmarshal(b, &extensions); err != nil {
+			return nil, fmt.Errorf("error decoding topic event extensions: %w", err)
+		}
+		for k, v := range extensions {
+			if _, ok := envelope[k]; !ok {
+				envelope[k] = v
+			}
+		}
+	}
+	return envelope, nil
+}
+
+// Router is implemented by the HTTP and gRPC services, which route topic
+// events with the rules of their subscriptions.
+type Router interface {
+	TopicEventRoute(e *common.TopicEvent) (string, error)
+}
+
+// Route returns the route Dapr selects for e, given the subscriptions
+// registered with s: the path of the first routing rule matching the event,
+// in priority order, or the default route. The route is empty when no rule
+// matches and there is no default route, in which case Dapr drops the event.
+// The PubsubName and Topic of e select the subscription.
+func Route(s common.Service, e *common.TopicEvent) (string, error) {
+	r, ok := s.(Router)
+	if !ok {
+		return "", fmt.Errorf("service %T does not route topic events", s)
+	}
+	return r.TopicEventRoute(e)
+}
@@ -0,0 +1,134 @@
+/*
+Copyright 2026 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package routing_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/dapr/go-sdk/service/common"
+	daprd "github.com/dapr/go-sdk/service/grpc"
+	daprhttp "github.com/dapr/go-sdk/service/http"
+	"github.com/dapr/go-sdk/service/routing"
+)
+
+func handler(ctx context.Context, e *common.TopicEvent) (bool, error) {
+	return false, nil
+}
+
+func TestCompile(t *testing.T) {
+	for _, expr := range []string{
+		`event.type == "order"`,
+		`event.data.total > 100.0 && event.source.startsWith("checkout")`,
+		`has(event.data.priority)`,
+		`event.tenant`,
+	} {
+		m, err := routing.Compile(expr)
+		require.NoError(t, err, expr)
+		assert.Equal(t, expr, m.String())
+	}
+
+	_, err := routing.Compile(`event.type = "order"`)
+	require.ErrorContains(t, err, `invalid match expression "event.type = \"order\""`)
+	_, err = routing.Compile(`order.type == "order"`)
+	require.ErrorContains(t, err, "undeclared reference to 'order'")
+	_, err = routing.Compile(`event.type + "order"`)
+	require.ErrorContains(t, err, "evaluates to string, want bool")
+}
+
+func TestEval(t *testing.T) {
+	envelope, err := routing.Envelope(&common.TopicEvent{
+		ID:         "1",
+		Type:       "order",
+		Source:     "checkout",
+		Data:       map[string]any{"total": 120, "items": []string{"a", "b"}},
+		Metadata:   map[string]string{"key": "value"},
+		Extensions: map[string]any{"tenant": "acme", "type": "ignored"},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "order", envelope["type"])
+	assert.Equal(t, "acme", envelope["tenant"])
+	assert.NotContains(t, envelope, "metadata")
+
+	for expr, want := range map[string]bool{
+		`event.type == "order"`:                     true,
+		`event.data.total > 100.0`:                  true,
+		`size(event.data.items) == 3`:               false,
+		`event.tenant == "acme"`:                    true,
+		`has(event.data.priority)`:                  false,
+		`event.source.startsWith("check")`:          true,
+		`"b" in event.data.items && event.id != ""`: true,
+	} {
+		m, err := routing.Compile(expr)
+		require.NoError(t, err, expr)
+		got, err := m.Eval(envelope)
+		require.NoError(t, err, expr)
+		assert.Equal(t, want, got, expr)
+	}
+
+	m, err := routing.Compile(`event.data.priority == "high"`)
+	require.NoError(t, err)
+	_, err = m.Eval(envelope)
+	require.ErrorContains(t, err, "no such key: priority")
+
+	m, err = routing.Compile(`event.type`)
+	require.NoError(t, err)
+	_, err = m.Eval(envelope)
+	require.ErrorContains(t, err, "want bool")
+}
+
+func TestRoute(t *testing.T) {
+	subs := []*common.Subscription{
+		{PubsubName: "messages", Topic: "orders", Route: "/orders"},
+		{PubsubName: "messages", Topic: "orders", Route: "/orders/large", Match: `event.data.total > 100.0`, Priority: 2},
+		{PubsubName: "messages", Topic: "orders", Route: "/orders/eu", Match: `event.data.region == "eu"`, Priority: 1},
+		{PubsubName: "messages", Topic: "audit", Route: "/audit/login", Match: `event.type == "login"`},
+		{PubsubName: "other", Topic: "any", Route: "/other", DisableTopicValidation: true},
+	}
+	services := map[string]common.Service{
+		"http": daprhttp.NewService(":0"),
+		"grpc": daprd.NewServiceWithListener(nil),
+	}
+	for name, s := range services {
+		t.Run(name, func(t *testing.T) {
+			for _, sub := range subs {
+				require.NoError(t, s.AddTopicEventHandler(sub, handler))
+			}
+
+			tests := []struct {
+				event *common.TopicEvent
+				route string
+			}{
+				{&common.TopicEvent{PubsubName: "messages", Topic: "orders", Data: map[string]any{"region": "eu", "total": 500}}, "/orders/eu"},
+				{&common.TopicEvent{PubsubName: "messages", Topic: "orders", Data: map[string]any{"region": "us", "total": 500}}, "/orders/large"},
+				{&common.TopicEvent{PubsubName: "messages", Topic: "orders", Data: map[string]any{"region": "us", "total": 5}}, "/orders"},
+				{&common.TopicEvent{PubsubName: "messages", Topic: "audit", Type: "logout"}, ""},
+				{&common.TopicEvent{PubsubName: "other", Topic: "anything"}, "/other"},
+			}
+			for _, tt := range tests {
+				route, err := routing.Route(s, tt.event)
+				require.NoError(t, err)
+				assert.Equal(t, tt.route, route)
+			}
+
+			_, err := routing.Route(s, &common.TopicEvent{PubsubName: "messages", Topic: "orders", Data: map[string]any{"total": 5}})
+			require.ErrorContains(t, err, "no such key: region")
+			_, err = routing.Route(s, &common.TopicEvent{PubsubName: "messages", Topic: "payments"})
+			require.EqualError(t, err, "no subscription for topic payments on pubsub messages")
+		})
+	}
+}